@@ -0,0 +1,67 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/containerd/containerd/log"
+	"github.com/containerd/containerd/runtime/v2/shim"
+	"golang.org/x/sys/unix"
+
+	"github.com/firecracker-microvm/firecracker-containerd/internal"
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+	vmclock "github.com/firecracker-microvm/firecracker-containerd/proto/service/vmclock/ttrpc"
+)
+
+// clockHandler implements VMClockService that sets the clock of the VM, which stops while the VM
+// is paused or snapshotted.
+type clockHandler struct {
+	shimCtx   context.Context
+	publisher shim.Publisher
+
+	// settimeofday sets the clock of the VM, it is replaced in tests
+	settimeofday func(*unix.Timeval) error
+}
+
+var _ vmclock.VMClockService = &clockHandler{}
+
+// SyncClock sets the clock of the VM to the time of the host and publishes the skew it corrected.
+func (h *clockHandler) SyncClock(ctx context.Context, req *vmclock.SyncClockRequest) (*vmclock.SyncClockResponse, error) {
+	logger := log.G(ctx).WithField("reason", req.Reason)
+	defer logPanicAndDie(logger)
+
+	hostTime := time.Unix(0, req.HostTimeUnixNano)
+	skew := hostTime.Sub(time.Now())
+
+	tv := unix.NsecToTimeval(hostTime.UnixNano())
+	if err := h.settimeofday(&tv); err != nil {
+		return nil, fmt.Errorf("failed to set the clock: %w", err)
+	}
+	logger.WithField("skew", skew).Info("synchronized the clock with the host")
+
+	err := h.publisher.Publish(h.shimCtx, internal.ClockSyncEventName, &proto.VMClockSync{
+		VMID:            req.VMID,
+		Reason:          req.Reason,
+		SkewNanoseconds: skew.Nanoseconds(),
+	})
+	if err != nil {
+		// the clock is set already, the event is only informational
+		logger.WithError(err).Warn("failed to publish clock sync event")
+	}
+
+	return &vmclock.SyncClockResponse{SkewNanoseconds: skew.Nanoseconds()}, nil
+}
@@ -0,0 +1,90 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/containerd/containerd/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+
+	"github.com/firecracker-microvm/firecracker-containerd/internal"
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+	vmclock "github.com/firecracker-microvm/firecracker-containerd/proto/service/vmclock/ttrpc"
+)
+
+type recordingPublisher struct {
+	topics []string
+	events []events.Event
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, topic string, event events.Event) error {
+	p.topics = append(p.topics, topic)
+	p.events = append(p.events, event)
+	return nil
+}
+
+func (p *recordingPublisher) Close() error {
+	return nil
+}
+
+func TestSyncClock(t *testing.T) {
+	var set *unix.Timeval
+	publisher := &recordingPublisher{}
+	h := &clockHandler{
+		shimCtx:   context.Background(),
+		publisher: publisher,
+		settimeofday: func(tv *unix.Timeval) error {
+			set = tv
+			return nil
+		},
+	}
+
+	hostTime := time.Now().Add(time.Hour)
+	resp, err := h.SyncClock(context.Background(), &vmclock.SyncClockRequest{
+		VMID:             "vm",
+		Reason:           "resumed",
+		HostTimeUnixNano: hostTime.UnixNano(),
+	})
+	require.NoError(t, err)
+
+	require.NotNil(t, set)
+	assert.Equal(t, unix.NsecToTimeval(hostTime.UnixNano()), *set)
+	assert.InDelta(t, time.Hour.Nanoseconds(), resp.SkewNanoseconds, float64(time.Minute.Nanoseconds()))
+
+	require.Equal(t, []string{internal.ClockSyncEventName}, publisher.topics)
+	assert.Equal(t, "vm", publisher.events[0].(*proto.VMClockSync).VMID)
+	assert.Equal(t, "resumed", publisher.events[0].(*proto.VMClockSync).Reason)
+	assert.Equal(t, resp.SkewNanoseconds, publisher.events[0].(*proto.VMClockSync).SkewNanoseconds)
+}
+
+func TestSyncClockFailure(t *testing.T) {
+	publisher := &recordingPublisher{}
+	h := &clockHandler{
+		shimCtx:   context.Background(),
+		publisher: publisher,
+		settimeofday: func(*unix.Timeval) error {
+			return unix.EPERM
+		},
+	}
+
+	_, err := h.SyncClock(context.Background(), &vmclock.SyncClockRequest{HostTimeUnixNano: time.Now().UnixNano()})
+	assert.True(t, errors.Is(err, unix.EPERM))
+	assert.Empty(t, publisher.topics, "no event is published if the clock wasn't set")
+}
@@ -0,0 +1,270 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/containerd/containerd/log"
+	"github.com/containerd/containerd/protobuf/types"
+	"github.com/containerd/continuity/fs"
+	"github.com/firecracker-microvm/firecracker-go-sdk/vsock"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	vmcopy "github.com/firecracker-microvm/firecracker-containerd/proto/service/vmcopy/ttrpc"
+)
+
+// vmCopyConnectTimeout is the time the host has to connect to the port of a copy request.
+const vmCopyConnectTimeout = 10 * time.Second
+
+// copyHandler implements VMCopyService that moves tar archives between the host and the VM.
+type copyHandler struct{}
+
+var _ vmcopy.VMCopyService = &copyHandler{}
+
+// Extract extracts the archive the host sends into the requested directory.
+func (h *copyHandler) Extract(ctx context.Context, req *vmcopy.ExtractRequest) (*types.Empty, error) {
+	logger := log.G(ctx).WithField("path", req.Path)
+	defer logPanicAndDie(logger)
+
+	if !filepath.IsAbs(req.Path) {
+		return nil, status.Errorf(codes.InvalidArgument, "path %q is not absolute", req.Path)
+	}
+
+	if err := os.MkdirAll(req.Path, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create %q: %w", req.Path, err)
+	}
+
+	conn, err := acceptCopyConnection(ctx, logger, req.Port)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if err := extractArchive(conn, req.Path); err != nil {
+		return nil, fmt.Errorf("failed to extract archive into %q: %w", req.Path, err)
+	}
+
+	return &types.Empty{}, nil
+}
+
+// Archive sends an archive of the requested file or directory to the host.
+func (h *copyHandler) Archive(ctx context.Context, req *vmcopy.ArchiveRequest) (*types.Empty, error) {
+	logger := log.G(ctx).WithField("path", req.Path)
+	defer logPanicAndDie(logger)
+
+	if !filepath.IsAbs(req.Path) {
+		return nil, status.Errorf(codes.InvalidArgument, "path %q is not absolute", req.Path)
+	}
+
+	if _, err := os.Lstat(req.Path); err != nil {
+		if os.IsNotExist(err) {
+			return nil, status.Errorf(codes.NotFound, "%q does not exist", req.Path)
+		}
+		return nil, fmt.Errorf("failed to stat %q: %w", req.Path, err)
+	}
+
+	conn, err := acceptCopyConnection(ctx, logger, req.Port)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if err := writeArchive(conn, req.Path); err != nil {
+		return nil, fmt.Errorf("failed to archive %q: %w", req.Path, err)
+	}
+
+	return &types.Empty{}, nil
+}
+
+// acceptCopyConnection accepts the connection of the host on the given port.
+func acceptCopyConnection(ctx context.Context, logger *logrus.Entry, port uint32) (net.Conn, error) {
+	listener, err := vsock.Listener(ctx, logger, port)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on vsock port %d: %w", port, err)
+	}
+	defer listener.Close()
+
+	// closing the listener unblocks Accept
+	timer := time.AfterFunc(vmCopyConnectTimeout, func() { listener.Close() })
+	defer timer.Stop()
+
+	conn, err := listener.Accept()
+	if err != nil {
+		return nil, status.Errorf(codes.DeadlineExceeded, "host did not connect to vsock port %d: %v", port, err)
+	}
+	return conn, nil
+}
+
+// writeArchive writes a tar archive of path to w. The entries are named relative to the parent
+// directory of path, so that path itself is the root entry of the archive.
+func writeArchive(w io.Writer, path string) error {
+	tw := tar.NewWriter(w)
+	parent := filepath.Dir(path)
+
+	err := filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode()&(os.ModeSocket|os.ModeDevice|os.ModeCharDevice|os.ModeNamedPipe) != 0 {
+			// sockets cannot be archived, and devices and FIFOs are not extracted
+			return nil
+		}
+
+		var link string
+		if info.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(file); err != nil {
+				return err
+			}
+		}
+
+		hdr, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return fmt.Errorf("failed to create header of %q: %w", file, err)
+		}
+		name, err := filepath.Rel(parent, file)
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(name)
+		if info.IsDir() {
+			hdr.Name += "/"
+		}
+
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return tw.Close()
+}
+
+// extractArchive extracts the tar archive read from r into dest, keeping the ownership, mode and
+// modification time of its entries. Entries cannot be written outside of dest, even through
+// symlinks.
+func extractArchive(r io.Reader, dest string) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read archive: %w", err)
+		}
+
+		name := filepath.Clean(string(filepath.Separator) + hdr.Name)
+		if name == string(filepath.Separator) {
+			continue
+		}
+		// the parent is resolved within dest, but not the entry itself, which may be a symlink
+		parent, err := fs.RootPath(dest, filepath.Dir(name))
+		if err != nil {
+			return fmt.Errorf("failed to resolve %q: %w", hdr.Name, err)
+		}
+		if err := os.MkdirAll(parent, 0755); err != nil {
+			return err
+		}
+		target := filepath.Join(parent, filepath.Base(name))
+
+		if err := extractEntry(tr, hdr, dest, target); err != nil {
+			return fmt.Errorf("failed to extract %q: %w", hdr.Name, err)
+		}
+	}
+}
+
+func extractEntry(tr *tar.Reader, hdr *tar.Header, dest, target string) error {
+	switch hdr.Typeflag {
+	case tar.TypeDir:
+		if info, err := os.Lstat(target); err == nil && !info.IsDir() {
+			// a symlink replaced by a directory must not be followed by the chmod below
+			if err := os.Remove(target); err != nil {
+				return err
+			}
+		}
+		if err := os.Mkdir(target, 0755); err != nil && !os.IsExist(err) {
+			return err
+		}
+	case tar.TypeReg:
+		// an existing file is replaced rather than written to, as it may be a symlink pointing
+		// outside of dest
+		if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		f, err := os.OpenFile(target, os.O_CREATE|os.O_EXCL|os.O_WRONLY|syscall.O_NOFOLLOW, 0600)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(f, tr)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+	case tar.TypeSymlink:
+		if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		if err := os.Symlink(hdr.Linkname, target); err != nil {
+			return err
+		}
+		return os.Lchown(target, hdr.Uid, hdr.Gid)
+	case tar.TypeLink:
+		linkTarget, err := fs.RootPath(dest, hdr.Linkname)
+		if err != nil {
+			return err
+		}
+		if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		// the link shares the ownership and mode of its target
+		return os.Link(linkTarget, target)
+	default:
+		return fmt.Errorf("unsupported entry type %q", hdr.Typeflag)
+	}
+
+	if err := os.Lchown(target, hdr.Uid, hdr.Gid); err != nil {
+		return err
+	}
+	if err := os.Chmod(target, hdr.FileInfo().Mode()&(os.ModePerm|os.ModeSetuid|os.ModeSetgid|os.ModeSticky)); err != nil {
+		return err
+	}
+	return os.Chtimes(target, hdr.ModTime, hdr.ModTime)
+}
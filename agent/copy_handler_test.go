@@ -0,0 +1,125 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+func TestArchiveRoundTrip(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "logs")
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "app"), 0750))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "app", "out.log"), []byte("hello"), 0640))
+	require.NoError(t, os.Symlink("app/out.log", filepath.Join(src, "latest")))
+
+	var archive bytes.Buffer
+	require.NoError(t, writeArchive(&archive, src))
+
+	dest := t.TempDir()
+	require.NoError(t, extractArchive(&archive, dest))
+
+	contents, err := os.ReadFile(filepath.Join(dest, "logs", "app", "out.log"))
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(contents))
+
+	info, err := os.Stat(filepath.Join(dest, "logs", "app", "out.log"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0640), info.Mode().Perm())
+
+	info, err = os.Stat(filepath.Join(dest, "logs", "app"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0750), info.Mode().Perm())
+
+	link, err := os.Readlink(filepath.Join(dest, "logs", "latest"))
+	require.NoError(t, err)
+	assert.Equal(t, "app/out.log", link)
+}
+
+func TestExtractArchiveStaysInDestination(t *testing.T) {
+	outside := t.TempDir()
+	dest := t.TempDir()
+
+	var archive bytes.Buffer
+	tw := tar.NewWriter(&archive)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "escape", Typeflag: tar.TypeSymlink, Linkname: outside}))
+	for _, name := range []string{"../evil", "escape/evil"} {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0644, Size: 4}))
+		_, err := tw.Write([]byte("evil"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+
+	require.NoError(t, extractArchive(&archive, dest))
+
+	entries, err := os.ReadDir(outside)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "no entry must be written outside of the destination")
+	assert.FileExists(t, filepath.Join(dest, "evil"))
+}
+
+func TestExtractArchiveReplacesSymlinks(t *testing.T) {
+	outside := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(outside, "file"), []byte("outside"), 0644))
+	dest := t.TempDir()
+
+	var archive bytes.Buffer
+	tw := tar.NewWriter(&archive)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "file", Typeflag: tar.TypeSymlink, Linkname: filepath.Join(outside, "file")}))
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "file", Typeflag: tar.TypeReg, Mode: 0600, Size: 4}))
+	_, err := tw.Write([]byte("evil"))
+	require.NoError(t, err)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "dir", Typeflag: tar.TypeSymlink, Linkname: outside}))
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "dir/", Typeflag: tar.TypeDir, Mode: 0700}))
+	require.NoError(t, tw.Close())
+
+	require.NoError(t, extractArchive(&archive, dest))
+
+	contents, err := os.ReadFile(filepath.Join(outside, "file"))
+	require.NoError(t, err)
+	assert.Equal(t, "outside", string(contents), "the target of the symlink must not be written")
+	info, err := os.Stat(outside)
+	require.NoError(t, err)
+	assert.NotEqual(t, os.FileMode(0700), info.Mode().Perm(), "the target of the symlink must not be chmoded")
+
+	contents, err = os.ReadFile(filepath.Join(dest, "file"))
+	require.NoError(t, err)
+	assert.Equal(t, "evil", string(contents))
+	info, err = os.Lstat(filepath.Join(dest, "dir"))
+	require.NoError(t, err)
+	assert.True(t, info.IsDir())
+}
+
+func TestArchiveRoundTripSkipsSpecialFiles(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "run")
+	require.NoError(t, os.Mkdir(src, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "pid"), []byte("1"), 0644))
+	require.NoError(t, unix.Mkfifo(filepath.Join(src, "fifo"), 0600))
+
+	var archive bytes.Buffer
+	require.NoError(t, writeArchive(&archive, src))
+
+	dest := t.TempDir()
+	require.NoError(t, extractArchive(&archive, dest))
+
+	assert.FileExists(t, filepath.Join(dest, "run", "pid"))
+	assert.NoFileExists(t, filepath.Join(dest, "run", "fifo"))
+}
@@ -0,0 +1,31 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"context"
+
+	agenthealth "github.com/firecracker-microvm/firecracker-containerd/proto/service/agenthealth/ttrpc"
+)
+
+// healthHandler implements AgentHealthService so that the runtime can detect an agent that
+// stopped serving requests.
+type healthHandler struct{}
+
+var _ agenthealth.AgentHealthService = &healthHandler{}
+
+// Ping answers the liveness checks of the runtime.
+func (h *healthHandler) Ping(ctx context.Context, req *agenthealth.PingRequest) (*agenthealth.PingResponse, error) {
+	return &agenthealth.PingResponse{}, nil
+}
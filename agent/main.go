@@ -36,8 +36,12 @@ import (
 	"github.com/firecracker-microvm/firecracker-containerd/eventbridge"
 	"github.com/firecracker-microvm/firecracker-containerd/internal/event"
 
+	agenthealth "github.com/firecracker-microvm/firecracker-containerd/proto/service/agenthealth/ttrpc"
 	drivemount "github.com/firecracker-microvm/firecracker-containerd/proto/service/drivemount/ttrpc"
 	ioproxy "github.com/firecracker-microvm/firecracker-containerd/proto/service/ioproxy/ttrpc"
+	vmclock "github.com/firecracker-microvm/firecracker-containerd/proto/service/vmclock/ttrpc"
+	vmcopy "github.com/firecracker-microvm/firecracker-containerd/proto/service/vmcopy/ttrpc"
+	vmexec "github.com/firecracker-microvm/firecracker-containerd/proto/service/vmexec/ttrpc"
 )
 
 const (
@@ -117,6 +121,15 @@ func main() {
 		taskManager: taskService.taskManager,
 	})
 
+	vmexec.RegisterVMExecService(server, &vmExecHandler{})
+	agenthealth.RegisterAgentHealthService(server, &healthHandler{})
+	vmcopy.RegisterVMCopyService(server, &copyHandler{})
+	vmclock.RegisterVMClockService(server, &clockHandler{
+		shimCtx:      shimCtx,
+		publisher:    eventExchange,
+		settimeofday: unix.Settimeofday,
+	})
+
 	// Run ttrpc over vsock
 
 	vsockLogger := log.G(shimCtx).WithField("port", port)
@@ -0,0 +1,248 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"syscall"
+	"time"
+
+	"github.com/containerd/console"
+	"github.com/containerd/containerd/log"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/firecracker-microvm/firecracker-containerd/internal/vm"
+	vmexec "github.com/firecracker-microvm/firecracker-containerd/proto/service/vmexec/ttrpc"
+)
+
+// vmExecIOTimeout is the time the host has to connect to the stdio of a process once Exec has
+// been called.
+const vmExecIOTimeout = 10 * time.Second
+
+// vmExecHandler implements VMExecService that runs processes in the root
+// namespaces of the VM.
+type vmExecHandler struct{}
+
+var _ vmexec.VMExecService = &vmExecHandler{}
+
+// Exec runs the requested process and returns its exit status once it has exited.
+func (h *vmExecHandler) Exec(ctx context.Context, req *vmexec.ExecRequest) (*vmexec.ExecResponse, error) {
+	logger := log.G(ctx).WithField("args", req.Args)
+	defer logPanicAndDie(logger)
+
+	if len(req.Args) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no args were specified")
+	}
+	if req.Terminal && req.StdoutPort == 0 {
+		return nil, status.Error(codes.InvalidArgument, "a terminal requires a stdout port")
+	}
+
+	cmd := exec.Command(req.Args[0], req.Args[1:]...)
+	cmd.Env = req.Env
+	cmd.Dir = req.Cwd
+	if cmd.Dir == "" {
+		cmd.Dir = "/"
+	}
+
+	proxy, childFiles, err := vmExecStdio(cmd, req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		for _, f := range childFiles {
+			f.Close()
+		}
+	}()
+
+	procCtx, procCancel := context.WithCancel(ctx)
+	defer procCancel()
+
+	initDone, copyDone := vm.StartIOProxy(procCtx, logger, proxy)
+	select {
+	case err := <-initDone:
+		if err != nil {
+			go func() { <-copyDone }()
+			return nil, fmt.Errorf("failed to initialize stdio: %w", err)
+		}
+	case <-time.After(vmExecIOTimeout):
+		go func() {
+			<-initDone
+			<-copyDone
+		}()
+		return nil, status.Error(codes.DeadlineExceeded, "timed out waiting for stdio to be connected")
+	}
+
+	if err := cmd.Start(); err != nil {
+		procCancel()
+		<-copyDone
+		return nil, fmt.Errorf("failed to start %q: %w", req.Args[0], err)
+	}
+	logger = logger.WithField("pid", cmd.Process.Pid)
+	logger.Debug("started process")
+
+	// the process holds its own copies of its stdio
+	for _, f := range childFiles {
+		f.Close()
+	}
+	childFiles = nil
+
+	var exitErr *exec.ExitError
+	if err := cmd.Wait(); err != nil && !errors.As(err, &exitErr) {
+		procCancel()
+		<-copyDone
+		return nil, fmt.Errorf("failed to wait for %q: %w", req.Args[0], err)
+	}
+
+	procCancel()
+	<-copyDone
+
+	exitStatus := uint32(cmd.ProcessState.ExitCode())
+	if ws, ok := cmd.ProcessState.Sys().(syscall.WaitStatus); ok && ws.Signaled() {
+		exitStatus = 128 + uint32(ws.Signal())
+	}
+	logger.WithField("exit_status", exitStatus).Debug("process exited")
+
+	return &vmexec.ExecResponse{ExitStatus: exitStatus}, nil
+}
+
+// vmExecStdio sets the stdio of cmd up and returns the proxy copying it over vsock, along with
+// the files the process is given, which the agent must close once it has started.
+func vmExecStdio(cmd *exec.Cmd, req *vmexec.ExecRequest) (vm.IOProxy, []*os.File, error) {
+	var (
+		stdin, stdout, stderr *vm.IOConnectorPair
+		childFiles            []*os.File
+		parentFiles           []*os.File
+	)
+
+	if req.Terminal {
+		pty, slavePath, err := console.NewPty()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create pty: %w", err)
+		}
+		if req.Width > 0 && req.Height > 0 {
+			if err := pty.Resize(console.WinSize{Width: uint16(req.Width), Height: uint16(req.Height)}); err != nil {
+				pty.Close()
+				return nil, nil, fmt.Errorf("failed to resize pty: %w", err)
+			}
+		}
+
+		slave, err := os.OpenFile(slavePath, os.O_RDWR|syscall.O_NOCTTY, 0)
+		if err != nil {
+			pty.Close()
+			return nil, nil, fmt.Errorf("failed to open pty %q: %w", slavePath, err)
+		}
+		childFiles = append(childFiles, slave)
+
+		cmd.Stdin, cmd.Stdout, cmd.Stderr = slave, slave, slave
+		cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true, Setctty: true}
+
+		// the pty is closed once its output has been copied, so the stdin copy must not close it
+		if req.StdinPort != 0 {
+			stdin = &vm.IOConnectorPair{
+				ReadConnector:  vm.VSockAcceptConnector(req.StdinPort),
+				WriteConnector: streamConnector(nopCloser{pty}),
+			}
+		}
+		stdout = &vm.IOConnectorPair{
+			ReadConnector:  streamConnector(ptyMaster{pty}),
+			WriteConnector: vm.VSockAcceptConnector(req.StdoutPort),
+		}
+
+		return vm.NewIOConnectorProxy(stdin, stdout, nil), childFiles, nil
+	}
+
+	if req.StdinPort != 0 {
+		r, w, err := os.Pipe()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create stdin pipe: %w", err)
+		}
+		cmd.Stdin = r
+		childFiles = append(childFiles, r)
+		parentFiles = append(parentFiles, w)
+		stdin = &vm.IOConnectorPair{
+			ReadConnector:  vm.VSockAcceptConnector(req.StdinPort),
+			WriteConnector: streamConnector(w),
+		}
+	}
+
+	for _, output := range []struct {
+		port   uint32
+		stream *io.Writer
+		pair   **vm.IOConnectorPair
+	}{
+		{req.StdoutPort, &cmd.Stdout, &stdout},
+		{req.StderrPort, &cmd.Stderr, &stderr},
+	} {
+		if output.port == 0 {
+			continue
+		}
+
+		r, w, err := os.Pipe()
+		if err != nil {
+			for _, f := range append(childFiles, parentFiles...) {
+				f.Close()
+			}
+			return nil, nil, fmt.Errorf("failed to create output pipe: %w", err)
+		}
+		*output.stream = w
+		childFiles = append(childFiles, w)
+		parentFiles = append(parentFiles, r)
+		*output.pair = &vm.IOConnectorPair{
+			ReadConnector:  streamConnector(r),
+			WriteConnector: vm.VSockAcceptConnector(output.port),
+		}
+	}
+
+	return vm.NewIOConnectorProxy(stdin, stdout, stderr), childFiles, nil
+}
+
+// streamConnector returns an IOConnector for a stream which is already open.
+func streamConnector(stream io.ReadWriteCloser) vm.IOConnector {
+	return func(procCtx context.Context, logger *logrus.Entry) <-chan vm.IOConnectorResult {
+		returnCh := make(chan vm.IOConnectorResult, 1)
+		defer close(returnCh)
+
+		returnCh <- vm.IOConnectorResult{ReadWriteCloser: stream}
+		return returnCh
+	}
+}
+
+type nopCloser struct {
+	io.ReadWriter
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
+
+// ptyMaster reports the end of the output of a pty as io.EOF rather than the EIO its reads
+// fail with once the process side of the pty is closed.
+type ptyMaster struct {
+	console.Console
+}
+
+func (p ptyMaster) Read(b []byte) (int, error) {
+	n, err := p.Console.Read(b)
+	if errors.Is(err, syscall.EIO) {
+		return n, io.EOF
+	}
+	return n, err
+}
@@ -0,0 +1,50 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	vmexec "github.com/firecracker-microvm/firecracker-containerd/proto/service/vmexec/ttrpc"
+)
+
+func TestVMExecInvalidRequests(t *testing.T) {
+	h := &vmExecHandler{}
+	for name, req := range map[string]*vmexec.ExecRequest{
+		"no args":                 {},
+		"terminal without stdout": {Args: []string{"/bin/sh"}, Terminal: true},
+	} {
+		req := req
+		t.Run(name, func(t *testing.T) {
+			_, err := h.Exec(context.Background(), req)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+}
+
+func TestVMExecWithoutStdio(t *testing.T) {
+	h := &vmExecHandler{}
+	resp, err := h.Exec(context.Background(), &vmexec.ExecRequest{Args: []string{"/bin/sh", "-c", "exit 3"}})
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(3), resp.ExitStatus)
+
+	resp, err = h.Exec(context.Background(), &vmexec.ExecRequest{Args: []string{"/bin/sh", "-c", "kill -TERM $$"}})
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(128+15), resp.ExitStatus, "a signaled process must exit with 128+signal")
+}
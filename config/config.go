@@ -27,15 +27,16 @@ import (
 const (
 	// ConfigPathEnvName is the name of the environment variable used to
 	// overwrite the default runtime config path
-	ConfigPathEnvName  = "FIRECRACKER_CONTAINERD_RUNTIME_CONFIG_PATH"
-	defaultConfigPath  = "/etc/containerd/firecracker-runtime.json"
-	defaultKernelArgs  = "console=ttyS0 noapic reboot=k panic=1 pci=off nomodules rw"
-	defaultFilesPath   = "/var/lib/firecracker-containerd/runtime/"
-	defaultKernelPath  = defaultFilesPath + "default-vmlinux.bin"
-	defaultRootfsPath  = defaultFilesPath + "default-rootfs.img"
-	defaultCPUTemplate = models.CPUTemplateT2
-	defaultShimBaseDir = "/var/lib/firecracker-containerd/shim-base"
-	runcConfigPath     = "/etc/containerd/firecracker-runc-config.json"
+	ConfigPathEnvName    = "FIRECRACKER_CONTAINERD_RUNTIME_CONFIG_PATH"
+	defaultConfigPath    = "/etc/containerd/firecracker-runtime.json"
+	defaultKernelArgs    = "console=ttyS0 noapic reboot=k panic=1 pci=off nomodules rw"
+	defaultFilesPath     = "/var/lib/firecracker-containerd/runtime/"
+	defaultKernelPath    = defaultFilesPath + "default-vmlinux.bin"
+	defaultRootfsPath    = defaultFilesPath + "default-rootfs.img"
+	defaultCPUTemplate   = models.CPUTemplateT2
+	defaultShimBaseDir   = "/var/lib/firecracker-containerd/shim-base"
+	defaultImageCacheDir = "/var/lib/firecracker-containerd/images"
+	runcConfigPath       = "/etc/containerd/firecracker-runc-config.json"
 )
 
 // Config represents runtime configuration parameters
@@ -47,6 +48,10 @@ type Config struct {
 	CPUTemplate           string   `json:"cpu_template"`
 	LogLevels             []string `json:"log_levels"`
 	SmtEnabled            bool     `json:"smt_enabled"`
+	// CustomCPUTemplate is the custom CPU template of the VMs whose CreateVM request sets no CPU
+	// template, either the path of its JSON file or the JSON itself. It takes precedence over
+	// CPUTemplate.
+	CustomCPUTemplate string `json:"custom_cpu_template"`
 	// If a CreateVM call specifies no network interfaces and DefaultNetworkInterfaces is non-empty,
 	// the VM will default to using the network interfaces as specified here. This is especially
 	// useful when a CNI-based network interface is provided in DefaultNetworkInterfaces.
@@ -57,6 +62,23 @@ type Config struct {
 	// directory.
 	ShimBaseDir  string       `json:"shim_base_dir"`
 	JailerConfig JailerConfig `json:"jailer"`
+	// VMPools are pools of pre-booted VMs kept warm by the firecracker-control plugin. A CreateVM
+	// request matching the profile of a pool is handed one of its VMs instead of booting a new one.
+	VMPools []VMPoolConfig `json:"vm_pools"`
+	// MetricsAddress is the TCP address at which the firecracker-control plugin serves
+	// Prometheus metrics under /metrics. Metrics are not served if it is empty.
+	MetricsAddress string `json:"metrics_address"`
+	// FirecrackerLogFile configures the file under the shim directory of each VM the logs of its
+	// Firecracker VMM are copied to.
+	FirecrackerLogFile FirecrackerLogFileConfig `json:"firecracker_log_file"`
+	// AgentWatchdog configures the periodic liveness checks of the agent of each VM.
+	AgentWatchdog AgentWatchdogConfig `json:"agent_watchdog"`
+	// ImageCacheDir is the directory the kernels and root filesystems of the images requested
+	// with CreateVM are extracted to.
+	ImageCacheDir string `json:"image_cache_dir"`
+	// FirecrackerBinaries are Firecracker binaries, by name, which CreateVM requests can select
+	// instead of FirecrackerBinaryPath.
+	FirecrackerBinaries map[string]string `json:"firecracker_binaries"`
 
 	DebugHelper *debug.Helper `json:"-"`
 }
@@ -68,15 +90,54 @@ type JailerConfig struct {
 	RuncConfigPath string `json:"runc_config_path"`
 }
 
+// FirecrackerLogFileConfig configures the file the logs of a Firecracker VMM are copied to, in
+// addition to the logs of its shim. The file is disabled if MaxSizeMib is 0.
+type FirecrackerLogFileConfig struct {
+	// MaxSizeMib is the size the file is rotated at.
+	MaxSizeMib int `json:"max_size_mib"`
+	// MaxBackups is the number of rotated files kept besides the current one.
+	MaxBackups int `json:"max_backups"`
+}
+
+// AgentWatchdogConfig configures the periodic liveness checks of the agent of each VM.
+type AgentWatchdogConfig struct {
+	// IntervalSeconds is the time between two pings of the agent. The agent is not pinged if
+	// it is 0.
+	IntervalSeconds int `json:"interval_seconds"`
+	// TimeoutSeconds is the time the agent has to answer a ping. Defaults to IntervalSeconds.
+	TimeoutSeconds int `json:"timeout_seconds"`
+	// MaxMissedPings is the number of consecutive pings the agent must miss to be reported as
+	// unhealthy. Defaults to 3.
+	MaxMissedPings int `json:"max_missed_pings"`
+	// ForceTerminate forcefully terminates the VM once its agent is unhealthy.
+	ForceTerminate bool `json:"force_terminate"`
+}
+
+// VMPoolConfig describes a pool of pre-booted VMs and the profile they are booted with. Profile
+// fields left unset only match CreateVM requests leaving them unset as well, which get the
+// runtime's defaults.
+type VMPoolConfig struct {
+	// Name identifies the pool and is part of the VMID its VMs are booted with, so it has at most
+	// 29 characters.
+	Name string `json:"name"`
+	// Namespace is the containerd namespace of the pool's VMs. Defaults to "default".
+	Namespace string `json:"namespace"`
+	// Size is the number of booted, unclaimed VMs the pool is kept at.
+	Size int `json:"size"`
+
+	KernelImagePath string `json:"kernel_image_path"`
+	KernelArgs      string `json:"kernel_args"`
+	RootDrive       string `json:"root_drive"`
+	VcpuCount       uint32 `json:"vcpu_count"`
+	MemSizeMib      uint32 `json:"mem_size_mib"`
+	// ContainerCount is the number of container stub drives of the pool's VMs. Requests asking
+	// for at most that many containers match the pool.
+	ContainerCount int32 `json:"container_count"`
+}
+
 // LoadConfig loads configuration from JSON file at 'path'
 func LoadConfig(path string) (*Config, error) {
-	if path == "" {
-		path = os.Getenv(ConfigPathEnvName)
-	}
-
-	if path == "" {
-		path = defaultConfigPath
-	}
+	path = configPath(path)
 
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -88,6 +149,7 @@ func LoadConfig(path string) (*Config, error) {
 		KernelImagePath: defaultKernelPath,
 		RootDrive:       defaultRootfsPath,
 		ShimBaseDir:     defaultShimBaseDir,
+		ImageCacheDir:   defaultImageCacheDir,
 		JailerConfig: JailerConfig{
 			RuncConfigPath: runcConfigPath,
 		},
@@ -112,3 +174,17 @@ func LoadConfig(path string) (*Config, error) {
 
 	return cfg, nil
 }
+
+// configPath returns the path of the configuration file to load when path is given, which may be
+// empty to use the path of ConfigPathEnvName or the default one.
+func configPath(path string) string {
+	if path == "" {
+		path = os.Getenv(ConfigPathEnvName)
+	}
+
+	if path == "" {
+		path = defaultConfigPath
+	}
+
+	return path
+}
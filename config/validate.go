@@ -0,0 +1,396 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/containernetworking/cni/libcni"
+	models "github.com/firecracker-microvm/firecracker-go-sdk/client/models"
+	"github.com/hashicorp/go-multierror"
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/firecracker-microvm/firecracker-containerd/internal"
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+const (
+	// defaultFirecrackerBinary is the binary the SDK runs if FirecrackerBinaryPath is empty
+	defaultFirecrackerBinary = "firecracker"
+	// defaultCNIConfDir and defaultCNIBinDir are the directories the SDK looks CNI networks and
+	// plugins up in if a CNIConfiguration doesn't set them
+	defaultCNIConfDir = "/etc/cni/conf.d"
+	defaultCNIBinDir  = "/opt/cni/bin"
+)
+
+// ValidateConfig loads the configuration file at path like LoadConfig does, then validates it.
+// Unlike LoadConfig, it rejects fields it doesn't know and checks that the files and CNI networks
+// the configuration refers to exist. It returns every problem found as a *multierror.Error.
+func ValidateConfig(path string) error {
+	path = configPath(path)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config from %q: %w", path, err)
+	}
+
+	var result *multierror.Error
+	for _, field := range unknownFields(data, reflect.TypeOf(Config{}), "") {
+		result = multierror.Append(result, fmt.Errorf("%s: unknown field", field))
+	}
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		return multierror.Append(result, err)
+	}
+	if err := cfg.Validate(); err != nil {
+		result = multierror.Append(result, err)
+	}
+
+	return result.ErrorOrNil()
+}
+
+// Validate checks the settings of the configuration, and that the files and CNI networks it
+// refers to exist. It returns every problem found as a *multierror.Error, each prefixed with the
+// JSON path of its field.
+func (c *Config) Validate() error {
+	var result *multierror.Error
+	problem := func(field string, err error) {
+		result = multierror.Append(result, fmt.Errorf("%s: %w", field, err))
+	}
+
+	if err := checkExecutable(c.FirecrackerBinaryPath); err != nil {
+		problem("firecracker_binary_path", err)
+	}
+	names := make([]string, 0, len(c.FirecrackerBinaries))
+	for name := range c.FirecrackerBinaries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		field := "firecracker_binaries." + name
+		if c.FirecrackerBinaries[name] == "" {
+			problem(field, errors.New("no path was specified"))
+		} else if err := checkExecutable(c.FirecrackerBinaries[name]); err != nil {
+			problem(field, err)
+		}
+	}
+
+	if err := checkFile(c.KernelImagePath); err != nil {
+		problem("kernel_image_path", err)
+	}
+	if err := checkFile(c.RootDrive); err != nil {
+		problem("root_drive", err)
+	}
+
+	switch models.CPUTemplate(c.CPUTemplate) {
+	case "", models.CPUTemplateC3, models.CPUTemplateT2:
+	default:
+		problem("cpu_template", fmt.Errorf("unknown CPU template %q", c.CPUTemplate))
+	}
+	if c.CustomCPUTemplate != "" {
+		if err := checkCustomCPUTemplate(c.CustomCPUTemplate); err != nil {
+			problem("custom_cpu_template", err)
+		}
+	}
+
+	if !filepath.IsAbs(c.ShimBaseDir) {
+		problem("shim_base_dir", fmt.Errorf("%q is not an absolute path", c.ShimBaseDir))
+	}
+	if !filepath.IsAbs(c.ImageCacheDir) {
+		problem("image_cache_dir", fmt.Errorf("%q is not an absolute path", c.ImageCacheDir))
+	}
+
+	if c.JailerConfig.RuncBinaryPath != "" {
+		if err := checkExecutable(c.JailerConfig.RuncBinaryPath); err != nil {
+			problem("jailer.runc_binary_path", err)
+		}
+		if err := checkRuncConfig(c.JailerConfig.RuncConfigPath); err != nil {
+			problem("jailer.runc_config_path", err)
+		}
+	}
+
+	for i := range c.DefaultNetworkInterfaces {
+		field := fmt.Sprintf("default_network_interfaces[%d]", i)
+		for _, err := range checkNetworkInterface(&c.DefaultNetworkInterfaces[i]) {
+			problem(field, err)
+		}
+	}
+
+	for i := range c.DriveMounts {
+		drive := &c.DriveMounts[i]
+		field := fmt.Sprintf("drive_mounts[%d]", i)
+		if err := checkFile(drive.HostPath); err != nil {
+			problem(field+".HostPath", err)
+		}
+		if !filepath.IsAbs(drive.VMPath) {
+			problem(field+".VMPath", fmt.Errorf("%q is not an absolute path", drive.VMPath))
+		}
+	}
+
+	pools := make(map[string]bool, len(c.VMPools))
+	for i, pool := range c.VMPools {
+		field := fmt.Sprintf("vm_pools[%d]", i)
+		if pool.Name == "" {
+			problem(field+".name", errors.New("no name was specified"))
+		} else if pools[pool.Name] {
+			problem(field+".name", fmt.Errorf("pool %q is defined more than once", pool.Name))
+		}
+		pools[pool.Name] = true
+
+		if pool.Size < 0 {
+			problem(field+".size", fmt.Errorf("%d is negative", pool.Size))
+		}
+		if pool.KernelImagePath != "" {
+			if err := checkFile(pool.KernelImagePath); err != nil {
+				problem(field+".kernel_image_path", err)
+			}
+		}
+		if pool.RootDrive != "" {
+			if err := checkFile(pool.RootDrive); err != nil {
+				problem(field+".root_drive", err)
+			}
+		}
+	}
+
+	if c.MetricsAddress != "" {
+		if _, _, err := net.SplitHostPort(c.MetricsAddress); err != nil {
+			problem("metrics_address", err)
+		}
+	}
+
+	for _, setting := range []struct {
+		field string
+		value int
+	}{
+		{"firecracker_log_file.max_size_mib", c.FirecrackerLogFile.MaxSizeMib},
+		{"firecracker_log_file.max_backups", c.FirecrackerLogFile.MaxBackups},
+		{"agent_watchdog.interval_seconds", c.AgentWatchdog.IntervalSeconds},
+		{"agent_watchdog.timeout_seconds", c.AgentWatchdog.TimeoutSeconds},
+		{"agent_watchdog.max_missed_pings", c.AgentWatchdog.MaxMissedPings},
+	} {
+		if setting.value < 0 {
+			problem(setting.field, fmt.Errorf("%d is negative", setting.value))
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+// checkNetworkInterface checks that a network interface sets exactly one of a CNI and a static
+// configuration, and that the CNI network, its configuration directory and plugin directories
+// exist.
+func checkNetworkInterface(iface *proto.FirecrackerNetworkInterface) []error {
+	if (iface.CNIConfig == nil) == (iface.StaticConfig == nil) {
+		return []error{errors.New("exactly one of CNIConfig and StaticConfig must be specified")}
+	}
+
+	if static := iface.StaticConfig; static != nil {
+		var errs []error
+		if static.HostDevName == "" {
+			errs = append(errs, errors.New("StaticConfig.HostDevName: no tap device was specified"))
+		}
+		if ip := static.IPConfig; ip != nil {
+			if _, _, err := net.ParseCIDR(ip.PrimaryAddr); err != nil {
+				errs = append(errs, fmt.Errorf("StaticConfig.IPConfig.PrimaryAddr: %w", err))
+			}
+		}
+		return errs
+	}
+
+	cni := iface.CNIConfig
+	var errs []error
+	if cni.InterfaceName == "" {
+		errs = append(errs, errors.New("CNIConfig.InterfaceName: no interface name was specified"))
+	}
+
+	confDir := cni.ConfDir
+	if confDir == "" {
+		confDir = defaultCNIConfDir
+	}
+	if cni.NetworkName == "" {
+		errs = append(errs, errors.New("CNIConfig.NetworkName: no network name was specified"))
+	} else if _, err := os.Stat(confDir); err != nil {
+		errs = append(errs, fmt.Errorf("CNIConfig.ConfDir: %w", err))
+	} else if _, err := libcni.LoadConfList(confDir, cni.NetworkName); err != nil {
+		errs = append(errs, fmt.Errorf("CNIConfig.NetworkName: %w", err))
+	}
+
+	binPaths := cni.BinPath
+	if len(binPaths) == 0 {
+		binPaths = []string{defaultCNIBinDir}
+	}
+	for _, dir := range binPaths {
+		if info, err := os.Stat(dir); err != nil {
+			errs = append(errs, fmt.Errorf("CNIConfig.BinPath: %w", err))
+		} else if !info.IsDir() {
+			errs = append(errs, fmt.Errorf("CNIConfig.BinPath: %q is not a directory", dir))
+		}
+	}
+
+	return errs
+}
+
+// checkExecutable checks that path is an executable file. An empty path is looked up in $PATH as
+// the default Firecracker binary.
+func checkExecutable(path string) error {
+	if path == "" {
+		if _, err := exec.LookPath(defaultFirecrackerBinary); err != nil {
+			return fmt.Errorf("no path was specified and %w", err)
+		}
+		return nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if !info.Mode().IsRegular() || info.Mode().Perm()&0111 == 0 {
+		return fmt.Errorf("%q is not an executable file", path)
+	}
+	return nil
+}
+
+// checkFile checks that path exists and is not a directory, so that it can back a drive.
+func checkFile(path string) error {
+	if path == "" {
+		return errors.New("no path was specified")
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return fmt.Errorf("%q is a directory", path)
+	}
+	return nil
+}
+
+// checkRuncConfig checks that path is a runc configuration the runc jailer can use.
+func checkRuncConfig(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var spec specs.Spec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return fmt.Errorf("failed to unmarshal %q: %w", path, err)
+	}
+	return nil
+}
+
+// checkCustomCPUTemplate checks that template is a custom CPU template applicable to the CPU of
+// this host.
+func checkCustomCPUTemplate(template string) error {
+	t, err := internal.LoadCustomCPUTemplate(template)
+	if err != nil {
+		return err
+	}
+	cpu, err := internal.DetectHostCPU()
+	if err != nil {
+		return fmt.Errorf("failed to detect the CPU of the host: %w", err)
+	}
+	return t.Validate(cpu)
+}
+
+// unknownFields returns the JSON paths of the fields of data which match no field of t, which
+// encoding/json silently ignores. Values whose type doesn't match t are skipped, as decoding them
+// fails anyway.
+func unknownFields(data json.RawMessage, t reflect.Type, path string) []string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	var unknown []string
+	switch t.Kind() {
+	case reflect.Struct:
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return nil
+		}
+		for _, name := range sortedFieldNames(fields) {
+			fieldPath := joinFieldPath(path, name)
+			field, ok := jsonField(t, name)
+			if !ok {
+				unknown = append(unknown, fieldPath)
+				continue
+			}
+			unknown = append(unknown, unknownFields(fields[name], field.Type, fieldPath)...)
+		}
+	case reflect.Slice, reflect.Array:
+		var elems []json.RawMessage
+		if err := json.Unmarshal(data, &elems); err != nil {
+			return nil
+		}
+		for i, elem := range elems {
+			unknown = append(unknown, unknownFields(elem, t.Elem(), fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	case reflect.Map:
+		var values map[string]json.RawMessage
+		if err := json.Unmarshal(data, &values); err != nil {
+			return nil
+		}
+		for _, key := range sortedFieldNames(values) {
+			unknown = append(unknown, unknownFields(values[key], t.Elem(), joinFieldPath(path, key))...)
+		}
+	}
+	return unknown
+}
+
+// jsonField returns the field of t encoding/json decodes the given key into.
+func jsonField(t reflect.Type, key string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		// encoding/json matches keys case-insensitively
+		if strings.EqualFold(name, key) {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+func joinFieldPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func sortedFieldNames(m map[string]json.RawMessage) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
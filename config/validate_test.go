@@ -0,0 +1,96 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateConfig(t *testing.T) {
+	dir := t.TempDir()
+	firecracker := filepath.Join(dir, "firecracker")
+	kernel := filepath.Join(dir, "vmlinux")
+	rootfs := filepath.Join(dir, "rootfs.img")
+	confDir := filepath.Join(dir, "conf.d")
+	binDir := filepath.Join(dir, "bin")
+
+	require.NoError(t, os.WriteFile(firecracker, []byte("#!/bin/sh\n"), 0755))
+	require.NoError(t, os.WriteFile(kernel, nil, 0644))
+	require.NoError(t, os.WriteFile(rootfs, nil, 0644))
+	require.NoError(t, os.Mkdir(confDir, 0755))
+	require.NoError(t, os.Mkdir(binDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(confDir, "fcnet.conflist"),
+		[]byte(`{"cniVersion": "0.4.0", "name": "fcnet", "plugins": [{"type": "ptp"}]}`), 0644))
+
+	valid := fmt.Sprintf(`{
+		"firecracker_binary_path": %q,
+		"kernel_image_path": %q,
+		"root_drive": %q,
+		"default_network_interfaces": [{
+			"AllowMMDS": true,
+			"CNIConfig": {"NetworkName": "fcnet", "InterfaceName": "veth0", "ConfDir": %q, "BinPath": [%q]}
+		}]
+	}`, firecracker, kernel, rootfs, confDir, binDir)
+	configFile, cleanup := createTempConfig(t, valid)
+	defer cleanup()
+	assert.NoError(t, ValidateConfig(configFile))
+
+	invalid := fmt.Sprintf(`{
+		"firecracker_binary_path": %q,
+		"kernel_image_pth": %q,
+		"kernel_image_path": %q,
+		"root_drive": %q,
+		"custom_cpu_template": "{\"cpuid_modifier\": []}",
+		"shim_base_dir": "shim-base",
+		"jailer": {"runc_binary_path": %q, "runc_config": "config.json", "runc_config_path": %q},
+		"default_network_interfaces": [{
+			"CNIConfig": {"NetworkName": "missing", "InterfaceName": "veth0", "ConfDir": %q, "BinPath": [%q]},
+			"Network": "fcnet"
+		}],
+		"vm_pools": [{"name": "small", "size": 1}, {"name": "small", "size": -1}]
+	}`, kernel, kernel, filepath.Join(dir, "missing-vmlinux"), filepath.Join(dir, "missing.img"),
+		firecracker, filepath.Join(dir, "missing.json"), confDir, binDir)
+	configFile, cleanup = createTempConfig(t, invalid)
+	defer cleanup()
+
+	err := ValidateConfig(configFile)
+	var merr *multierror.Error
+	require.ErrorAs(t, err, &merr)
+
+	var problems []string
+	for _, err := range merr.Errors {
+		problems = append(problems, err.Error())
+	}
+	assert.Equal(t, []string{
+		"default_network_interfaces[0].Network: unknown field",
+		"jailer.runc_config: unknown field",
+		"kernel_image_pth: unknown field",
+		fmt.Sprintf("firecracker_binary_path: %q is not an executable file", kernel),
+		fmt.Sprintf("kernel_image_path: stat %s: no such file or directory", filepath.Join(dir, "missing-vmlinux")),
+		fmt.Sprintf("root_drive: stat %s: no such file or directory", filepath.Join(dir, "missing.img")),
+		`custom_cpu_template: failed to parse custom CPU template: json: unknown field "cpuid_modifier"`,
+		`shim_base_dir: "shim-base" is not an absolute path`,
+		fmt.Sprintf("jailer.runc_config_path: open %s: no such file or directory", filepath.Join(dir, "missing.json")),
+		fmt.Sprintf(`default_network_interfaces[0]: CNIConfig.NetworkName: no net configuration with name "missing" in %s`, confDir),
+		`vm_pools[1].name: pool "small" is defined more than once`,
+		"vm_pools[1].size: -1 is negative",
+	}, problems)
+}
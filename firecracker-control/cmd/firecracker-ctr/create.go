@@ -0,0 +1,321 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	gocontext "context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	fcclient "github.com/firecracker-microvm/firecracker-containerd/firecracker-control/client"
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+const defaultCNIInterfaceName = "veth0"
+
+var createCommand = cli.Command{
+	Name:  "create",
+	Usage: "create a VM",
+	Description: `Create a VM from a CreateVMRequest in JSON and the flags, which take precedence over it.
+
+For example, to create a VM using the "fcnet" CNI network and the default kernel and root filesystem:
+
+    firecracker-ctr vm create --cni fcnet --cpus 2 --memory 1024 vm-1`,
+	ArgsUsage: "[flags] [VM_ID]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "config",
+			Usage: `CreateVMRequest in JSON to start from, "-" to read it from stdin`,
+		},
+		formatFlag,
+		cli.StringFlag{
+			Name:  "kernel",
+			Usage: "path of the kernel image on the host",
+		},
+		cli.StringFlag{
+			Name:  "kernel-image",
+			Usage: "reference of the containerd image holding the kernel",
+		},
+		cli.StringFlag{
+			Name:  "kernel-args",
+			Usage: "kernel command line",
+		},
+		cli.StringFlag{
+			Name:  "rootfs",
+			Usage: "path of the root filesystem image on the host",
+		},
+		cli.StringFlag{
+			Name:  "rootfs-image",
+			Usage: "reference of the containerd image holding the root filesystem",
+		},
+		cli.BoolFlag{
+			Name:  "rootfs-writable",
+			Usage: "attach the root filesystem read-write",
+		},
+		cli.UintFlag{
+			Name:  "cpus",
+			Usage: "number of vCPUs",
+		},
+		cli.UintFlag{
+			Name:  "memory",
+			Usage: "memory size in MiB",
+		},
+		cli.StringFlag{
+			Name:  "cpu-template",
+			Usage: "CPU template, such as T2 or C3",
+		},
+		cli.StringFlag{
+			Name:  "custom-cpu-template",
+			Usage: "custom CPU template of Firecracker, the path of its JSON file on the host or the JSON itself",
+		},
+		cli.BoolFlag{
+			Name:  "track-dirty-pages",
+			Usage: "track dirty pages, which diff snapshots require",
+		},
+		cli.StringSliceFlag{
+			Name:  "cni",
+			Usage: "CNI network to attach the VM to, as NETWORK[:INTERFACE], may be repeated",
+		},
+		cli.StringSliceFlag{
+			Name:  "drive",
+			Usage: "drive to mount in the VM, as HOST_PATH:VM_PATH[:FILESYSTEM_TYPE[:OPTION,...]], may be repeated",
+		},
+		cli.IntFlag{
+			Name:  "containers",
+			Usage: "number of containers the VM will run",
+		},
+		cli.IntFlag{
+			Name:  "spare-drives",
+			Usage: "number of spare drives for containers to be attached later",
+		},
+		cli.BoolFlag{
+			Name:  "exit-after-all-tasks-deleted",
+			Usage: "stop the VM once all its tasks are deleted",
+		},
+		cli.UintFlag{
+			Name:  "timeout-seconds",
+			Usage: "time to wait for the VM to start, 0 for the default",
+		},
+		cli.StringFlag{
+			Name:  "firecracker-binary",
+			Usage: "name of the Firecracker binary in the runtime configuration to run the VM with",
+		},
+		cli.StringFlag{
+			Name:  "restart",
+			Usage: "relaunch the VM when Firecracker exits unexpectedly, never or on-failure[:MAX_RETRIES]",
+		},
+		cli.UintFlag{
+			Name:  "restart-backoff-seconds",
+			Usage: "delay before the first relaunch of the VM, doubled for each following one",
+		},
+	},
+	Action: func(context *cli.Context) error {
+		request, err := createVMRequest(context)
+		if err != nil {
+			return err
+		}
+		return withClient(context, func(ctx gocontext.Context, client *fcclient.Client) error {
+			resp, err := client.CreateVM(ctx, request)
+			if err != nil {
+				return err
+			}
+			return printResponse(context, resp)
+		})
+	},
+}
+
+// createVMRequest returns the CreateVMRequest of the --config file with the other flags applied.
+func createVMRequest(context *cli.Context) (*proto.CreateVMRequest, error) {
+	request := &proto.CreateVMRequest{}
+	if path := context.String("config"); path != "" {
+		var (
+			b   []byte
+			err error
+		)
+		if path == "-" {
+			b, err = io.ReadAll(os.Stdin)
+		} else {
+			b, err = os.ReadFile(path)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %q: %w", path, err)
+		}
+		if err := protojson.Unmarshal(b, request); err != nil {
+			return nil, fmt.Errorf("failed to parse %q: %w", path, err)
+		}
+	}
+
+	if id := context.Args().First(); id != "" {
+		request.VMID = id
+	}
+
+	if context.IsSet("kernel") {
+		path, err := absPath(context.String("kernel"))
+		if err != nil {
+			return nil, err
+		}
+		request.KernelImagePath = path
+	}
+	if context.IsSet("kernel-image") {
+		request.KernelImage = &proto.VMImage{Ref: context.String("kernel-image")}
+	}
+	if context.IsSet("kernel-args") {
+		request.KernelArgs = context.String("kernel-args")
+	}
+
+	if context.IsSet("rootfs") || context.IsSet("rootfs-writable") {
+		if request.RootDrive == nil {
+			request.RootDrive = &proto.FirecrackerRootDrive{}
+		}
+		if context.IsSet("rootfs") {
+			path, err := absPath(context.String("rootfs"))
+			if err != nil {
+				return nil, err
+			}
+			request.RootDrive.HostPath = path
+		}
+		if context.IsSet("rootfs-writable") {
+			request.RootDrive.IsWritable = context.Bool("rootfs-writable")
+		}
+	}
+	if context.IsSet("rootfs-image") {
+		request.RootDriveImage = &proto.VMImage{Ref: context.String("rootfs-image")}
+	}
+
+	for _, flag := range []string{"cpus", "memory", "cpu-template", "custom-cpu-template", "track-dirty-pages"} {
+		if context.IsSet(flag) && request.MachineCfg == nil {
+			request.MachineCfg = &proto.FirecrackerMachineConfiguration{}
+		}
+	}
+	if context.IsSet("cpus") {
+		request.MachineCfg.VcpuCount = uint32(context.Uint("cpus"))
+	}
+	if context.IsSet("memory") {
+		request.MachineCfg.MemSizeMib = uint32(context.Uint("memory"))
+	}
+	if context.IsSet("cpu-template") {
+		request.MachineCfg.CPUTemplate = context.String("cpu-template")
+	}
+	if context.IsSet("custom-cpu-template") {
+		request.MachineCfg.CustomCPUTemplate = context.String("custom-cpu-template")
+	}
+	if context.IsSet("track-dirty-pages") {
+		request.MachineCfg.TrackDirtyPages = context.Bool("track-dirty-pages")
+	}
+
+	for _, cni := range context.StringSlice("cni") {
+		network, iface, _ := strings.Cut(cni, ":")
+		if iface == "" {
+			iface = defaultCNIInterfaceName
+		}
+		request.NetworkInterfaces = append(request.NetworkInterfaces, &proto.FirecrackerNetworkInterface{
+			AllowMMDS: true,
+			CNIConfig: &proto.CNIConfiguration{
+				NetworkName:   network,
+				InterfaceName: iface,
+			},
+		})
+	}
+
+	for _, drive := range context.StringSlice("drive") {
+		driveMount, err := parseDriveMount(drive)
+		if err != nil {
+			return nil, err
+		}
+		request.DriveMounts = append(request.DriveMounts, driveMount)
+	}
+
+	if context.IsSet("containers") {
+		request.ContainerCount = int32(context.Int("containers"))
+	}
+	if context.IsSet("spare-drives") {
+		request.SpareDriveCount = int32(context.Int("spare-drives"))
+	}
+	if context.IsSet("exit-after-all-tasks-deleted") {
+		request.ExitAfterAllTasksDeleted = context.Bool("exit-after-all-tasks-deleted")
+	}
+	if context.IsSet("timeout-seconds") {
+		request.TimeoutSeconds = uint32(context.Uint("timeout-seconds"))
+	}
+	if context.IsSet("firecracker-binary") {
+		request.FirecrackerBinary = context.String("firecracker-binary")
+	}
+	if context.IsSet("restart") {
+		policy, err := parseRestartPolicy(context.String("restart"))
+		if err != nil {
+			return nil, err
+		}
+		request.RestartPolicy = policy
+	}
+	if context.IsSet("restart-backoff-seconds") {
+		if request.RestartPolicy == nil {
+			return nil, errors.New("--restart-backoff-seconds requires a restart policy")
+		}
+		request.RestartPolicy.BackoffSeconds = uint32(context.Uint("restart-backoff-seconds"))
+	}
+
+	return request, nil
+}
+
+// parseRestartPolicy parses a --restart flag, never or on-failure[:MAX_RETRIES].
+func parseRestartPolicy(restart string) (*proto.RestartPolicy, error) {
+	mode, maxRetries, hasMaxRetries := strings.Cut(restart, ":")
+	switch {
+	case mode == "never" && !hasMaxRetries:
+		return &proto.RestartPolicy{Mode: proto.RestartMode_RESTART_NEVER}, nil
+	case mode == "on-failure":
+		policy := &proto.RestartPolicy{Mode: proto.RestartMode_RESTART_ON_FAILURE}
+		if hasMaxRetries {
+			n, err := strconv.ParseUint(maxRetries, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid maximum number of retries %q: %w", maxRetries, err)
+			}
+			policy.MaxRetries = uint32(n)
+		}
+		return policy, nil
+	default:
+		return nil, fmt.Errorf("invalid restart policy %q, expected never or on-failure[:MAX_RETRIES]", restart)
+	}
+}
+
+// parseDriveMount parses a --drive flag, HOST_PATH:VM_PATH[:FILESYSTEM_TYPE[:OPTION,...]].
+func parseDriveMount(drive string) (*proto.FirecrackerDriveMount, error) {
+	parts := strings.SplitN(drive, ":", 4)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("invalid drive %q, expected HOST_PATH:VM_PATH[:FILESYSTEM_TYPE[:OPTION,...]]", drive)
+	}
+	hostPath, err := absPath(parts[0])
+	if err != nil {
+		return nil, err
+	}
+	driveMount := &proto.FirecrackerDriveMount{
+		HostPath:       hostPath,
+		VMPath:         parts[1],
+		FilesystemType: "ext4",
+	}
+	if len(parts) > 2 && parts[2] != "" {
+		driveMount.FilesystemType = parts[2]
+	}
+	if len(parts) > 3 && parts[3] != "" {
+		driveMount.Options = strings.Split(parts[3], ",")
+	}
+	return driveMount, nil
+}
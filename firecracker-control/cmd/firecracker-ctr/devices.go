@@ -0,0 +1,304 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	gocontext "context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/urfave/cli"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	fcclient "github.com/firecracker-microvm/firecracker-containerd/firecracker-control/client"
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+var driveCommand = cli.Command{
+	Name:  "drive",
+	Usage: "manage the drives of a VM",
+	Subcommands: cli.Commands{
+		{
+			Name:      "list",
+			Aliases:   []string{"ls"},
+			Usage:     "list the drives of a VM",
+			ArgsUsage: "[flags] VM_ID",
+			Flags:     []cli.Flag{formatFlag},
+			Action: func(context *cli.Context) error {
+				id, err := vmID(context)
+				if err != nil {
+					return err
+				}
+				return withClient(context, func(ctx gocontext.Context, client *fcclient.Client) error {
+					resp, err := client.ListDrives(ctx, &proto.ListDrivesRequest{VMID: id})
+					if err != nil {
+						return err
+					}
+					return printResponse(context, resp)
+				})
+			},
+		},
+		{
+			Name:      "attach",
+			Usage:     "attach a block device or image file of the host and mount it in a VM",
+			ArgsUsage: "[flags] VM_ID HOST_PATH VM_PATH",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "type",
+					Usage: "filesystem type of the drive",
+					Value: "ext4",
+				},
+				cli.StringSliceFlag{
+					Name:  "option",
+					Usage: "mount option of the drive, may be repeated",
+				},
+				rateLimiterFlag("rate-limiter", "the drive"),
+			},
+			Action: func(context *cli.Context) error {
+				id, err := vmID(context)
+				if err != nil {
+					return err
+				}
+				hostPath, vmPath := context.Args().Get(1), context.Args().Get(2)
+				if hostPath == "" || vmPath == "" {
+					return errors.New("host path and VM path must be provided")
+				}
+				if hostPath, err = absPath(hostPath); err != nil {
+					return err
+				}
+				rateLimiter, err := parseRateLimiter(context, "rate-limiter")
+				if err != nil {
+					return err
+				}
+				return withClient(context, func(ctx gocontext.Context, client *fcclient.Client) error {
+					_, err := client.AttachDriveMount(ctx, &proto.AttachDriveMountRequest{
+						VMID: id,
+						DriveMount: &proto.FirecrackerDriveMount{
+							HostPath:       hostPath,
+							VMPath:         vmPath,
+							FilesystemType: context.String("type"),
+							Options:        context.StringSlice("option"),
+							RateLimiter:    rateLimiter,
+						},
+					})
+					return err
+				})
+			},
+		},
+		{
+			Name:      "detach",
+			Usage:     "unmount a drive attached with attach and detach it from a VM",
+			ArgsUsage: "VM_ID VM_PATH",
+			Action: func(context *cli.Context) error {
+				id, err := vmID(context)
+				if err != nil {
+					return err
+				}
+				vmPath := context.Args().Get(1)
+				if vmPath == "" {
+					return errors.New("VM path must be provided")
+				}
+				return withClient(context, func(ctx gocontext.Context, client *fcclient.Client) error {
+					_, err := client.DetachDriveMount(ctx, &proto.DetachDriveMountRequest{VMID: id, VMPath: vmPath})
+					return err
+				})
+			},
+		},
+		{
+			Name:      "update",
+			Usage:     "update the rate limiter of a drive",
+			ArgsUsage: "[flags] VM_ID",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "vm-path",
+					Usage: "the path the drive is mounted at in the VM",
+				},
+				cli.StringFlag{
+					Name:  "drive-id",
+					Usage: "the Firecracker ID of the drive, as listed by drive list",
+				},
+				rateLimiterFlag("rate-limiter", "the drive"),
+			},
+			Action: func(context *cli.Context) error {
+				id, err := vmID(context)
+				if err != nil {
+					return err
+				}
+				rateLimiter, err := parseRateLimiter(context, "rate-limiter")
+				if err != nil {
+					return err
+				}
+				return withClient(context, func(ctx gocontext.Context, client *fcclient.Client) error {
+					_, err := client.UpdateDrive(ctx, &proto.UpdateDriveRequest{
+						VMID:        id,
+						VMPath:      context.String("vm-path"),
+						DriveID:     context.String("drive-id"),
+						RateLimiter: rateLimiter,
+					})
+					return err
+				})
+			},
+		},
+	},
+}
+
+var networkCommand = cli.Command{
+	Name:  "network",
+	Usage: "manage the network interfaces of a VM",
+	Subcommands: cli.Commands{
+		{
+			Name:      "update",
+			Usage:     "update the rate limiters of a network interface",
+			ArgsUsage: "[flags] VM_ID INTERFACE_INDEX",
+			Flags: []cli.Flag{
+				rateLimiterFlag("in-rate-limiter", "the incoming traffic of the interface"),
+				rateLimiterFlag("out-rate-limiter", "the outgoing traffic of the interface"),
+			},
+			Action: func(context *cli.Context) error {
+				id, err := vmID(context)
+				if err != nil {
+					return err
+				}
+				index, err := strconv.ParseUint(context.Args().Get(1), 10, 32)
+				if err != nil {
+					return fmt.Errorf("invalid interface index %q: %w", context.Args().Get(1), err)
+				}
+				inRateLimiter, err := parseRateLimiter(context, "in-rate-limiter")
+				if err != nil {
+					return err
+				}
+				outRateLimiter, err := parseRateLimiter(context, "out-rate-limiter")
+				if err != nil {
+					return err
+				}
+				return withClient(context, func(ctx gocontext.Context, client *fcclient.Client) error {
+					_, err := client.UpdateNetworkInterface(ctx, &proto.UpdateNetworkInterfaceRequest{
+						VMID:           id,
+						InterfaceIndex: uint32(index),
+						InRateLimiter:  inRateLimiter,
+						OutRateLimiter: outRateLimiter,
+					})
+					return err
+				})
+			},
+		},
+	},
+}
+
+var balloonCommand = cli.Command{
+	Name:  "balloon",
+	Usage: "manage the balloon device of a VM",
+	Subcommands: cli.Commands{
+		{
+			Name:      "config",
+			Usage:     "get the configuration of the balloon device",
+			ArgsUsage: "[flags] VM_ID",
+			Flags:     []cli.Flag{formatFlag},
+			Action: func(context *cli.Context) error {
+				id, err := vmID(context)
+				if err != nil {
+					return err
+				}
+				return withClient(context, func(ctx gocontext.Context, client *fcclient.Client) error {
+					resp, err := client.GetBalloonConfig(ctx, &proto.GetBalloonConfigRequest{VMID: id})
+					if err != nil {
+						return err
+					}
+					return printResponse(context, resp)
+				})
+			},
+		},
+		{
+			Name:      "stats",
+			Usage:     "get the statistics of the balloon device",
+			ArgsUsage: "[flags] VM_ID",
+			Flags:     []cli.Flag{formatFlag},
+			Action: func(context *cli.Context) error {
+				id, err := vmID(context)
+				if err != nil {
+					return err
+				}
+				return withClient(context, func(ctx gocontext.Context, client *fcclient.Client) error {
+					resp, err := client.GetBalloonStats(ctx, &proto.GetBalloonStatsRequest{VMID: id})
+					if err != nil {
+						return err
+					}
+					return printResponse(context, resp)
+				})
+			},
+		},
+		{
+			Name:      "update",
+			Usage:     "set the target size of the balloon device",
+			ArgsUsage: "VM_ID AMOUNT_MIB",
+			Action: func(context *cli.Context) error {
+				id, err := vmID(context)
+				if err != nil {
+					return err
+				}
+				amount, err := strconv.ParseInt(context.Args().Get(1), 10, 64)
+				if err != nil {
+					return fmt.Errorf("invalid balloon size %q: %w", context.Args().Get(1), err)
+				}
+				return withClient(context, func(ctx gocontext.Context, client *fcclient.Client) error {
+					_, err := client.UpdateBalloon(ctx, &proto.UpdateBalloonRequest{VMID: id, AmountMib: amount})
+					return err
+				})
+			},
+		},
+		{
+			Name:      "update-stats",
+			Usage:     "set the interval the statistics of the balloon device are refreshed at",
+			ArgsUsage: "VM_ID INTERVAL_SECONDS",
+			Action: func(context *cli.Context) error {
+				id, err := vmID(context)
+				if err != nil {
+					return err
+				}
+				interval, err := strconv.ParseInt(context.Args().Get(1), 10, 64)
+				if err != nil {
+					return fmt.Errorf("invalid statistics interval %q: %w", context.Args().Get(1), err)
+				}
+				return withClient(context, func(ctx gocontext.Context, client *fcclient.Client) error {
+					_, err := client.UpdateBalloonStats(ctx, &proto.UpdateBalloonStatsRequest{
+						VMID:                  id,
+						StatsPollingIntervals: interval,
+					})
+					return err
+				})
+			},
+		},
+	},
+}
+
+func rateLimiterFlag(name, target string) cli.Flag {
+	return cli.StringFlag{
+		Name:  name,
+		Usage: "rate limiter of " + target + ", as a FirecrackerRateLimiter in JSON",
+	}
+}
+
+// parseRateLimiter returns the rate limiter of the given flag, or nil if it isn't set.
+func parseRateLimiter(context *cli.Context, flag string) (*proto.FirecrackerRateLimiter, error) {
+	value := context.String(flag)
+	if value == "" {
+		return nil, nil
+	}
+	var rateLimiter proto.FirecrackerRateLimiter
+	if err := protojson.Unmarshal([]byte(value), &rateLimiter); err != nil {
+		return nil, fmt.Errorf("invalid --%s: %w", flag, err)
+	}
+	return &rateLimiter, nil
+}
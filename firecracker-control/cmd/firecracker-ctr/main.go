@@ -0,0 +1,38 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// firecracker-ctr is containerd's ctr, built from the same version of containerd as
+// firecracker-containerd, with a vm command for the firecracker-control plugin.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/containerd/containerd/cmd/ctr/app"
+	"github.com/containerd/containerd/pkg/seed"
+)
+
+func init() {
+	seed.WithTimeAndRand()
+}
+
+func main() {
+	app := app.New()
+	app.Name = "firecracker-ctr"
+	app.Commands = append(app.Commands, vmCommand)
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintf(os.Stderr, "firecracker-ctr: %s\n", err)
+		os.Exit(1)
+	}
+}
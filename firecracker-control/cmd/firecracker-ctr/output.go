@@ -0,0 +1,139 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/urfave/cli"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+)
+
+var formatFlag = cli.StringFlag{
+	Name:  "format",
+	Usage: `output format, "table" or "json"`,
+	Value: formatTable,
+}
+
+// printResponse writes a response to stdout in the format selected by the flags of the command.
+func printResponse(context *cli.Context, m proto.Message) error {
+	switch format := context.String("format"); format {
+	case formatJSON:
+		b, err := protojson.MarshalOptions{
+			Multiline:       true,
+			UseProtoNames:   true,
+			EmitUnpopulated: true,
+		}.Marshal(m)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(os.Stdout, string(b))
+		return err
+	case formatTable:
+		return printTable(os.Stdout, m)
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+}
+
+// printTable writes a message as a table. A message made of a single list of messages, such as
+// ListVMsResponse, is written as a row per element and a column per field of the elements. Other
+// messages are written as a row per field, except that a message made of a single message, such
+// as GetBalloonConfigResponse, is written as that message. Nested messages, lists and maps are
+// written as JSON.
+func printTable(out io.Writer, m proto.Message) error {
+	w := tabwriter.NewWriter(out, 4, 8, 4, ' ', 0)
+
+	fields := m.ProtoReflect().Descriptor().Fields()
+	if fields.Len() == 1 && fields.Get(0).Kind() == protoreflect.MessageKind && !fields.Get(0).IsList() && !fields.Get(0).IsMap() {
+		return printTable(out, m.ProtoReflect().Get(fields.Get(0)).Message().Interface())
+	}
+	if fields.Len() == 1 && fields.Get(0).IsList() && fields.Get(0).Message() != nil {
+		columns := fields.Get(0).Message().Fields()
+		header := make([]string, columns.Len())
+		for i := range header {
+			header[i] = string(columns.Get(i).Name())
+		}
+		fmt.Fprintln(w, strings.Join(header, "\t"))
+
+		list := m.ProtoReflect().Get(fields.Get(0)).List()
+		for i := 0; i < list.Len(); i++ {
+			values, err := fieldValues(list.Get(i).Message().Interface())
+			if err != nil {
+				return err
+			}
+			row := make([]string, columns.Len())
+			for j := range row {
+				row[j] = values[string(columns.Get(j).Name())]
+			}
+			fmt.Fprintln(w, strings.Join(row, "\t"))
+		}
+		return w.Flush()
+	}
+
+	values, err := fieldValues(m)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < fields.Len(); i++ {
+		name := string(fields.Get(i).Name())
+		fmt.Fprintf(w, "%s\t%s\n", name, values[name])
+	}
+	return w.Flush()
+}
+
+// fieldValues returns the values of the fields of a message as written in a table, by field name.
+// They are taken from the JSON encoding of the message so that enums, timestamps and 64-bit
+// integers are written the same way in both formats.
+func fieldValues(m proto.Message) (map[string]string, error) {
+	b, err := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string, len(fields))
+	for name, raw := range fields {
+		var s string
+		switch {
+		case string(raw) == "null", string(raw) == `""`:
+			values[name] = "-"
+		case json.Unmarshal(raw, &s) == nil:
+			values[name] = s
+		default:
+			var compact bytes.Buffer
+			if err := json.Compact(&compact, raw); err != nil {
+				return nil, err
+			}
+			values[name] = compact.String()
+		}
+	}
+	return values, nil
+}
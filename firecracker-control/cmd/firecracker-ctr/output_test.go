@@ -0,0 +1,88 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+func TestPrintTable(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		message  protobuf.Message
+		expected string
+	}{
+		{
+			name: "list",
+			message: &proto.ListDrivesResponse{Drives: []*proto.FirecrackerDriveInfo{
+				{DriveID: "root_drive", HostPath: "/var/lib/rootfs.img", IsRootDevice: true},
+				{
+					DriveID:     "drive_1",
+					HostPath:    "/tmp/data.img",
+					VMPath:      "/data",
+					RateLimiter: &proto.FirecrackerRateLimiter{},
+				},
+			}},
+			expected: "DriveID       HostPath               VMPath    RateLimiter                      IsRootDevice    IsWritable\n" +
+				"root_drive    /var/lib/rootfs.img    -         -                                true            false\n" +
+				"drive_1       /tmp/data.img          /data     {\"Bandwidth\":null,\"Ops\":null}    false           false\n",
+		},
+		{
+			name:     "empty list",
+			message:  &proto.ListVMsResponse{},
+			expected: "VMID    SocketPath    LogFifoPath    MetricsFifoPath    CgroupPath    VSockPath    ShimPID    State    CreatedAt\n",
+		},
+		{
+			name: "single message",
+			message: &proto.GetBalloonConfigResponse{BalloonConfig: &proto.FirecrackerBalloonDevice{
+				AmountMib:    256,
+				DeflateOnOom: true,
+			}},
+			expected: "AmountMib                256\n" +
+				"DeflateOnOom             true\n" +
+				"StatsPollingIntervals    0\n",
+		},
+		{
+			name: "fields",
+			message: &proto.GetVMInfoResponse{
+				VMID:    "vm-1",
+				ShimPID: 42,
+				State:   proto.VMState_RUNNING,
+			},
+			expected: "VMID               vm-1\n" +
+				"SocketPath         -\n" +
+				"LogFifoPath        -\n" +
+				"MetricsFifoPath    -\n" +
+				"CgroupPath         -\n" +
+				"VSockPath          -\n" +
+				"ShimPID            42\n" +
+				"State              RUNNING\n" +
+				"CreatedAt          -\n",
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			var out bytes.Buffer
+			require.NoError(t, printTable(&out, tc.message))
+			assert.Equal(t, tc.expected, out.String())
+		})
+	}
+}
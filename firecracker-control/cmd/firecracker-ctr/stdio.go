@@ -0,0 +1,384 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	gocontext "context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"github.com/containerd/console"
+	"github.com/containerd/fifo"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	fcclient "github.com/firecracker-microvm/firecracker-containerd/firecracker-control/client"
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+// consoleDetachKey detaches from an interactive console, as the input is passed through to the
+// console of the VM otherwise.
+const consoleDetachKey = 0x1d // ^]
+
+var consoleCommand = cli.Command{
+	Name:  "console",
+	Usage: "access the serial console of a VM",
+	Subcommands: cli.Commands{
+		{
+			Name:      "log",
+			Usage:     "print the latest output of the console",
+			ArgsUsage: "[flags] VM_ID",
+			Flags: []cli.Flag{cli.StringFlag{
+				Name:  "format",
+				Usage: `output format, "raw" or "json"`,
+				Value: "raw",
+			}},
+			Action: func(context *cli.Context) error {
+				id, err := vmID(context)
+				if err != nil {
+					return err
+				}
+				return withClient(context, func(ctx gocontext.Context, client *fcclient.Client) error {
+					resp, err := client.GetConsoleLog(ctx, &proto.GetConsoleLogRequest{VMID: id})
+					if err != nil {
+						return err
+					}
+					if context.String("format") == formatJSON {
+						return printResponse(context, resp)
+					}
+					_, err = os.Stdout.Write(resp.Output)
+					return err
+				})
+			},
+		},
+		{
+			Name:      "attach",
+			Usage:     "attach to the console, ^] detaches",
+			ArgsUsage: "[flags] VM_ID",
+			Flags: []cli.Flag{cli.BoolFlag{
+				Name:  "read-only",
+				Usage: "only print the output of the console",
+			}},
+			Action: func(context *cli.Context) error {
+				id, err := vmID(context)
+				if err != nil {
+					return err
+				}
+				return withClient(context, func(ctx gocontext.Context, client *fcclient.Client) error {
+					return attachConsole(ctx, client, id, !context.Bool("read-only"))
+				})
+			},
+		},
+	},
+}
+
+func attachConsole(ctx gocontext.Context, client *fcclient.Client, id string, input bool) error {
+	ctx, cancel := gocontext.WithCancel(ctx)
+	defer cancel()
+
+	dir, err := os.MkdirTemp("", "firecracker-ctr-console-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	request := &proto.AttachConsoleRequest{VMID: id, StdoutPath: filepath.Join(dir, "stdout")}
+	stdout, err := fifo.OpenFifo(ctx, request.StdoutPath, syscall.O_RDONLY|syscall.O_CREAT|syscall.O_NONBLOCK, 0700)
+	if err != nil {
+		return err
+	}
+	defer stdout.Close()
+
+	var stdin io.WriteCloser
+	if input {
+		request.StdinPath = filepath.Join(dir, "stdin")
+		stdin, err = fifo.OpenFifo(ctx, request.StdinPath, syscall.O_WRONLY|syscall.O_CREAT|syscall.O_NONBLOCK, 0700)
+		if err != nil {
+			return err
+		}
+		defer stdin.Close()
+	}
+
+	if _, err := client.AttachConsole(ctx, request); err != nil {
+		return err
+	}
+
+	if input {
+		current := console.Current()
+		if err := current.SetRaw(); err == nil {
+			defer current.Reset()
+		}
+		go func() {
+			// closing the input detaches from the console, which closes its output
+			defer stdin.Close()
+			_, _ = io.Copy(stdin, detachReader{os.Stdin})
+		}()
+	}
+
+	_, err = io.Copy(os.Stdout, stdout)
+	return err
+}
+
+// detachReader ends its input at consoleDetachKey.
+type detachReader struct {
+	io.Reader
+}
+
+func (r detachReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	if i := bytes.IndexByte(p[:n], consoleDetachKey); i >= 0 {
+		return i, io.EOF
+	}
+	return n, err
+}
+
+var execCommand = cli.Command{
+	Name:           "exec",
+	Usage:          "run a process in a VM, outside of any container",
+	ArgsUsage:      "[flags] VM_ID COMMAND [ARG...]",
+	SkipArgReorder: true,
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "tty, t",
+			Usage: "run the process in a terminal",
+		},
+		cli.StringSliceFlag{
+			Name:  "env",
+			Usage: "environment variable of the process, as NAME=VALUE, may be repeated",
+		},
+		cli.StringFlag{
+			Name:  "cwd",
+			Usage: "working directory of the process",
+		},
+	},
+	Action: func(context *cli.Context) error {
+		id, err := vmID(context)
+		if err != nil {
+			return err
+		}
+		args := context.Args().Tail()
+		if len(args) == 0 {
+			return errors.New("command must be provided")
+		}
+
+		request := &proto.ExecInVMRequest{
+			VMID:     id,
+			Args:     args,
+			Env:      context.StringSlice("env"),
+			Cwd:      context.String("cwd"),
+			Terminal: context.Bool("tty"),
+		}
+		var exitStatus uint32
+		err = withClient(context, func(ctx gocontext.Context, client *fcclient.Client) error {
+			exitStatus, err = execInVM(ctx, client, request)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		if exitStatus != 0 {
+			return cli.NewExitError("", int(exitStatus))
+		}
+		return nil
+	},
+}
+
+// execInVM runs the process of the request with the stdio of firecracker-ctr and returns its
+// exit status.
+func execInVM(ctx gocontext.Context, client *fcclient.Client, request *proto.ExecInVMRequest) (uint32, error) {
+	ctx, cancel := gocontext.WithCancel(ctx)
+	defer cancel()
+
+	dir, err := os.MkdirTemp("", "firecracker-ctr-exec-")
+	if err != nil {
+		return 0, err
+	}
+	defer os.RemoveAll(dir)
+
+	if request.Terminal {
+		current := console.Current()
+		if size, err := current.Size(); err == nil {
+			request.Width, request.Height = uint32(size.Width), uint32(size.Height)
+		}
+		if err := current.SetRaw(); err != nil {
+			return 0, fmt.Errorf("failed to put the terminal in raw mode: %w", err)
+		}
+		defer current.Reset()
+	}
+
+	request.StdinPath = filepath.Join(dir, "stdin")
+	stdin, err := fifo.OpenFifo(ctx, request.StdinPath, syscall.O_WRONLY|syscall.O_CREAT|syscall.O_NONBLOCK, 0700)
+	if err != nil {
+		return 0, err
+	}
+	defer stdin.Close()
+	go func() {
+		defer stdin.Close()
+		_, _ = io.Copy(stdin, os.Stdin)
+	}()
+
+	var outputs errgroup.Group
+	for _, output := range []struct {
+		name string
+		path *string
+		dest io.Writer
+	}{
+		{"stdout", &request.StdoutPath, os.Stdout},
+		{"stderr", &request.StderrPath, os.Stderr},
+	} {
+		if request.Terminal && output.name == "stderr" {
+			continue
+		}
+		*output.path = filepath.Join(dir, output.name)
+		f, err := fifo.OpenFifo(ctx, *output.path, syscall.O_RDONLY|syscall.O_CREAT|syscall.O_NONBLOCK, 0700)
+		if err != nil {
+			return 0, err
+		}
+		defer f.Close()
+		dest := output.dest
+		outputs.Go(func() error {
+			_, err := io.Copy(dest, f)
+			return err
+		})
+	}
+
+	resp, err := client.ExecInVM(ctx, request)
+	if err != nil {
+		return 0, err
+	}
+	if err := outputs.Wait(); err != nil {
+		return 0, err
+	}
+	return resp.ExitStatus, nil
+}
+
+var copyToCommand = cli.Command{
+	Name:  "copy-to",
+	Usage: "extract a tar archive into a directory of a VM",
+	Description: `Extract a tar archive into a directory of a VM, keeping the ownership and mode of the files.
+
+For example, to copy a directory into /srv of a VM:
+
+    tar -C /path/to -c dir | firecracker-ctr vm copy-to vm-1 - /srv`,
+	ArgsUsage: "VM_ID ARCHIVE|- VM_DIR",
+	Action: func(context *cli.Context) error {
+		id, err := vmID(context)
+		if err != nil {
+			return err
+		}
+		archive, vmPath := context.Args().Get(1), context.Args().Get(2)
+		if archive == "" || vmPath == "" {
+			return errors.New("archive and VM directory must be provided")
+		}
+		return withClient(context, func(ctx gocontext.Context, client *fcclient.Client) error {
+			return withArchive(ctx, archive, true, func(path string) error {
+				_, err := client.CopyToVM(ctx, &proto.CopyToVMRequest{
+					VMID:        id,
+					ArchivePath: path,
+					VMPath:      vmPath,
+				})
+				return err
+			})
+		})
+	},
+}
+
+var copyFromCommand = cli.Command{
+	Name:  "copy-from",
+	Usage: "archive a file or directory of a VM in tar format",
+	Description: `Archive a file or directory of a VM, along with the ownership and mode of its files.
+
+For example, to copy /var/log of a VM into the current directory:
+
+    firecracker-ctr vm copy-from vm-1 /var/log - | tar -x
+
+An ARCHIVE other than - must not exist yet.`,
+	ArgsUsage: "VM_ID VM_PATH ARCHIVE|-",
+	Action: func(context *cli.Context) error {
+		id, err := vmID(context)
+		if err != nil {
+			return err
+		}
+		vmPath, archive := context.Args().Get(1), context.Args().Get(2)
+		if vmPath == "" || archive == "" {
+			return errors.New("VM path and archive must be provided")
+		}
+		return withClient(context, func(ctx gocontext.Context, client *fcclient.Client) error {
+			return withArchive(ctx, archive, false, func(path string) error {
+				_, err := client.CopyFromVM(ctx, &proto.CopyFromVMRequest{
+					VMID:        id,
+					VMPath:      vmPath,
+					ArchivePath: path,
+				})
+				return err
+			})
+		})
+	},
+}
+
+// withArchive calls copy with the path the plugin is to read or write an archive at. If it is "-",
+// the archive is streamed through a FIFO, from stdin when copying to the VM or to stdout otherwise.
+func withArchive(ctx gocontext.Context, archive string, toVM bool, copy func(string) error) error {
+	if archive != "-" {
+		path, err := absPath(archive)
+		if err != nil {
+			return err
+		}
+		return copy(path)
+	}
+
+	ctx, cancel := gocontext.WithCancel(ctx)
+	defer cancel()
+
+	dir, err := os.MkdirTemp("", "firecracker-ctr-copy-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "archive")
+	if toVM {
+		f, err := fifo.OpenFifo(ctx, path, syscall.O_WRONLY|syscall.O_CREAT|syscall.O_NONBLOCK, 0700)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		go func() {
+			defer f.Close()
+			_, _ = io.Copy(f, os.Stdin)
+		}()
+		// the archive has been extracted once copy returns, whether or not stdin was read to its end
+		return copy(path)
+	}
+
+	f, err := fifo.OpenFifo(ctx, path, syscall.O_RDONLY|syscall.O_CREAT|syscall.O_NONBLOCK, 0700)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	copied := make(chan error, 1)
+	go func() {
+		_, err := io.Copy(os.Stdout, f)
+		copied <- err
+	}()
+	if err := copy(path); err != nil {
+		return err
+	}
+	return <-copied
+}
@@ -0,0 +1,317 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	gocontext "context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/containerd/containerd/cmd/ctr/commands"
+	"github.com/urfave/cli"
+
+	fcclient "github.com/firecracker-microvm/firecracker-containerd/firecracker-control/client"
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+var vmCommand = cli.Command{
+	Name:  "vm",
+	Usage: "manage Firecracker VMs through the firecracker-control plugin",
+	Subcommands: cli.Commands{
+		createCommand,
+		stopCommand,
+		pauseCommand,
+		resumeCommand,
+		infoCommand,
+		listCommand,
+		metricsCommand,
+		snapshotCommand,
+		driveCommand,
+		networkCommand,
+		balloonCommand,
+		metadataCommand,
+		consoleCommand,
+		execCommand,
+		copyToCommand,
+		copyFromCommand,
+	},
+}
+
+var stopCommand = cli.Command{
+	Name:      "stop",
+	Usage:     "stop a VM",
+	ArgsUsage: "[flags] VM_ID",
+	Flags: []cli.Flag{
+		cli.UintFlag{
+			Name:  "timeout-seconds",
+			Usage: "time to wait for the VM to shut down before it is killed, 0 for the default",
+		},
+		cli.UintFlag{
+			Name:  "grace-period-seconds",
+			Usage: "time the tasks have to exit after SIGTERM before they are killed, 0 for the default",
+		},
+	},
+	Action: func(context *cli.Context) error {
+		id, err := vmID(context)
+		if err != nil {
+			return err
+		}
+		return withClient(context, func(ctx gocontext.Context, client *fcclient.Client) error {
+			_, err := client.StopVM(ctx, &proto.StopVMRequest{
+				VMID:               id,
+				TimeoutSeconds:     uint32(context.Uint("timeout-seconds")),
+				GracePeriodSeconds: uint32(context.Uint("grace-period-seconds")),
+			})
+			return err
+		})
+	},
+}
+
+var pauseCommand = cli.Command{
+	Name:      "pause",
+	Usage:     "pause a VM",
+	ArgsUsage: "VM_ID",
+	Action: func(context *cli.Context) error {
+		id, err := vmID(context)
+		if err != nil {
+			return err
+		}
+		return withClient(context, func(ctx gocontext.Context, client *fcclient.Client) error {
+			_, err := client.PauseVM(ctx, &proto.PauseVMRequest{VMID: id})
+			return err
+		})
+	},
+}
+
+var resumeCommand = cli.Command{
+	Name:      "resume",
+	Usage:     "resume a paused VM",
+	ArgsUsage: "VM_ID",
+	Action: func(context *cli.Context) error {
+		id, err := vmID(context)
+		if err != nil {
+			return err
+		}
+		return withClient(context, func(ctx gocontext.Context, client *fcclient.Client) error {
+			_, err := client.ResumeVM(ctx, &proto.ResumeVMRequest{VMID: id})
+			return err
+		})
+	},
+}
+
+var infoCommand = cli.Command{
+	Name:      "info",
+	Usage:     "get information about a VM",
+	ArgsUsage: "[flags] VM_ID",
+	Flags:     []cli.Flag{formatFlag},
+	Action: func(context *cli.Context) error {
+		id, err := vmID(context)
+		if err != nil {
+			return err
+		}
+		return withClient(context, func(ctx gocontext.Context, client *fcclient.Client) error {
+			resp, err := client.GetVMInfo(ctx, &proto.GetVMInfoRequest{VMID: id})
+			if err != nil {
+				return err
+			}
+			return printResponse(context, resp)
+		})
+	},
+}
+
+var listCommand = cli.Command{
+	Name:    "list",
+	Aliases: []string{"ls"},
+	Usage:   "list the VMs",
+	Flags:   []cli.Flag{formatFlag},
+	Action: func(context *cli.Context) error {
+		return withClient(context, func(ctx gocontext.Context, client *fcclient.Client) error {
+			resp, err := client.ListVMs(ctx, &proto.ListVMsRequest{})
+			if err != nil {
+				return err
+			}
+			return printResponse(context, resp)
+		})
+	},
+}
+
+var metricsCommand = cli.Command{
+	Name:      "metrics",
+	Usage:     "get the latest metrics Firecracker flushed for a VM",
+	ArgsUsage: "[flags] VM_ID",
+	Flags:     []cli.Flag{formatFlag},
+	Action: func(context *cli.Context) error {
+		id, err := vmID(context)
+		if err != nil {
+			return err
+		}
+		return withClient(context, func(ctx gocontext.Context, client *fcclient.Client) error {
+			resp, err := client.GetVMMetrics(ctx, &proto.GetVMMetricsRequest{VMID: id})
+			if err != nil {
+				return err
+			}
+			return printResponse(context, resp)
+		})
+	},
+}
+
+var snapshotCommand = cli.Command{
+	Name:      "snapshot",
+	Usage:     "create a snapshot of a paused VM",
+	ArgsUsage: "[flags] VM_ID MEM_FILE SNAPSHOT_FILE",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "diff",
+			Usage: "create a diff snapshot, which requires dirty page tracking",
+		},
+	},
+	Action: func(context *cli.Context) error {
+		id, err := vmID(context)
+		if err != nil {
+			return err
+		}
+		memFile, snapshotFile := context.Args().Get(1), context.Args().Get(2)
+		if memFile == "" || snapshotFile == "" {
+			return errors.New("memory file and snapshot file must be provided")
+		}
+		request := &proto.CreateSnapshotRequest{VMID: id, SnapshotType: proto.SnapshotType_FULL}
+		if context.Bool("diff") {
+			request.SnapshotType = proto.SnapshotType_DIFF
+		}
+		if request.MemFilePath, err = absPath(memFile); err != nil {
+			return err
+		}
+		if request.SnapshotPath, err = absPath(snapshotFile); err != nil {
+			return err
+		}
+		return withClient(context, func(ctx gocontext.Context, client *fcclient.Client) error {
+			_, err := client.CreateSnapshot(ctx, request)
+			return err
+		})
+	},
+}
+
+var metadataCommand = cli.Command{
+	Name:  "metadata",
+	Usage: "manage the metadata MMDS serves to a VM",
+	Subcommands: cli.Commands{
+		{
+			Name:      "get",
+			Usage:     "print the metadata of a VM as JSON",
+			ArgsUsage: "VM_ID",
+			Action: func(context *cli.Context) error {
+				id, err := vmID(context)
+				if err != nil {
+					return err
+				}
+				return withClient(context, func(ctx gocontext.Context, client *fcclient.Client) error {
+					resp, err := client.GetVMMetadata(ctx, &proto.GetVMMetadataRequest{VMID: id})
+					if err != nil {
+						return err
+					}
+					_, err = os.Stdout.WriteString(resp.Metadata + "\n")
+					return err
+				})
+			},
+		},
+		{
+			Name:      "set",
+			Usage:     "replace the metadata of a VM with a JSON document",
+			ArgsUsage: "VM_ID JSON",
+			Action: func(context *cli.Context) error {
+				id, metadata, err := vmMetadataArgs(context)
+				if err != nil {
+					return err
+				}
+				return withClient(context, func(ctx gocontext.Context, client *fcclient.Client) error {
+					_, err := client.SetVMMetadata(ctx, &proto.SetVMMetadataRequest{VMID: id, Metadata: metadata})
+					return err
+				})
+			},
+		},
+		{
+			Name:      "update",
+			Usage:     "merge a JSON document into the metadata of a VM",
+			ArgsUsage: "VM_ID JSON",
+			Action: func(context *cli.Context) error {
+				id, metadata, err := vmMetadataArgs(context)
+				if err != nil {
+					return err
+				}
+				return withClient(context, func(ctx gocontext.Context, client *fcclient.Client) error {
+					_, err := client.UpdateVMMetadata(ctx, &proto.UpdateVMMetadataRequest{VMID: id, Metadata: metadata})
+					return err
+				})
+			},
+		},
+	},
+}
+
+// vmMetadataArgs returns the VM ID and metadata arguments. The metadata is read from stdin if it
+// is "-".
+func vmMetadataArgs(context *cli.Context) (string, string, error) {
+	id, err := vmID(context)
+	if err != nil {
+		return "", "", err
+	}
+	metadata := context.Args().Get(1)
+	if metadata == "" {
+		return "", "", errors.New("metadata must be provided")
+	}
+	if metadata == "-" {
+		b, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to read metadata: %w", err)
+		}
+		metadata = string(b)
+	}
+	return id, metadata, nil
+}
+
+// vmID returns the VM ID the arguments of a command start with.
+func vmID(context *cli.Context) (string, error) {
+	id := context.Args().First()
+	if id == "" {
+		return "", errors.New("VM ID must be provided")
+	}
+	return id, nil
+}
+
+// absPath returns the absolute path of a file given on the command line, as the plugin and its
+// shims don't resolve paths relative to the working directory of firecracker-ctr.
+func absPath(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to get absolute path of %q: %w", path, err)
+	}
+	return abs, nil
+}
+
+// withClient calls fn with a client of the firecracker-control plugin of the containerd the
+// global flags point to, and a context in the namespace they select.
+func withClient(context *cli.Context, fn func(gocontext.Context, *fcclient.Client) error) error {
+	ctx, cancel := commands.AppContext(context)
+	defer cancel()
+
+	client, err := fcclient.New(context.GlobalString("address") + ".ttrpc")
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	return fn(ctx, client)
+}
@@ -0,0 +1,229 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package service
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/containerd/containerd/archive/compression"
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/platforms"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+// vmImages resolves the kernels and root filesystems of CreateVM requests given as images of the
+// containerd image store to files of the host. The files are extracted once into a cache which
+// is keyed by the digest of the layer holding them, so VMs booted from an image keep using the
+// same file even if the name of the image is later pointed at another one.
+type vmImages struct {
+	images   images.Store
+	content  content.Store
+	cacheDir string
+}
+
+// resolvedImages holds the digests of the images of a CreateVM request.
+type resolvedImages struct {
+	kernel    digest.Digest
+	rootDrive digest.Digest
+}
+
+// resolveRequest returns a copy of req whose images are replaced by the paths of the files they
+// hold, along with the digests of the images.
+func (v *vmImages) resolveRequest(ctx context.Context, req *proto.CreateVMRequest) (*proto.CreateVMRequest, resolvedImages, error) {
+	var resolved resolvedImages
+	if req.KernelImage == nil && req.RootDriveImage == nil {
+		return req, resolved, nil
+	}
+
+	if req.KernelImage != nil && req.KernelImagePath != "" {
+		return nil, resolved, status.Error(codes.InvalidArgument, "KernelImage and KernelImagePath cannot both be set")
+	}
+	if req.RootDriveImage != nil {
+		if req.RootDrive.GetHostPath() != "" {
+			return nil, resolved, status.Error(codes.InvalidArgument, "RootDriveImage and the host path of RootDrive cannot both be set")
+		}
+		if req.RootDrive.GetIsWritable() {
+			return nil, resolved, status.Error(codes.InvalidArgument, "a root drive from an image cannot be writable")
+		}
+	}
+
+	req = protobuf.Clone(req).(*proto.CreateVMRequest)
+
+	if req.KernelImage != nil {
+		path, dgst, err := v.resolve(ctx, req.KernelImage)
+		if err != nil {
+			return nil, resolved, err
+		}
+		req.KernelImagePath = path
+		req.KernelImage = nil
+		resolved.kernel = dgst
+	}
+
+	if req.RootDriveImage != nil {
+		path, dgst, err := v.resolve(ctx, req.RootDriveImage)
+		if err != nil {
+			return nil, resolved, err
+		}
+		if req.RootDrive == nil {
+			req.RootDrive = &proto.FirecrackerRootDrive{}
+		}
+		req.RootDrive.HostPath = path
+		req.RootDriveImage = nil
+		resolved.rootDrive = dgst
+	}
+
+	return req, resolved, nil
+}
+
+// resolve returns the path of the file held by the given image along with the digest of the
+// image, which the file is extracted for if it isn't cached already.
+func (v *vmImages) resolve(ctx context.Context, image *proto.VMImage) (string, digest.Digest, error) {
+	img, err := v.images.Get(ctx, image.Ref)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return "", "", status.Errorf(codes.NotFound, "image %q was not found, it must be pulled first", image.Ref)
+		}
+		return "", "", fmt.Errorf("failed to get image %q: %w", image.Ref, err)
+	}
+
+	if image.Digest != "" && img.Target.Digest.String() != image.Digest {
+		return "", "", status.Errorf(codes.FailedPrecondition,
+			"image %q resolves to %s rather than the pinned %s", image.Ref, img.Target.Digest, image.Digest)
+	}
+
+	manifest, err := images.Manifest(ctx, v.content, img.Target, platforms.Default())
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get the manifest of image %q: %w", image.Ref, err)
+	}
+	if len(manifest.Layers) != 1 {
+		return "", "", status.Errorf(codes.FailedPrecondition,
+			"image %q has %d layers rather than a single one", image.Ref, len(manifest.Layers))
+	}
+	layer := manifest.Layers[0]
+
+	path := filepath.Join(v.cacheDir, layer.Digest.Algorithm().String(), layer.Digest.Encoded())
+	if _, err := os.Stat(path); err == nil {
+		return path, img.Target.Digest, nil
+	} else if !os.IsNotExist(err) {
+		return "", "", fmt.Errorf("failed to stat %q: %w", path, err)
+	}
+
+	if err := v.extract(ctx, layer, path); err != nil {
+		return "", "", fmt.Errorf("failed to extract image %q: %w", image.Ref, err)
+	}
+	return path, img.Target.Digest, nil
+}
+
+// extract writes the file held by layer to path. The file is written to a temporary file first
+// so that concurrent requests for the same image never see a partial file.
+func (v *vmImages) extract(ctx context.Context, layer ocispec.Descriptor, path string) error {
+	ra, err := v.content.ReaderAt(ctx, layer)
+	if err != nil {
+		return fmt.Errorf("failed to read layer %s: %w", layer.Digest, err)
+	}
+	defer ra.Close()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".extract-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	var r io.Reader = content.NewReader(ra)
+	if images.IsLayerType(layer.MediaType) {
+		r, err = singleFileOfLayer(r)
+		if err != nil {
+			return err
+		}
+	}
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		return fmt.Errorf("failed to write %q: %w", tmp.Name(), err)
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// singleFileOfLayer returns the content of the only regular file of a container image layer,
+// e.g. one built from a Dockerfile copying the file into an empty image.
+func singleFileOfLayer(layer io.Reader) (io.Reader, error) {
+	decompressed, err := compression.DecompressStream(layer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress layer: %w", err)
+	}
+
+	tr := tar.NewReader(decompressed)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, status.Error(codes.FailedPrecondition, "layer holds no regular file")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read layer: %w", err)
+		}
+		if hdr.Typeflag == tar.TypeReg {
+			return &singleFileReader{tr: tr}, nil
+		}
+	}
+}
+
+// singleFileReader reads the current file of a tar archive, and fails if the archive holds
+// another regular file after it.
+type singleFileReader struct {
+	tr *tar.Reader
+}
+
+func (r *singleFileReader) Read(p []byte) (int, error) {
+	n, err := r.tr.Read(p)
+	if !errors.Is(err, io.EOF) {
+		return n, err
+	}
+
+	for {
+		hdr, err := r.tr.Next()
+		if errors.Is(err, io.EOF) {
+			return n, io.EOF
+		}
+		if err != nil {
+			return n, fmt.Errorf("failed to read layer: %w", err)
+		}
+		if hdr.Typeflag == tar.TypeReg {
+			return n, status.Error(codes.FailedPrecondition, "layer holds more than one regular file")
+		}
+	}
+}
@@ -0,0 +1,259 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package service
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/containerd/containerd/content"
+	contentlocal "github.com/containerd/containerd/content/local"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/images"
+	"github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+// imageStore is an images.Store holding images by name in memory.
+type imageStore struct {
+	images.Store
+	images map[string]images.Image
+}
+
+func (s *imageStore) Get(_ context.Context, name string) (images.Image, error) {
+	img, ok := s.images[name]
+	if !ok {
+		return images.Image{}, errdefs.ErrNotFound
+	}
+	return img, nil
+}
+
+func newTestVMImages(t *testing.T) *vmImages {
+	store, err := contentlocal.NewStore(t.TempDir())
+	require.NoError(t, err)
+	return &vmImages{
+		images:   &imageStore{images: make(map[string]images.Image)},
+		content:  store,
+		cacheDir: t.TempDir(),
+	}
+}
+
+func writeBlob(t *testing.T, store content.Store, mediaType string, data []byte) ocispec.Descriptor {
+	desc := ocispec.Descriptor{
+		MediaType: mediaType,
+		Digest:    digest.FromBytes(data),
+		Size:      int64(len(data)),
+	}
+	err := content.WriteBlob(context.Background(), store, desc.Digest.String(), bytes.NewReader(data), desc)
+	require.NoError(t, err)
+	return desc
+}
+
+// addImage adds an image of the given layers to the stores and returns the digest of its manifest.
+func addImage(t *testing.T, v *vmImages, name string, layers ...ocispec.Descriptor) digest.Digest {
+	config, err := json.Marshal(ocispec.Image{Platform: ocispec.Platform{OS: "linux", Architecture: runtime.GOARCH}})
+	require.NoError(t, err)
+
+	manifest, err := json.Marshal(ocispec.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config:    writeBlob(t, v.content, ocispec.MediaTypeImageConfig, config),
+		Layers:    layers,
+	})
+	require.NoError(t, err)
+
+	target := writeBlob(t, v.content, ocispec.MediaTypeImageManifest, manifest)
+	v.images.(*imageStore).images[name] = images.Image{Name: name, Target: target}
+	return target.Digest
+}
+
+func tarLayer(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "boot/", Typeflag: tar.TypeDir, Mode: 0755}))
+	for _, name := range []string{"boot/vmlinux", "boot/rootfs.img"} {
+		data, ok := files[name]
+		if !ok {
+			continue
+		}
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(data))}))
+		_, err := tw.Write([]byte(data))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	return buf.Bytes()
+}
+
+func TestVMImagesResolve(t *testing.T) {
+	ctx := context.Background()
+	v := newTestVMImages(t)
+
+	raw := writeBlob(t, v.content, "application/octet-stream", []byte("raw kernel"))
+	rawImage := addImage(t, v, "raw", raw)
+
+	single := writeBlob(t, v.content, ocispec.MediaTypeImageLayer, tarLayer(t, map[string]string{"boot/vmlinux": "tar kernel"}))
+	singleImage := addImage(t, v, "single", single)
+
+	double := writeBlob(t, v.content, ocispec.MediaTypeImageLayer,
+		tarLayer(t, map[string]string{"boot/vmlinux": "kernel", "boot/rootfs.img": "rootfs"}))
+	addImage(t, v, "double", double)
+
+	addImage(t, v, "multi", raw, single)
+
+	t.Run("raw blob", func(t *testing.T) {
+		path, dgst, err := v.resolve(ctx, &proto.VMImage{Ref: "raw"})
+		require.NoError(t, err)
+		assert.Equal(t, rawImage, dgst)
+		assert.Equal(t, filepath.Join(v.cacheDir, "sha256", raw.Digest.Encoded()), path)
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "raw kernel", string(data))
+	})
+
+	t.Run("tar layer", func(t *testing.T) {
+		path, dgst, err := v.resolve(ctx, &proto.VMImage{Ref: "single", Digest: singleImage.String()})
+		require.NoError(t, err)
+		assert.Equal(t, singleImage, dgst)
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "tar kernel", string(data))
+	})
+
+	t.Run("tar layer with two files", func(t *testing.T) {
+		_, _, err := v.resolve(ctx, &proto.VMImage{Ref: "double"})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		_, err = os.Stat(filepath.Join(v.cacheDir, "sha256", double.Digest.Encoded()))
+		assert.True(t, os.IsNotExist(err), "no file is left in the cache")
+	})
+
+	t.Run("multiple layers", func(t *testing.T) {
+		_, _, err := v.resolve(ctx, &proto.VMImage{Ref: "multi"})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("digest mismatch", func(t *testing.T) {
+		_, _, err := v.resolve(ctx, &proto.VMImage{Ref: "raw", Digest: singleImage.String()})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("missing image", func(t *testing.T) {
+		_, _, err := v.resolve(ctx, &proto.VMImage{Ref: "missing"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("cache hit", func(t *testing.T) {
+		// the layer isn't in the content store, so only the cached file can be returned
+		layer := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageLayer, Digest: digest.FromString("cached"), Size: 6}
+		addImage(t, v, "cached", layer)
+		path := filepath.Join(v.cacheDir, "sha256", layer.Digest.Encoded())
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte("cached"), 0644))
+
+		resolved, _, err := v.resolve(ctx, &proto.VMImage{Ref: "cached"})
+		require.NoError(t, err)
+		assert.Equal(t, path, resolved)
+	})
+}
+
+func TestSingleFileOfLayer(t *testing.T) {
+	r, err := singleFileOfLayer(bytes.NewReader(tarLayer(t, map[string]string{"boot/rootfs.img": "rootfs"})))
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(r)
+	require.NoError(t, err)
+	assert.Equal(t, "rootfs", buf.String())
+
+	r, err = singleFileOfLayer(bytes.NewReader(tarLayer(t, map[string]string{"boot/vmlinux": "kernel", "boot/rootfs.img": "rootfs"})))
+	require.NoError(t, err)
+	_, err = buf.ReadFrom(r)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = singleFileOfLayer(bytes.NewReader(tarLayer(t, nil)))
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestVMImagesResolveRequest(t *testing.T) {
+	ctx := context.Background()
+	v := newTestVMImages(t)
+
+	kernel := writeBlob(t, v.content, "application/octet-stream", []byte("kernel"))
+	kernelImage := addImage(t, v, "kernel", kernel)
+	rootfs := writeBlob(t, v.content, ocispec.MediaTypeImageLayer, tarLayer(t, map[string]string{"boot/rootfs.img": "rootfs"}))
+	rootfsImage := addImage(t, v, "rootfs", rootfs)
+
+	plain := &proto.CreateVMRequest{KernelImagePath: "/vmlinux"}
+	req, resolved, err := v.resolveRequest(ctx, plain)
+	require.NoError(t, err)
+	assert.Same(t, plain, req, "requests without images are left as is")
+	assert.Equal(t, resolvedImages{}, resolved)
+
+	original := &proto.CreateVMRequest{
+		VMID:           "vm",
+		KernelImage:    &proto.VMImage{Ref: "kernel"},
+		RootDrive:      &proto.FirecrackerRootDrive{Partuuid: "1234"},
+		RootDriveImage: &proto.VMImage{Ref: "rootfs"},
+	}
+	req, resolved, err = v.resolveRequest(ctx, original)
+	require.NoError(t, err)
+	assert.Equal(t, resolvedImages{kernel: kernelImage, rootDrive: rootfsImage}, resolved)
+	assert.Nil(t, req.KernelImage)
+	assert.Nil(t, req.RootDriveImage)
+	assert.Equal(t, filepath.Join(v.cacheDir, "sha256", kernel.Digest.Encoded()), req.KernelImagePath)
+	assert.Equal(t, filepath.Join(v.cacheDir, "sha256", rootfs.Digest.Encoded()), req.RootDrive.HostPath)
+	assert.Equal(t, "1234", req.RootDrive.Partuuid)
+	assert.NotNil(t, original.KernelImage, "the request is copied rather than modified")
+
+	for _, tc := range []struct {
+		name    string
+		request *proto.CreateVMRequest
+	}{
+		{
+			name:    "kernel image and path",
+			request: &proto.CreateVMRequest{KernelImage: &proto.VMImage{Ref: "kernel"}, KernelImagePath: "/vmlinux"},
+		},
+		{
+			name: "root drive image and path",
+			request: &proto.CreateVMRequest{
+				RootDriveImage: &proto.VMImage{Ref: "rootfs"},
+				RootDrive:      &proto.FirecrackerRootDrive{HostPath: "/rootfs.img"},
+			},
+		},
+		{
+			name: "writable root drive image",
+			request: &proto.CreateVMRequest{
+				RootDriveImage: &proto.VMImage{Ref: "rootfs"},
+				RootDrive:      &proto.FirecrackerRootDrive{IsWritable: true},
+			},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := v.resolveRequest(ctx, tc.request)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+}
@@ -19,13 +19,16 @@ import (
 	"net"
 	"os"
 	"os/exec"
+	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
 
 	"github.com/containerd/containerd/identifiers"
 	"github.com/containerd/containerd/log"
+	"github.com/containerd/containerd/metadata"
 	"github.com/containerd/containerd/namespaces"
 	"github.com/containerd/containerd/plugin"
 	"github.com/containerd/containerd/protobuf/types"
@@ -49,12 +52,18 @@ var (
 	_               fccontrolTtrpc.FirecrackerService = (*local)(nil)
 	ttrpcAddressEnv                                   = "TTRPC_ADDRESS"
 	stopVMInterval                                    = 10 * time.Millisecond
+	// listVMInfoTimeout bounds the time ListVMs waits for each shim, so that a hung shim doesn't
+	// hold the whole list up
+	listVMInfoTimeout = 5 * time.Second
 )
 
 func init() {
 	plugin.Register(&plugin.Registration{
 		Type: plugin.ServicePlugin,
 		ID:   localPluginID,
+		Requires: []plugin.Type{
+			plugin.MetadataPlugin,
+		},
 		InitFn: func(ic *plugin.InitContext) (interface{}, error) {
 			log.G(ic.Context).Debugf("initializing %s plugin (root: %q)", localPluginID, ic.Root)
 			return newLocal(ic)
@@ -68,7 +77,21 @@ type local struct {
 	config            *config.Config
 
 	processesMu sync.Mutex
-	processes   map[string]int32
+	processes   map[string]shimProcess
+	// aliases maps the shim socket address of a claimed pooled VM to the VMID it was claimed as
+	aliases map[string]string
+
+	pools   []*vmPool
+	metrics *controlMetrics
+	images  *vmImages
+}
+
+// shimProcess tracks a runtime shim spawned by the plugin, keyed in local.processes by
+// the shim's socket address.
+type shimProcess struct {
+	namespace string
+	vmID      string
+	pid       int32
 }
 
 func newLocal(ic *plugin.InitContext) (*local, error) {
@@ -81,20 +104,55 @@ func newLocal(ic *plugin.InitContext) (*local, error) {
 		return nil, fmt.Errorf("failed to load config: %w", err)
 	}
 
-	return &local{
+	s := &local{
 		containerdAddress: ic.Address,
 		logger:            log.G(ic.Context),
 		config:            cfg,
-		processes:         make(map[string]int32),
-	}, nil
+		processes:         make(map[string]shimProcess),
+		aliases:           make(map[string]string),
+	}
+
+	m, err := ic.Get(plugin.MetadataPlugin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metadata plugin: %w", err)
+	}
+	db := m.(*metadata.DB)
+	s.images = &vmImages{
+		images:   metadata.NewImageStore(db),
+		content:  db.ContentStore(),
+		cacheDir: cfg.ImageCacheDir,
+	}
+
+	s.pools, err = newVMPools(cfg.VMPools)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VM pool config: %w", err)
+	}
+
+	s.metrics = newControlMetrics(s)
+	if cfg.MetricsAddress != "" {
+		if err := s.serveMetrics(ic.Context, cfg.MetricsAddress); err != nil {
+			return nil, fmt.Errorf("failed to serve metrics: %w", err)
+		}
+		go s.metrics.watchVMEvents(ic.Context, ic.Events)
+	}
+
+	// Shims outlive containerd, so pick up the ones spawned before it was restarted
+	if err := s.rediscoverShims(ic.Context); err != nil {
+		s.logger.WithError(err).Error("failed to rediscover shims")
+	}
+
+	for _, pool := range s.pools {
+		go s.fillPool(ic.Context, pool)
+	}
+
+	return s, nil
 }
 
 // CreateVM creates new Firecracker VM instance. It creates a runtime shim for the VM and the forwards
 // the CreateVM request to that shim. If there is already a VM created with the provided VMID, then
-// AlreadyExists is returned.
+// AlreadyExists is returned. Requests matching the profile of a VM pool are handed one of its
+// pre-booted VMs instead, if there is any.
 func (s *local) CreateVM(requestCtx context.Context, req *proto.CreateVMRequest) (*proto.CreateVMResponse, error) {
-	var err error
-
 	id := req.GetVMID()
 	if err := identifiers.Validate(id); err != nil {
 		s.logger.WithError(err).Error()
@@ -110,6 +168,38 @@ func (s *local) CreateVM(requestCtx context.Context, req *proto.CreateVMRequest)
 
 	s.logger.Debugf("using namespace: %s", ns)
 
+	req, images, err := s.images.resolveRequest(requestCtx, req)
+	if err != nil {
+		err = fmt.Errorf("failed to resolve VM images: %w", err)
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
+
+	resp, err := s.createVM(requestCtx, ns, req, false)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.KernelImageDigest = images.kernel.String()
+	resp.RootDriveImageDigest = images.rootDrive.String()
+	return resp, nil
+}
+
+// createVM spawns a shim for the requested VM, unless a pooled VM can be claimed. If pooled is
+// true, the VM is booted into a pool and can be claimed later on.
+func (s *local) createVM(requestCtx context.Context, ns string, req *proto.CreateVMRequest, pooled bool) (*proto.CreateVMResponse, error) {
+	var err error
+
+	// Only the phases of CreateVM requests are observed, not the ones of VMs booted into pools
+	start := time.Now()
+	observe := func(phase string, phaseStart time.Time) {
+		if !pooled {
+			s.metrics.observeCreateVM(phase, phaseStart)
+		}
+	}
+
+	id := req.GetVMID()
+
 	// We determine if there is already a shim managing a VM with the current VMID by attempting
 	// to listen on the abstract socket address (which is parameterized by VMID). If we get
 	// EADDRINUSE, then we assume there is already a shim for the VM and return an AlreadyExists error.
@@ -129,6 +219,19 @@ func (s *local) CreateVM(requestCtx context.Context, req *proto.CreateVMRequest)
 		return nil, err
 	}
 
+	if !pooled {
+		resp, claimed, err := s.claimPooledVM(requestCtx, ns, req, shimSocket)
+		if claimed {
+			observe(createVMPhasePoolClaim, start)
+			observe(createVMPhaseTotal, start)
+		}
+		if claimed || err != nil {
+			return resp, err
+		}
+	}
+
+	shimStart := time.Now()
+
 	// If we're here, there is no pre-existing shim for this VMID, so we spawn a new one
 	if err := os.Mkdir(s.config.ShimBaseDir, 0700); err != nil && !os.IsExist(err) {
 		s.logger.WithError(err).Error()
@@ -176,7 +279,7 @@ func (s *local) CreateVM(requestCtx context.Context, req *proto.CreateVMRequest)
 		return nil, err
 	}
 
-	cmd, err := s.newShim(ns, id, s.containerdAddress, shimSocket, fcSocket)
+	cmd, err := s.newShim(ns, id, s.containerdAddress, shimSocket, fcSocket, pooled)
 	if err != nil {
 		return nil, err
 	}
@@ -196,21 +299,31 @@ func (s *local) CreateVM(requestCtx context.Context, req *proto.CreateVMRequest)
 
 	defer client.Close()
 
+	observe(createVMPhaseShimStart, shimStart)
+	bootStart := time.Now()
+
 	resp, err := client.CreateVM(requestCtx, req)
 	if err != nil {
 		s.logger.WithError(err).Error("shim CreateVM returned error")
 		return nil, err
 	}
 
-	s.addShim(shimSocketAddress, cmd)
+	observe(createVMPhaseVMBoot, bootStart)
+	observe(createVMPhaseTotal, start)
+
+	s.addShim(shimSocketAddress, ns, id, cmd)
 
 	return resp, nil
 }
 
-func (s *local) addShim(address string, cmd *exec.Cmd) {
+func (s *local) addShim(address, ns, vmID string, cmd *exec.Cmd) {
 	s.processesMu.Lock()
 	defer s.processesMu.Unlock()
-	s.processes[address] = int32(cmd.Process.Pid)
+	s.processes[address] = shimProcess{
+		namespace: ns,
+		vmID:      vmID,
+		pid:       int32(cmd.Process.Pid),
+	}
 }
 
 func (s *local) shimFirecrackerClient(requestCtx context.Context, vmID string) (*fcclient.Client, error) {
@@ -230,7 +343,9 @@ func (s *local) shimFirecrackerClient(requestCtx context.Context, vmID string) (
 
 // StopVM stops running VM instance by VM ID. This stops the VM, all tasks within the VM and the runtime shim
 // managing the VM.
-func (s *local) StopVM(requestCtx context.Context, req *proto.StopVMRequest) (*types.Empty, error) {
+func (s *local) StopVM(requestCtx context.Context, req *proto.StopVMRequest) (_ *types.Empty, err error) {
+	defer func() { s.metrics.observeStopVM(err) }()
+
 	client, err := s.shimFirecrackerClient(requestCtx, req.VMID)
 	if err != nil {
 		return nil, err
@@ -283,6 +398,101 @@ func (s *local) ResumeVM(ctx context.Context, req *proto.ResumeVMRequest) (*type
 	return resp, nil
 }
 
+// CreateSnapshot creates a snapshot of the memory and state of the VM with the given VMID
+func (s *local) CreateSnapshot(ctx context.Context, req *proto.CreateSnapshotRequest) (*types.Empty, error) {
+	client, err := s.shimFirecrackerClient(ctx, req.VMID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer client.Close()
+
+	resp, err := client.CreateSnapshot(ctx, req)
+	if err != nil {
+		err = fmt.Errorf("shim client failed to create snapshot: %w", err)
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// AttachDriveMount attaches a drive mount to the VM with the given VMID
+func (s *local) AttachDriveMount(ctx context.Context, req *proto.AttachDriveMountRequest) (*types.Empty, error) {
+	client, err := s.shimFirecrackerClient(ctx, req.VMID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer client.Close()
+
+	resp, err := client.AttachDriveMount(ctx, req)
+	if err != nil {
+		err = fmt.Errorf("shim client failed to attach drive mount: %w", err)
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// DetachDriveMount detaches a drive mount from the VM with the given VMID
+func (s *local) DetachDriveMount(ctx context.Context, req *proto.DetachDriveMountRequest) (*types.Empty, error) {
+	client, err := s.shimFirecrackerClient(ctx, req.VMID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer client.Close()
+
+	resp, err := client.DetachDriveMount(ctx, req)
+	if err != nil {
+		err = fmt.Errorf("shim client failed to detach drive mount: %w", err)
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// UpdateDrive updates the rate limiter of a drive of the VM with the given VMID
+func (s *local) UpdateDrive(ctx context.Context, req *proto.UpdateDriveRequest) (*types.Empty, error) {
+	client, err := s.shimFirecrackerClient(ctx, req.VMID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer client.Close()
+
+	resp, err := client.UpdateDrive(ctx, req)
+	if err != nil {
+		err = fmt.Errorf("shim client failed to update drive: %w", err)
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// ListDrives returns the drives of the VM with the given VMID
+func (s *local) ListDrives(ctx context.Context, req *proto.ListDrivesRequest) (*proto.ListDrivesResponse, error) {
+	client, err := s.shimFirecrackerClient(ctx, req.VMID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer client.Close()
+
+	resp, err := client.ListDrives(ctx, req)
+	if err != nil {
+		err = fmt.Errorf("shim client failed to list drives: %w", err)
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
+
+	return resp, nil
+}
+
 func (s *local) waitForShimToExit(ctx context.Context, vmID string) error {
 	socketAddr, err := shim.SocketAddress(ctx, s.containerdAddress, vmID)
 	if err != nil {
@@ -292,13 +502,13 @@ func (s *local) waitForShimToExit(ctx context.Context, vmID string) error {
 	s.processesMu.Lock()
 	defer s.processesMu.Unlock()
 
-	pid, ok := s.processes[socketAddr]
+	proc, ok := s.processes[socketAddr]
 	if !ok {
 		return fmt.Errorf("failed to find a shim process for %q", socketAddr)
 	}
 	defer delete(s.processes, socketAddr)
 
-	return internal.WaitForPidToExit(ctx, stopVMInterval, pid)
+	return internal.WaitForPidToExit(ctx, stopVMInterval, proc.pid)
 }
 
 // GetVMInfo returns metadata for the VM with the given VMID.
@@ -320,6 +530,81 @@ func (s *local) GetVMInfo(requestCtx context.Context, req *proto.GetVMInfoReques
 	return resp, nil
 }
 
+// ListVMs returns metadata for every VM in the caller's namespace. The shims are asked in
+// parallel, each within listVMInfoTimeout. VMs whose shim could not be reached in time are still
+// listed, with their state reported as UNKNOWN.
+func (s *local) ListVMs(requestCtx context.Context, req *proto.ListVMsRequest) (*proto.ListVMsResponse, error) {
+	ns, err := namespaces.NamespaceRequired(requestCtx)
+	if err != nil {
+		err = fmt.Errorf("error retrieving namespace of request: %w", err)
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
+
+	var procs []shimProcess
+	s.processesMu.Lock()
+	for _, proc := range s.processes {
+		// pooled VMs are not listed until they are claimed
+		if proc.namespace == ns && !strings.HasPrefix(proc.vmID, pooledVMIDPrefix) {
+			procs = append(procs, proc)
+		}
+	}
+	s.processesMu.Unlock()
+
+	sort.Slice(procs, func(i, j int) bool { return procs[i].vmID < procs[j].vmID })
+
+	resp := &proto.ListVMsResponse{VMs: make([]*proto.GetVMInfoResponse, len(procs))}
+	var wg sync.WaitGroup
+	for i, proc := range procs {
+		i, proc := i, proc
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp.VMs[i] = s.listedVMInfo(requestCtx, proc)
+		}()
+	}
+	wg.Wait()
+
+	return resp, nil
+}
+
+// listedVMInfo returns the metadata of the VM of the given shim, with its state reported as
+// UNKNOWN if the shim doesn't answer within listVMInfoTimeout.
+func (s *local) listedVMInfo(requestCtx context.Context, proc shimProcess) *proto.GetVMInfoResponse {
+	ctx, cancel := context.WithTimeout(requestCtx, listVMInfoTimeout)
+	defer cancel()
+
+	info, err := s.GetVMInfo(ctx, &proto.GetVMInfoRequest{VMID: proc.vmID})
+	if err != nil {
+		s.logger.WithError(err).WithField("vmID", proc.vmID).Warn("failed to get vm info while listing vms")
+		return &proto.GetVMInfoResponse{
+			VMID:    proc.vmID,
+			ShimPID: uint32(proc.pid),
+			State:   proto.VMState_UNKNOWN,
+		}
+	}
+	return info
+}
+
+// GetVMMetrics returns the metrics Firecracker reported for the VM with the given VMID.
+func (s *local) GetVMMetrics(requestCtx context.Context, req *proto.GetVMMetricsRequest) (*proto.GetVMMetricsResponse, error) {
+	client, err := s.shimFirecrackerClient(requestCtx, req.VMID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer client.Close()
+
+	resp, err := client.GetVMMetrics(requestCtx, req)
+	if err != nil {
+		err = fmt.Errorf("shim client failed to get vm metrics: %w", err)
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
+
+	return resp, nil
+}
+
 // SetVMMetadata sets Firecracker instance metadata for the VM with the given VMID.
 func (s *local) SetVMMetadata(requestCtx context.Context, req *proto.SetVMMetadataRequest) (*types.Empty, error) {
 	client, err := s.shimFirecrackerClient(requestCtx, req.VMID)
@@ -412,6 +697,24 @@ func (s *local) UpdateBalloon(requestCtx context.Context, req *proto.UpdateBallo
 	return resp, nil
 }
 
+// UpdateNetworkInterface updates the rate limiters of a network interface of the VM with the given VMID.
+func (s *local) UpdateNetworkInterface(requestCtx context.Context, req *proto.UpdateNetworkInterfaceRequest) (*types.Empty, error) {
+	client, err := s.shimFirecrackerClient(requestCtx, req.VMID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer client.Close()
+	resp, err := client.UpdateNetworkInterface(requestCtx, req)
+	if err != nil {
+		err = fmt.Errorf("shim client failed to update network interface: %w", err)
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
+
+	return resp, nil
+}
+
 // GetBalloonStats will return the latest balloon device statistics, only if enabled pre-boot.
 func (s *local) GetBalloonStats(requestCtx context.Context, req *proto.GetBalloonStatsRequest) (*proto.GetBalloonStatsResponse, error) {
 	client, err := s.shimFirecrackerClient(requestCtx, req.VMID)
@@ -448,7 +751,102 @@ func (s *local) UpdateBalloonStats(requestCtx context.Context, req *proto.Update
 	return resp, nil
 }
 
-func (s *local) newShim(ns, vmID, containerdAddress string, shimSocket *net.UnixListener, fcSocket *net.UnixListener) (*exec.Cmd, error) {
+// GetConsoleLog returns the latest output of the serial console of the VM with the given VMID.
+func (s *local) GetConsoleLog(requestCtx context.Context, req *proto.GetConsoleLogRequest) (*proto.GetConsoleLogResponse, error) {
+	client, err := s.shimFirecrackerClient(requestCtx, req.VMID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer client.Close()
+
+	resp, err := client.GetConsoleLog(requestCtx, req)
+	if err != nil {
+		err = fmt.Errorf("shim client failed to get console log: %w", err)
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// AttachConsole connects the FIFOs of the request to the serial console of the VM with the given VMID.
+func (s *local) AttachConsole(requestCtx context.Context, req *proto.AttachConsoleRequest) (*types.Empty, error) {
+	client, err := s.shimFirecrackerClient(requestCtx, req.VMID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer client.Close()
+
+	resp, err := client.AttachConsole(requestCtx, req)
+	if err != nil {
+		err = fmt.Errorf("shim client failed to attach console: %w", err)
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// ExecInVM runs a process in the VM with the given VMID outside of any container.
+func (s *local) ExecInVM(requestCtx context.Context, req *proto.ExecInVMRequest) (*proto.ExecInVMResponse, error) {
+	client, err := s.shimFirecrackerClient(requestCtx, req.VMID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer client.Close()
+
+	resp, err := client.ExecInVM(requestCtx, req)
+	if err != nil {
+		err = fmt.Errorf("shim client failed to exec in vm: %w", err)
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// CopyToVM extracts a tar archive of the host into the VM with the given VMID.
+func (s *local) CopyToVM(requestCtx context.Context, req *proto.CopyToVMRequest) (*types.Empty, error) {
+	client, err := s.shimFirecrackerClient(requestCtx, req.VMID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer client.Close()
+
+	resp, err := client.CopyToVM(requestCtx, req)
+	if err != nil {
+		err = fmt.Errorf("shim client failed to copy to vm: %w", err)
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// CopyFromVM writes a tar archive of a file or directory of the VM with the given VMID to the host.
+func (s *local) CopyFromVM(requestCtx context.Context, req *proto.CopyFromVMRequest) (*types.Empty, error) {
+	client, err := s.shimFirecrackerClient(requestCtx, req.VMID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer client.Close()
+
+	resp, err := client.CopyFromVM(requestCtx, req)
+	if err != nil {
+		err = fmt.Errorf("shim client failed to copy from vm: %w", err)
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (s *local) newShim(ns, vmID, containerdAddress string, shimSocket *net.UnixListener, fcSocket *net.UnixListener, pooled bool) (*exec.Cmd, error) {
 	logger := s.logger.WithField("vmID", vmID)
 
 	args := []string{
@@ -495,6 +893,9 @@ func (s *local) newShim(ns, vmID, containerdAddress string, shimSocket *net.Unix
 		fmt.Sprintf("%s=%s", ttrpcAddressEnv, ttrpc),
 		fmt.Sprintf("%s=%s", internal.VMIDEnvVarKey, vmID),
 		fmt.Sprintf("%s=%s", internal.FCSocketFDEnvKey, strconv.Itoa(fcSocketFDNum))) // TODO remove after containerd is updated to expose ttrpc server to shim
+	if pooled {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=1", internal.PooledVMEnvVarKey))
+	}
 
 	cmd.SysProcAttr = &syscall.SysProcAttr{
 		Setpgid: true,
@@ -540,6 +941,8 @@ func (s *local) newShim(ns, vmID, containerdAddress string, shimSocket *net.Unix
 		if err := os.RemoveAll(shimDir.RootPath()); err != nil {
 			logger.WithError(err).Errorf("failed to remove %q", shimDir.RootPath())
 		}
+
+		s.releaseShim(ns, vmID, logger)
 	}()
 
 	err = setShimOOMScore(cmd.Process.Pid)
@@ -0,0 +1,85 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/containerd/containerd/namespaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/firecracker-microvm/firecracker-containerd/internal"
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+// runningShim is a shim whose VM is running.
+type runningShim struct {
+	fakeShim
+}
+
+func (*runningShim) GetVMInfo(_ context.Context, req *proto.GetVMInfoRequest) (*proto.GetVMInfoResponse, error) {
+	return &proto.GetVMInfoResponse{VMID: req.VMID, State: proto.VMState_RUNNING}, nil
+}
+
+// hungShim is a shim which never answers GetVMInfo.
+type hungShim struct {
+	fakeShim
+}
+
+func (*hungShim) GetVMInfo(ctx context.Context, _ *proto.GetVMInfoRequest) (*proto.GetVMInfoResponse, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestListVMs(t *testing.T) {
+	internal.RequiresRoot(t)
+	s := newTestLocal(t)
+
+	timeout := listVMInfoTimeout
+	listVMInfoTimeout = time.Second
+	t.Cleanup(func() { listVMInfoTimeout = timeout })
+
+	track := func(ns, vmID string, pid int32) {
+		s.processes[ns+"/"+vmID] = shimProcess{namespace: ns, vmID: vmID, pid: pid}
+	}
+	track(testNamespace, "running", 10)
+	serveShim(t, s, "running", &runningShim{})
+	for i, vmID := range []string{"hung-1", "hung-2", "hung-3"} {
+		track(testNamespace, vmID, int32(20+i))
+		serveShim(t, s, vmID, &hungShim{})
+	}
+	track(testNamespace, pooledVMIDPrefix+"0", 30)
+	track("other", "elsewhere", 40)
+
+	ctx := namespaces.WithNamespace(context.Background(), testNamespace)
+	start := time.Now()
+	resp, err := s.ListVMs(ctx, &proto.ListVMsRequest{})
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), 3*listVMInfoTimeout, "the hung shims must be waited for in parallel")
+
+	require.Len(t, resp.VMs, 4, "pooled VMs and VMs of other namespaces are not listed")
+	for i, vmID := range []string{"hung-1", "hung-2", "hung-3"} {
+		assert.Equal(t, vmID, resp.VMs[i].VMID)
+		assert.Equal(t, uint32(20+i), resp.VMs[i].ShimPID)
+		assert.Equal(t, proto.VMState_UNKNOWN, resp.VMs[i].State)
+	}
+	assert.Equal(t, "running", resp.VMs[3].VMID)
+	assert.Equal(t, proto.VMState_RUNNING, resp.VMs[3].State)
+
+	_, err = s.ListVMs(context.Background(), &proto.ListVMsRequest{})
+	assert.Error(t, err, "the namespace of the request is required")
+}
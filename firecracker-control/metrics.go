@@ -0,0 +1,326 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/containerd/containerd/events/exchange"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+const (
+	metricsPath          = "/metrics"
+	metricsScrapeTimeout = 5 * time.Second
+
+	// forceTerminateTopic is the topic of the events the runtime shim publishes when it kills
+	// its VM, see ForceTerminateEventName of the runtime.
+	forceTerminateTopic = "/firecracker-vm/force-terminate"
+
+	createVMPhasePoolClaim = "pool_claim"
+	createVMPhaseShimStart = "shim_start"
+	createVMPhaseVMBoot    = "vm_boot"
+	createVMPhaseTotal     = "total"
+)
+
+// vmLabels are the labels of every metric reported for a VM.
+var vmLabels = []string{"namespace", "vmid"}
+
+// controlMetrics are the metrics served by the plugin. Metrics of the VMs are gathered from
+// their shims on each scrape, while the ones of the plugin itself are recorded as they happen.
+type controlMetrics struct {
+	registry *prometheus.Registry
+
+	createVMDuration   *prometheus.HistogramVec
+	stopVMs            *prometheus.CounterVec
+	forcedTerminations *prometheus.CounterVec
+}
+
+func newControlMetrics(s *local) *controlMetrics {
+	m := &controlMetrics{
+		registry: prometheus.NewRegistry(),
+		createVMDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "firecracker_containerd_create_vm_duration_seconds",
+			Help:    "Duration of the phases of successful CreateVM requests.",
+			Buckets: []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60},
+		}, []string{"phase"}),
+		stopVMs: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "firecracker_containerd_stop_vm_total",
+			Help: "Number of StopVM requests by the gRPC code they returned.",
+		}, []string{"code"}),
+		forcedTerminations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "firecracker_containerd_forced_terminations_total",
+			Help: "Number of VMs killed by their shim because they could not be stopped gracefully.",
+		}, []string{"namespace"}),
+	}
+
+	m.registry.MustRegister(m.createVMDuration, m.stopVMs, m.forcedTerminations, &vmCollector{local: s})
+	return m
+}
+
+// observeCreateVM records the duration of a CreateVM phase which started at the given time.
+func (m *controlMetrics) observeCreateVM(phase string, start time.Time) {
+	m.createVMDuration.WithLabelValues(phase).Observe(time.Since(start).Seconds())
+}
+
+// observeStopVM records the outcome of a StopVM request.
+func (m *controlMetrics) observeStopVM(err error) {
+	m.stopVMs.WithLabelValues(status.Code(err).String()).Inc()
+}
+
+// watchVMEvents counts the forced terminations reported by the runtime shims until ctx is done.
+func (m *controlMetrics) watchVMEvents(ctx context.Context, events *exchange.Exchange) {
+	envelopes, errs := events.Subscribe(ctx, fmt.Sprintf("topic==%q", forceTerminateTopic))
+	for {
+		select {
+		case envelope := <-envelopes:
+			m.forcedTerminations.WithLabelValues(envelope.Namespace).Inc()
+		case <-errs:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// serveMetrics serves the metrics at the given TCP address until ctx is done.
+func (s *local) serveMetrics(ctx context.Context, address string) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %q: %w", address, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(metricsPath, promhttp.HandlerFor(s.metrics.registry, promhttp.HandlerOpts{}))
+
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 2 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+
+	go func() {
+		err := server.Serve(listener)
+		if err != http.ErrServerClosed {
+			s.logger.WithError(err).Error("metrics server failed")
+		}
+	}()
+
+	s.logger.Infof("serving metrics at %s%s", listener.Addr(), metricsPath)
+	return nil
+}
+
+func newVMDesc(name, help string, labels ...string) *prometheus.Desc {
+	return prometheus.NewDesc(name, help, append(append([]string{}, vmLabels...), labels...), nil)
+}
+
+var (
+	vcpuExitsDesc    = newVMDesc("firecracker_vcpu_exits_total", "Number of vCPU exits by kind.", "kind")
+	vcpuFailuresDesc = newVMDesc("firecracker_vcpu_failures_total", "Number of vCPU failures.")
+	seccompDesc      = newVMDesc("firecracker_seccomp_faults_total", "Number of syscalls denied by the seccomp filters.")
+	latencyDesc      = newVMDesc("firecracker_latency_microseconds", "Last measured latency of VM operations.", "operation")
+	drivesDesc       = newVMDesc("firecracker_containerd_stub_drives_exhausted_total",
+		"Number of container creations and drive mount attachments which failed because the VM had no stub drive left.")
+
+	blockDescs = map[string]*prometheus.Desc{}
+	netDescs   = map[string]*prometheus.Desc{}
+	vsockDescs = map[string]*prometheus.Desc{}
+	mmdsDescs  = map[string]*prometheus.Desc{}
+
+	balloonDescs = map[string]*prometheus.Desc{}
+)
+
+func init() {
+	for _, name := range []string{
+		"read_bytes", "write_bytes", "reads", "writes", "flushes",
+		"rate_limiter_throttled_events", "execute_failures", "invalid_requests",
+	} {
+		blockDescs[name] = newVMDesc("firecracker_block_"+name+"_total", "Firecracker block device metric "+name+".", "drive_id")
+	}
+	for _, name := range []string{
+		"rx_bytes", "rx_packets", "rx_failures", "rx_rate_limiter_throttled",
+		"tx_bytes", "tx_packets", "tx_failures", "tx_rate_limiter_throttled",
+	} {
+		netDescs[name] = newVMDesc("firecracker_net_"+name+"_total", "Firecracker network interface metric "+name+".", "interface")
+	}
+	for _, name := range []string{
+		"rx_bytes", "rx_packets", "tx_bytes", "tx_packets", "conns_added", "conns_killed", "conns_removed",
+	} {
+		vsockDescs[name] = newVMDesc("firecracker_vsock_"+name+"_total", "Firecracker vsock device metric "+name+".")
+	}
+	for _, name := range []string{
+		"rx_packets", "rx_accepted", "rx_accepted_errors", "tx_packets", "tx_bytes", "tx_errors",
+	} {
+		mmdsDescs[name] = newVMDesc("firecracker_mmds_"+name+"_total", "Firecracker MMDS metric "+name+".")
+	}
+	for _, name := range []string{
+		"actual_mib", "target_mib", "available_memory_bytes", "free_memory_bytes", "total_memory_bytes",
+		"disk_caches_bytes", "swap_in_bytes", "swap_out_bytes", "major_faults", "minor_faults",
+	} {
+		balloonDescs[name] = newVMDesc("firecracker_balloon_"+name, "Firecracker balloon device statistic "+name+".")
+	}
+}
+
+// vmCollector gathers the metrics of the VMs of the plugin's shims on each scrape.
+type vmCollector struct {
+	local *local
+}
+
+// Describe sends no descriptor, as the collector is unchecked: the metrics of a VM depend on
+// its devices.
+func (c *vmCollector) Describe(chan<- *prometheus.Desc) {}
+
+func (c *vmCollector) Collect(ch chan<- prometheus.Metric) {
+	var procs []shimProcess
+	c.local.processesMu.Lock()
+	for _, proc := range c.local.processes {
+		// pooled VMs are only reported once they are claimed
+		if !strings.HasPrefix(proc.vmID, pooledVMIDPrefix) {
+			procs = append(procs, proc)
+		}
+	}
+	c.local.processesMu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, proc := range procs {
+		wg.Add(1)
+		go func(proc shimProcess) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(namespaces.WithNamespace(context.Background(), proc.namespace), metricsScrapeTimeout)
+			defer cancel()
+			c.collectVM(ctx, ch, proc)
+		}(proc)
+	}
+	wg.Wait()
+}
+
+func (c *vmCollector) collectVM(ctx context.Context, ch chan<- prometheus.Metric, proc shimProcess) {
+	logger := c.local.logger.WithField("vmID", proc.vmID)
+
+	client, err := c.local.shimFirecrackerClient(ctx, proc.vmID)
+	if err != nil {
+		logger.WithError(err).Warn("failed to create shim client to collect metrics")
+		return
+	}
+	defer client.Close()
+
+	counter := func(desc *prometheus.Desc, value uint64, labels ...string) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, float64(value),
+			append([]string{proc.namespace, proc.vmID}, labels...)...)
+	}
+	gauge := func(desc *prometheus.Desc, value int64) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, float64(value), proc.namespace, proc.vmID)
+	}
+
+	metrics, err := client.GetVMMetrics(ctx, &proto.GetVMMetricsRequest{VMID: proc.vmID})
+	if status.Code(err) == codes.FailedPrecondition {
+		logger.WithError(err).Debug("VM does not report metrics")
+	} else if err != nil {
+		logger.WithError(err).Warn("failed to get VM metrics")
+	} else {
+		counter(vcpuExitsDesc, metrics.Vcpu.GetExitIOIn(), "io_in")
+		counter(vcpuExitsDesc, metrics.Vcpu.GetExitIOOut(), "io_out")
+		counter(vcpuExitsDesc, metrics.Vcpu.GetExitMMIORead(), "mmio_read")
+		counter(vcpuExitsDesc, metrics.Vcpu.GetExitMMIOWrite(), "mmio_write")
+		counter(vcpuFailuresDesc, metrics.Vcpu.GetFailures())
+		counter(seccompDesc, metrics.SeccompFaults)
+		counter(drivesDesc, metrics.StubDrivesExhausted)
+
+		for driveID, block := range metrics.Drives {
+			counter(blockDescs["read_bytes"], block.ReadBytes, driveID)
+			counter(blockDescs["write_bytes"], block.WriteBytes, driveID)
+			counter(blockDescs["reads"], block.ReadCount, driveID)
+			counter(blockDescs["writes"], block.WriteCount, driveID)
+			counter(blockDescs["flushes"], block.FlushCount, driveID)
+			counter(blockDescs["rate_limiter_throttled_events"], block.RateLimiterThrottledEvents, driveID)
+			counter(blockDescs["execute_failures"], block.ExecuteFails, driveID)
+			counter(blockDescs["invalid_requests"], block.InvalidRequests, driveID)
+		}
+
+		for index, nic := range metrics.NetworkInterfaces {
+			iface := strconv.FormatUint(uint64(index), 10)
+			counter(netDescs["rx_bytes"], nic.RxBytes, iface)
+			counter(netDescs["rx_packets"], nic.RxPackets, iface)
+			counter(netDescs["rx_failures"], nic.RxFails, iface)
+			counter(netDescs["rx_rate_limiter_throttled"], nic.RxRateLimiterThrottled, iface)
+			counter(netDescs["tx_bytes"], nic.TxBytes, iface)
+			counter(netDescs["tx_packets"], nic.TxPackets, iface)
+			counter(netDescs["tx_failures"], nic.TxFails, iface)
+			counter(netDescs["tx_rate_limiter_throttled"], nic.TxRateLimiterThrottled, iface)
+		}
+
+		vsock := metrics.Vsock
+		counter(vsockDescs["rx_bytes"], vsock.GetRxBytes())
+		counter(vsockDescs["rx_packets"], vsock.GetRxPackets())
+		counter(vsockDescs["tx_bytes"], vsock.GetTxBytes())
+		counter(vsockDescs["tx_packets"], vsock.GetTxPackets())
+		counter(vsockDescs["conns_added"], vsock.GetConnsAdded())
+		counter(vsockDescs["conns_killed"], vsock.GetConnsKilled())
+		counter(vsockDescs["conns_removed"], vsock.GetConnsRemoved())
+
+		mmds := metrics.Mmds
+		counter(mmdsDescs["rx_packets"], mmds.GetRxCount())
+		counter(mmdsDescs["rx_accepted"], mmds.GetRxAccepted())
+		counter(mmdsDescs["rx_accepted_errors"], mmds.GetRxAcceptedErr())
+		counter(mmdsDescs["tx_packets"], mmds.GetTxCount())
+		counter(mmdsDescs["tx_bytes"], mmds.GetTxBytes())
+		counter(mmdsDescs["tx_errors"], mmds.GetTxErrors())
+
+		latencies := metrics.Latencies
+		for operation, value := range map[string]uint64{
+			"full_create_snapshot": latencies.GetFullCreateSnapshot(),
+			"diff_create_snapshot": latencies.GetDiffCreateSnapshot(),
+			"load_snapshot":        latencies.GetLoadSnapshot(),
+			"pause_vm":             latencies.GetPauseVM(),
+			"resume_vm":            latencies.GetResumeVM(),
+		} {
+			ch <- prometheus.MustNewConstMetric(latencyDesc, prometheus.GaugeValue, float64(value), proc.namespace, proc.vmID, operation)
+		}
+	}
+
+	// VMs without a balloon device or without statistics enabled fail to report balloon stats
+	balloon, err := client.GetBalloonStats(ctx, &proto.GetBalloonStatsRequest{VMID: proc.vmID})
+	if err != nil {
+		logger.WithError(err).Debug("VM does not report balloon statistics")
+		return
+	}
+	gauge(balloonDescs["actual_mib"], balloon.ActualMib)
+	gauge(balloonDescs["target_mib"], balloon.TargetMib)
+	gauge(balloonDescs["available_memory_bytes"], balloon.AvailableMemory)
+	gauge(balloonDescs["free_memory_bytes"], balloon.FreeMemory)
+	gauge(balloonDescs["total_memory_bytes"], balloon.TotalMemory)
+	gauge(balloonDescs["disk_caches_bytes"], balloon.DiskCaches)
+	gauge(balloonDescs["swap_in_bytes"], balloon.SwapIn)
+	gauge(balloonDescs["swap_out_bytes"], balloon.SwapOut)
+	gauge(balloonDescs["major_faults"], balloon.MajorFaults)
+	gauge(balloonDescs["minor_faults"], balloon.MinorFaults)
+}
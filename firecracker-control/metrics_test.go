@@ -0,0 +1,114 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package service
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/containerd/protobuf/types"
+	"github.com/containerd/containerd/runtime/v2/shim"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/firecracker-microvm/firecracker-containerd/internal"
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+// meteredShim is a shim reporting the metrics of its VM, whose StopVM returns stopErr.
+type meteredShim struct {
+	fakeShim
+	stopErr error
+}
+
+func (f *meteredShim) StopVM(context.Context, *proto.StopVMRequest) (*types.Empty, error) {
+	if f.stopErr != nil {
+		return nil, f.stopErr
+	}
+	return &types.Empty{}, nil
+}
+
+func (*meteredShim) GetVMMetrics(context.Context, *proto.GetVMMetricsRequest) (*proto.GetVMMetricsResponse, error) {
+	return &proto.GetVMMetricsResponse{SeccompFaults: 3, StubDrivesExhausted: 2}, nil
+}
+
+func (*meteredShim) GetBalloonStats(context.Context, *proto.GetBalloonStatsRequest) (*proto.GetBalloonStatsResponse, error) {
+	return nil, status.Error(codes.FailedPrecondition, "no balloon device")
+}
+
+func TestControlMetrics(t *testing.T) {
+	internal.RequiresRoot(t)
+	s := newTestLocal(t)
+	ctx := namespaces.WithNamespace(context.Background(), testNamespace)
+
+	// the shims of the test are all gone by the time StopVM waits for them to exit
+	exited := exec.Command("true")
+	require.NoError(t, exited.Run())
+	track := func(vmID string, svc *meteredShim) {
+		serveShim(t, s, vmID, svc)
+		address, err := shim.SocketAddress(ctx, s.containerdAddress, vmID)
+		require.NoError(t, err)
+		s.processes[address] = shimProcess{namespace: testNamespace, vmID: vmID, pid: int32(exited.Process.Pid)}
+	}
+	track("running", &meteredShim{})
+	track("stopped", &meteredShim{})
+	track("stuck", &meteredShim{stopErr: status.Error(codes.DeadlineExceeded, "VM did not stop")})
+
+	s.metrics.observeCreateVM(createVMPhaseTotal, time.Now().Add(-time.Second))
+
+	_, err := s.StopVM(ctx, &proto.StopVMRequest{VMID: "stopped"})
+	require.NoError(t, err)
+	_, err = s.StopVM(ctx, &proto.StopVMRequest{VMID: "stuck"})
+	require.Error(t, err)
+	// the calls failing before reaching a shim are counted as well
+	_, err = s.StopVM(ctx, &proto.StopVMRequest{VMID: "not/valid"})
+	require.Error(t, err)
+
+	err = testutil.GatherAndCompare(s.metrics.registry, strings.NewReader(`
+# HELP firecracker_containerd_stop_vm_total Number of StopVM requests by the gRPC code they returned.
+# TYPE firecracker_containerd_stop_vm_total counter
+firecracker_containerd_stop_vm_total{code="DeadlineExceeded"} 1
+firecracker_containerd_stop_vm_total{code="OK"} 1
+firecracker_containerd_stop_vm_total{code="Unknown"} 1
+`), "firecracker_containerd_stop_vm_total")
+	assert.NoError(t, err)
+	count, err := testutil.GatherAndCount(s.metrics.registry, "firecracker_containerd_create_vm_duration_seconds")
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	server := httptest.NewServer(promhttp.HandlerFor(s.metrics.registry, promhttp.HandlerOpts{}))
+	defer server.Close()
+	resp, err := http.Get(server.URL + metricsPath)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(body), `firecracker_seccomp_faults_total{namespace="test",vmid="running"} 3`)
+	assert.Contains(t, string(body), `firecracker_containerd_stub_drives_exhausted_total{namespace="test",vmid="running"} 2`)
+	assert.NotContains(t, string(body), `vmid="stopped"`, "stopped VMs are no longer reported")
+	assert.NotContains(t, string(body), "firecracker_balloon_", "VMs without a balloon device don't report balloon statistics")
+}
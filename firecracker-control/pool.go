@@ -0,0 +1,411 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/containerd/containerd/identifiers"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/containerd/runtime/v2/shim"
+	"github.com/gofrs/uuid"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/firecracker-microvm/firecracker-containerd/config"
+	fcShim "github.com/firecracker-microvm/firecracker-containerd/internal/shim"
+	"github.com/firecracker-microvm/firecracker-containerd/internal/vm"
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+// pooledVMIDPrefix starts the VMID of the VMs booted into a pool, until they are claimed.
+const pooledVMIDPrefix = "pooled-vm-"
+
+var poolRetryInterval = 10 * time.Second
+
+// vmPool keeps track of the booted, unclaimed VMs of a configured pool.
+type vmPool struct {
+	config config.VMPoolConfig
+	refill chan struct{}
+
+	mu    sync.Mutex
+	ready []string
+}
+
+func newVMPools(configs []config.VMPoolConfig) ([]*vmPool, error) {
+	var pools []*vmPool
+	for _, cfg := range configs {
+		if err := identifiers.Validate(cfg.Name); err != nil {
+			return nil, fmt.Errorf("invalid name of VM pool %q: %w", cfg.Name, err)
+		}
+		// the VMIDs of the pool only differ by their UUID, which has a fixed length
+		if err := identifiers.Validate(pooledVMID(cfg.Name, uuid.Nil)); err != nil {
+			return nil, fmt.Errorf("name of VM pool %q is too long for the VMIDs of its VMs: %w", cfg.Name, err)
+		}
+		if cfg.Size < 1 {
+			return nil, fmt.Errorf("size of VM pool %q must be positive", cfg.Name)
+		}
+		if cfg.Namespace == "" {
+			cfg.Namespace = namespaces.Default
+		}
+
+		pools = append(pools, &vmPool{
+			config: cfg,
+			refill: make(chan struct{}, 1),
+		})
+	}
+	return pools, nil
+}
+
+// request returns the CreateVM request the VMs of the pool are booted with.
+func (p *vmPool) request(vmID string, driveMounts []*proto.FirecrackerDriveMount) *proto.CreateVMRequest {
+	req := &proto.CreateVMRequest{
+		VMID:            vmID,
+		KernelImagePath: p.config.KernelImagePath,
+		KernelArgs:      p.config.KernelArgs,
+		ContainerCount:  p.config.ContainerCount,
+		DriveMounts:     driveMounts,
+	}
+	if p.config.RootDrive != "" {
+		req.RootDrive = &proto.FirecrackerRootDrive{HostPath: p.config.RootDrive}
+	}
+	if p.config.VcpuCount != 0 || p.config.MemSizeMib != 0 {
+		req.MachineCfg = &proto.FirecrackerMachineConfiguration{
+			VcpuCount:  p.config.VcpuCount,
+			MemSizeMib: p.config.MemSizeMib,
+		}
+	}
+	return req
+}
+
+// matches returns whether a VM of the pool can be handed to the given CreateVM request. Apart
+// from the container count, the request must be the one the pool's VMs are booted with, except
+// for what is applied when the VM is claimed.
+func (p *vmPool) matches(ns string, req *proto.CreateVMRequest, driveMounts []*proto.FirecrackerDriveMount) bool {
+	if ns != p.config.Namespace {
+		return false
+	}
+	if containerCount(req.ContainerCount) > containerCount(p.config.ContainerCount) {
+		return false
+	}
+
+	claim := protobuf.Clone(req).(*proto.CreateVMRequest)
+	claim.VMID = ""
+	claim.ExitAfterAllTasksDeleted = false
+	claim.TimeoutSeconds = 0
+	claim.ContainerCount = p.config.ContainerCount
+	if claim.MachineCfg != nil && protobuf.Equal(claim.MachineCfg, &proto.FirecrackerMachineConfiguration{}) {
+		claim.MachineCfg = nil
+	}
+	if claim.RootDrive != nil && protobuf.Equal(claim.RootDrive, &proto.FirecrackerRootDrive{}) {
+		claim.RootDrive = nil
+	}
+
+	return protobuf.Equal(claim, p.request("", driveMounts))
+}
+
+// containerCount returns the number of container stub drives the runtime creates for the given
+// requested count.
+func containerCount(count int32) int32 {
+	if count < 1 {
+		return 1
+	}
+	return count
+}
+
+func (p *vmPool) put(vmID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.ready = append(p.ready, vmID)
+}
+
+func (p *vmPool) take() (string, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.ready) == 0 {
+		return "", false
+	}
+	vmID := p.ready[0]
+	p.ready = p.ready[1:]
+	return vmID, true
+}
+
+// remove drops the given VM from the pool, returning whether it was part of it.
+func (p *vmPool) remove(vmID string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i, id := range p.ready {
+		if id == vmID {
+			p.ready = append(p.ready[:i], p.ready[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (p *vmPool) size() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.ready)
+}
+
+func (p *vmPool) requestRefill() {
+	select {
+	case p.refill <- struct{}{}:
+	default:
+	}
+}
+
+// fillPool boots VMs into the pool whenever it is below its configured size, until ctx is done.
+func (s *local) fillPool(ctx context.Context, pool *vmPool) {
+	logger := s.logger.WithField("pool", pool.config.Name)
+
+	for {
+		for pool.size() < pool.config.Size {
+			if err := s.addPooledVM(ctx, pool); err != nil {
+				logger.WithError(err).Error("failed to boot pooled VM")
+				break
+			}
+		}
+
+		retry := time.After(poolRetryInterval)
+		if pool.size() >= pool.config.Size {
+			retry = nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-pool.refill:
+		case <-retry:
+		}
+	}
+}
+
+// pooledVMID returns the VMID of a VM booted into the given pool.
+func pooledVMID(poolName string, id uuid.UUID) string {
+	return pooledVMIDPrefix + poolName + "-" + id.String()
+}
+
+func (s *local) addPooledVM(ctx context.Context, pool *vmPool) error {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return fmt.Errorf("failed to generate UUID for VMID: %w", err)
+	}
+	vmID := pooledVMID(pool.config.Name, id)
+
+	ns := pool.config.Namespace
+	_, err = s.createVM(namespaces.WithNamespace(ctx, ns), ns, pool.request(vmID, s.configDriveMounts()), true)
+	if err != nil {
+		return err
+	}
+
+	pool.put(vmID)
+	s.logger.WithFields(logrus.Fields{"pool": pool.config.Name, "vmID": vmID}).Debug("booted pooled VM")
+	return nil
+}
+
+// configDriveMounts returns the drive mounts of the runtime config, which the runtime shim also
+// requests for the VMs it creates on its own.
+func (s *local) configDriveMounts() []*proto.FirecrackerDriveMount {
+	driveMounts := make([]*proto.FirecrackerDriveMount, len(s.config.DriveMounts))
+	for i := range s.config.DriveMounts {
+		driveMounts[i] = &s.config.DriveMounts[i]
+	}
+	return driveMounts
+}
+
+// claimPooledVM hands a VM of a pool matching the request over to it, if there is one. The shim
+// of the VM takes the requested VMID, and the sockets and directory of the requested VMID are
+// made symlinks to the ones of the pooled VM. shimSocket is the listener holding the shim socket
+// address of the requested VMID, which is released if a VM is claimed.
+func (s *local) claimPooledVM(
+	requestCtx context.Context,
+	ns string,
+	req *proto.CreateVMRequest,
+	shimSocket *net.UnixListener,
+) (*proto.CreateVMResponse, bool, error) {
+	driveMounts := s.configDriveMounts()
+
+	for _, pool := range s.pools {
+		if !pool.matches(ns, req, driveMounts) {
+			continue
+		}
+
+		for {
+			pooledVMID, ok := pool.take()
+			if !ok {
+				break
+			}
+			pool.requestRefill()
+
+			logger := s.logger.WithFields(logrus.Fields{"pool": pool.config.Name, "vmID": req.VMID, "pooledVMID": pooledVMID})
+
+			client, err := s.shimFirecrackerClient(requestCtx, pooledVMID)
+			if err != nil {
+				logger.WithError(err).Warn("failed to create client of pooled VM, stopping it")
+				go s.stopPooledVM(ns, pooledVMID, logger)
+				continue
+			}
+			resp, err := client.CreateVM(requestCtx, req)
+			client.Close()
+			if err != nil {
+				logger.WithError(err).Warn("failed to claim pooled VM, stopping it")
+				go s.stopPooledVM(ns, pooledVMID, logger)
+				continue
+			}
+
+			if err := s.bindPooledVM(requestCtx, ns, pooledVMID, req.VMID, shimSocket); err != nil {
+				err = fmt.Errorf("failed to bind pooled VM %q to %q: %w", pooledVMID, req.VMID, err)
+				logger.WithError(err).Error()
+				go s.stopPooledVM(ns, pooledVMID, logger)
+				return nil, false, err
+			}
+
+			logger.Info("claimed pooled VM")
+			return resp, true, nil
+		}
+	}
+
+	return nil, false, nil
+}
+
+// bindPooledVM makes the claimed VM reachable through the sockets and shim directory of its new
+// VMID, and tracks its shim under that VMID.
+func (s *local) bindPooledVM(ctx context.Context, ns, pooledVMID, vmID string, shimSocket *net.UnixListener) error {
+	pooledShimAddress, err := shim.SocketAddress(ctx, s.containerdAddress, pooledVMID)
+	if err != nil {
+		return err
+	}
+	shimAddress, err := shim.SocketAddress(ctx, s.containerdAddress, vmID)
+	if err != nil {
+		return err
+	}
+	pooledFCAddress, err := fcShim.FCControlSocketAddress(ctx, s.containerdAddress, pooledVMID)
+	if err != nil {
+		return err
+	}
+	fcAddress, err := fcShim.FCControlSocketAddress(ctx, s.containerdAddress, vmID)
+	if err != nil {
+		return err
+	}
+	pooledShimDir, err := vm.ShimDir(s.config.ShimBaseDir, ns, pooledVMID)
+	if err != nil {
+		return err
+	}
+	shimDir, err := vm.ShimDir(s.config.ShimBaseDir, ns, vmID)
+	if err != nil {
+		return err
+	}
+
+	// Closing the listener removes its socket, leaving room for the symlink
+	if err := shimSocket.Close(); err != nil {
+		return fmt.Errorf("failed to close shim socket: %w", err)
+	}
+
+	// Let the symlinks be removed along with the files of the pooled VM from now on
+	s.processesMu.Lock()
+	s.aliases[pooledShimAddress] = vmID
+	s.processesMu.Unlock()
+
+	if err := os.Symlink(socketPath(pooledShimAddress), socketPath(shimAddress)); err != nil {
+		return err
+	}
+	if err := os.Symlink(socketPath(pooledFCAddress), socketPath(fcAddress)); err != nil {
+		return err
+	}
+	if err := os.Symlink(pooledShimDir.RootPath(), shimDir.RootPath()); err != nil {
+		return err
+	}
+
+	s.processesMu.Lock()
+	defer s.processesMu.Unlock()
+
+	proc := s.processes[pooledShimAddress]
+	delete(s.processes, pooledShimAddress)
+	s.processes[shimAddress] = shimProcess{
+		namespace: ns,
+		vmID:      vmID,
+		pid:       proc.pid,
+	}
+
+	return nil
+}
+
+// stopPooledVM stops a VM which was dropped from its pool. If its shim cannot be asked to stop
+// the VM, the process group of the shim, which Firecracker is part of, is killed instead so that
+// the VM doesn't keep running unnoticed.
+func (s *local) stopPooledVM(ns, vmID string, logger *logrus.Entry) {
+	ctx := namespaces.WithNamespace(context.Background(), ns)
+	address, err := shim.SocketAddress(ctx, s.containerdAddress, vmID)
+	if err != nil {
+		logger.WithError(err).Error("failed to obtain shim socket address")
+		return
+	}
+	s.processesMu.Lock()
+	proc, ok := s.processes[address]
+	s.processesMu.Unlock()
+
+	_, err = s.StopVM(ctx, &proto.StopVMRequest{VMID: vmID})
+	if err == nil {
+		return
+	}
+	if !ok {
+		logger.WithError(err).Error("failed to stop pooled VM")
+		return
+	}
+
+	logger.WithError(err).WithField("pid", proc.pid).Error("failed to stop pooled VM, killing its shim")
+	if err := unix.Kill(-int(proc.pid), unix.SIGKILL); err != nil && !errors.Is(err, unix.ESRCH) {
+		logger.WithError(err).Error("failed to kill the shim of pooled VM")
+	}
+}
+
+// releaseShim forgets about the given VM once its shim exited. VMs still in a pool are dropped
+// from it, while the symlinks of a claimed pooled VM are removed.
+func (s *local) releaseShim(ns, vmID string, logger *logrus.Entry) {
+	for _, pool := range s.pools {
+		if pool.remove(vmID) {
+			pool.requestRefill()
+		}
+	}
+
+	address, err := shim.SocketAddress(namespaces.WithNamespace(context.Background(), ns), s.containerdAddress, vmID)
+	if err != nil {
+		logger.WithError(err).Error("failed to obtain shim socket address")
+		return
+	}
+
+	s.processesMu.Lock()
+	alias, ok := s.aliases[address]
+	delete(s.aliases, address)
+	s.processesMu.Unlock()
+
+	if ok {
+		s.removeShimFiles(ns, alias, logger)
+	}
+}
+
+// socketPath returns the path of the file socket with the given address.
+func socketPath(address string) string {
+	return strings.TrimPrefix(address, "unix://")
+}
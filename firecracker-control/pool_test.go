@@ -0,0 +1,199 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package service
+
+import (
+	"context"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/containerd/runtime/v2/shim"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/firecracker-microvm/firecracker-containerd/config"
+	"github.com/firecracker-microvm/firecracker-containerd/internal"
+	fcShim "github.com/firecracker-microvm/firecracker-containerd/internal/shim"
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+func (f *fakeShim) CreateVM(_ context.Context, req *proto.CreateVMRequest) (*proto.CreateVMResponse, error) {
+	f.boundVMID = req.VMID
+	return &proto.CreateVMResponse{VMID: req.VMID}, nil
+}
+
+func TestVMPoolMatches(t *testing.T) {
+	pools, err := newVMPools([]config.VMPoolConfig{
+		{
+			Name:            "small",
+			Size:            1,
+			KernelImagePath: "/vmlinux",
+			RootDrive:       "/rootfs.img",
+			MemSizeMib:      256,
+			ContainerCount:  2,
+		},
+		{Name: "default", Size: 1},
+	})
+	require.NoError(t, err)
+	small, plain := pools[0], pools[1]
+
+	driveMounts := []*proto.FirecrackerDriveMount{{HostPath: "/data.img", VMPath: "/data"}}
+	profile := func() *proto.CreateVMRequest {
+		return &proto.CreateVMRequest{
+			VMID:            "vm",
+			KernelImagePath: "/vmlinux",
+			RootDrive:       &proto.FirecrackerRootDrive{HostPath: "/rootfs.img"},
+			MachineCfg:      &proto.FirecrackerMachineConfiguration{MemSizeMib: 256},
+			ContainerCount:  2,
+			DriveMounts:     driveMounts,
+		}
+	}
+
+	assert.True(t, small.matches(namespaces.Default, profile(), driveMounts))
+	assert.False(t, small.matches("other", profile(), driveMounts), "the namespace must match")
+
+	req := profile()
+	req.ExitAfterAllTasksDeleted = true
+	req.TimeoutSeconds = 10
+	req.ContainerCount = 1
+	assert.True(t, small.matches(namespaces.Default, req, driveMounts), "what is applied at claim time is ignored")
+
+	req = profile()
+	req.ContainerCount = 3
+	assert.False(t, small.matches(namespaces.Default, req, driveMounts), "the pool's VMs have 2 container drives")
+
+	req = profile()
+	req.MachineCfg.MemSizeMib = 512
+	assert.False(t, small.matches(namespaces.Default, req, driveMounts))
+
+	req = profile()
+	req.KernelArgs = "console=ttyS0"
+	assert.False(t, small.matches(namespaces.Default, req, driveMounts))
+
+	req = profile()
+	req.DriveMounts = nil
+	assert.False(t, small.matches(namespaces.Default, req, driveMounts), "the drive mounts of the config must be requested")
+
+	assert.True(t, plain.matches(namespaces.Default, &proto.CreateVMRequest{
+		VMID:       "vm",
+		MachineCfg: &proto.FirecrackerMachineConfiguration{},
+		RootDrive:  &proto.FirecrackerRootDrive{},
+	}, nil), "empty messages are the same as no messages")
+	assert.False(t, plain.matches(namespaces.Default, profile(), nil))
+}
+
+// newTestPool returns a pool holding the given VM, which can be claimed by empty requests.
+func newTestPool(t *testing.T, s *local, pooledVMID string) *vmPool {
+	pools, err := newVMPools([]config.VMPoolConfig{{Name: "test", Namespace: testNamespace, Size: 1}})
+	require.NoError(t, err)
+	s.pools = pools
+	pools[0].put(pooledVMID)
+	return pools[0]
+}
+
+// listenShimSocket holds the shim socket address of the given VM like createVM does.
+func listenShimSocket(t *testing.T, s *local, vmID string) (string, *net.UnixListener) {
+	ctx := namespaces.WithNamespace(context.Background(), testNamespace)
+	address, err := shim.SocketAddress(ctx, s.containerdAddress, vmID)
+	require.NoError(t, err)
+	listener, err := shim.NewSocket(address)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		listener.Close()
+		shim.RemoveSocket(address)
+	})
+	return address, listener
+}
+
+func TestClaimPooledVM(t *testing.T) {
+	internal.RequiresRoot(t)
+	s := newTestLocal(t)
+	ctx := namespaces.WithNamespace(context.Background(), testNamespace)
+
+	pooledVMID := pooledVMID("test", [16]byte{1})
+	pool := newTestPool(t, s, pooledVMID)
+	pooledDir := createShimDir(t, s, pooledVMID)
+	pooledShim := &fakeShim{}
+	serveShim(t, s, pooledVMID, pooledShim)
+	pooledAddress, _ := listenShimSocket(t, s, pooledVMID)
+	s.processes[pooledAddress] = shimProcess{namespace: testNamespace, vmID: pooledVMID, pid: 1234}
+
+	address, shimSocket := listenShimSocket(t, s, "claimed")
+	fcAddress, err := fcShim.FCControlSocketAddress(ctx, s.containerdAddress, "claimed")
+	require.NoError(t, err)
+	t.Cleanup(func() { shim.RemoveSocket(fcAddress) })
+
+	resp, claimed, err := s.claimPooledVM(ctx, testNamespace, &proto.CreateVMRequest{VMID: "claimed"}, shimSocket)
+	require.NoError(t, err)
+	require.True(t, claimed)
+	assert.Equal(t, "claimed", resp.VMID)
+	assert.Equal(t, "claimed", pooledShim.boundVMID, "the request is forwarded to the pooled shim")
+	assert.Zero(t, pool.size())
+
+	link, err := os.Readlink(socketPath(address))
+	require.NoError(t, err)
+	assert.Equal(t, socketPath(pooledAddress), link)
+	link, err = os.Readlink(filepath.Join(s.config.ShimBaseDir, testNamespace+"#claimed"))
+	require.NoError(t, err)
+	assert.Equal(t, pooledDir, link)
+
+	// the claimed VM is reachable through its new VMID
+	info, err := s.GetVMInfo(ctx, &proto.GetVMInfoRequest{VMID: "claimed"})
+	require.NoError(t, err)
+	assert.Equal(t, "claimed", info.VMID)
+
+	assert.Equal(t, map[string]shimProcess{
+		address: {namespace: testNamespace, vmID: "claimed", pid: 1234},
+	}, s.processes)
+	assert.Equal(t, map[string]string{pooledAddress: "claimed"}, s.aliases)
+}
+
+func TestClaimPooledVMStopsUnreachableVM(t *testing.T) {
+	internal.RequiresRoot(t)
+	s := newTestLocal(t)
+	ctx := namespaces.WithNamespace(context.Background(), testNamespace)
+
+	// the shim of the pooled VM runs, but its fccontrol socket is gone
+	pooledVMID := pooledVMID("test", [16]byte{2})
+	pool := newTestPool(t, s, pooledVMID)
+	pooledAddress, _ := listenShimSocket(t, s, pooledVMID)
+	pooledShim := exec.Command("sleep", "60")
+	pooledShim.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	require.NoError(t, pooledShim.Start())
+	s.addShim(pooledAddress, testNamespace, pooledVMID, pooledShim)
+
+	exited := make(chan error, 1)
+	go func() { exited <- pooledShim.Wait() }()
+
+	_, shimSocket := listenShimSocket(t, s, "claimed")
+	_, claimed, err := s.claimPooledVM(ctx, testNamespace, &proto.CreateVMRequest{VMID: "claimed"}, shimSocket)
+	require.NoError(t, err)
+	assert.False(t, claimed, "no other VM is left to claim")
+	assert.Zero(t, pool.size())
+
+	// dialing the shim times out twice, for the claim and for StopVM, before it is killed
+	select {
+	case err := <-exited:
+		assert.Error(t, err, "the shim must have been killed")
+	case <-time.After(30 * time.Second):
+		pooledShim.Process.Kill()
+		t.Fatal("the shim of the unreachable pooled VM was left running")
+	}
+}
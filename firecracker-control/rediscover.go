@@ -0,0 +1,220 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/containerd/containerd/identifiers"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/containerd/runtime/v2/shim"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+
+	"github.com/firecracker-microvm/firecracker-containerd/internal"
+	fcShim "github.com/firecracker-microvm/firecracker-containerd/internal/shim"
+	"github.com/firecracker-microvm/firecracker-containerd/internal/vm"
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+var (
+	shimDialTimeout           = 1 * time.Second
+	shimDialAttempts          = 3
+	shimDialRetryDelay        = 1 * time.Second
+	rediscoveredShimPollDelay = 1 * time.Second
+	shimRediscoveryTimeout    = 10 * time.Second
+)
+
+// rediscoverShims restores the tracking of shims spawned by a previous instance of the plugin,
+// such as before containerd was restarted. Every directory under ShimBaseDir belongs to a shim.
+// Shims still serving the fccontrol API are tracked again, while the sockets and directories
+// of the ones whose socket is gone are removed. Shims which can't be reached for another reason,
+// like a busy one, are left alone. Directories of claimed pooled VMs are symlinks, which are
+// skipped. The shims are reached in parallel, within shimRediscoveryTimeout overall, so that
+// the plugin starts in bounded time however many shims there are.
+func (s *local) rediscoverShims(ctx context.Context) error {
+	entries, err := os.ReadDir(s.config.ShimBaseDir)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to read shim base directory %s: %w", s.config.ShimBaseDir, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, shimRediscoveryTimeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for _, entry := range entries {
+		ns, vmID, ok := strings.Cut(entry.Name(), "#")
+		if !entry.IsDir() || !ok || identifiers.Validate(ns) != nil || identifiers.Validate(vmID) != nil {
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.rediscoverShim(ctx, ns, vmID)
+		}()
+	}
+	wg.Wait()
+
+	return nil
+}
+
+// rediscoverShim tracks the shim of the given VM again if it is still running, or removes its
+// files if it is gone.
+func (s *local) rediscoverShim(ctx context.Context, ns, vmID string) {
+	logger := s.logger.WithFields(logrus.Fields{"namespace": ns, "vmID": vmID})
+	nsCtx := namespaces.WithNamespace(ctx, ns)
+
+	pid, err := s.dialShimPID(nsCtx, vmID)
+	if isDeadSocket(err) {
+		logger.WithError(err).Warn("removing the files of a shim which is gone")
+		s.removeShimFiles(ns, vmID, logger)
+		return
+	} else if err != nil {
+		logger.WithError(err).Error("leaving the files of a shim which could not be reached")
+		return
+	}
+
+	// A claimed pooled VM is known by the VMID it was claimed as, which its shim reports
+	boundVMID := vmID
+	if info, err := s.GetVMInfo(nsCtx, &proto.GetVMInfoRequest{VMID: vmID}); err == nil && info.VMID != "" {
+		boundVMID = info.VMID
+	}
+
+	address, err := shim.SocketAddress(nsCtx, s.containerdAddress, boundVMID)
+	if err != nil {
+		logger.WithError(err).Error("failed to obtain shim socket address")
+		return
+	}
+
+	s.processesMu.Lock()
+	s.processes[address] = shimProcess{
+		namespace: ns,
+		vmID:      boundVMID,
+		pid:       pid,
+	}
+	if boundVMID != vmID {
+		pooledAddress, err := shim.SocketAddress(nsCtx, s.containerdAddress, vmID)
+		if err == nil {
+			s.aliases[pooledAddress] = boundVMID
+		}
+	}
+	s.processesMu.Unlock()
+
+	logger.WithFields(logrus.Fields{"pid": pid, "boundVMID": boundVMID}).Info("rediscovered shim")
+
+	// Pools are refilled from scratch, so VMs left unclaimed by the previous instance are stopped
+	if strings.HasPrefix(boundVMID, pooledVMIDPrefix) {
+		go s.stopPooledVM(ns, vmID, logger)
+	}
+
+	// The shim is not our child anymore, so poll for its exit to cleanup after it
+	// like newShim does for the shims it spawns.
+	go func() {
+		if err := internal.WaitForPidToExit(context.Background(), rediscoveredShimPollDelay, pid); err != nil {
+			logger.WithError(err).Error("failed to wait for shim to exit")
+			return
+		}
+		logger.Debug("shim has been terminated")
+		s.removeShimFiles(ns, vmID, logger)
+		s.releaseShim(ns, vmID, logger)
+	}()
+}
+
+// dialShimPID returns the PID of the shim of the given VM like shimPID, trying again when the shim
+// may be alive but too busy to accept the connection.
+func (s *local) dialShimPID(ctx context.Context, vmID string) (int32, error) {
+	pid, err := s.shimPID(ctx, vmID)
+	for attempt := 1; err != nil && !isDeadSocket(err) && attempt < shimDialAttempts; attempt++ {
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-time.After(shimDialRetryDelay):
+		}
+		pid, err = s.shimPID(ctx, vmID)
+	}
+	return pid, err
+}
+
+// isDeadSocket returns true if the error of a connection to a shim's socket proves that nothing
+// listens on it anymore.
+func isDeadSocket(err error) bool {
+	return errors.Is(err, unix.ECONNREFUSED) || errors.Is(err, unix.ENOENT)
+}
+
+// shimPID returns the PID of the shim serving the fccontrol API for the given VM, as reported
+// by the credentials of the peer of a connection to its socket.
+func (s *local) shimPID(ctx context.Context, vmID string) (int32, error) {
+	address, err := fcShim.FCControlSocketAddress(ctx, s.containerdAddress, vmID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to obtain shim's fccontrol socket address: %w", err)
+	}
+
+	conn, err := shim.AnonDialer(address, shimDialTimeout)
+	if err != nil {
+		return 0, fmt.Errorf("failed to connect to %q: %w", address, err)
+	}
+	defer conn.Close()
+
+	unixConn, ok := conn.(*net.UnixConn)
+	if !ok {
+		return 0, fmt.Errorf("unexpected connection type %T", conn)
+	}
+
+	rawConn, err := unixConn.SyscallConn()
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		cred    *unix.Ucred
+		credErr error
+	)
+	err = rawConn.Control(func(fd uintptr) {
+		cred, credErr = unix.GetsockoptUcred(int(fd), unix.SOL_SOCKET, unix.SO_PEERCRED)
+	})
+	if err != nil {
+		return 0, err
+	}
+	if credErr != nil {
+		return 0, fmt.Errorf("failed to get peer credentials: %w", credErr)
+	}
+
+	return cred.Pid, nil
+}
+
+func (s *local) removeShimFiles(ns, vmID string, logger *logrus.Entry) {
+	if err := s.removeSockets(ns, vmID); err != nil {
+		logger.WithError(err).Errorf("failed to remove sockets")
+	}
+
+	shimDir, err := vm.ShimDir(s.config.ShimBaseDir, ns, vmID)
+	if err != nil {
+		logger.WithError(err).Error("failed to build shim path")
+		return
+	}
+
+	if err := os.RemoveAll(shimDir.RootPath()); err != nil {
+		logger.WithError(err).Errorf("failed to remove %q", shimDir.RootPath())
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/containerd/runtime/v2/shim"
+	"github.com/containerd/ttrpc"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/firecracker-microvm/firecracker-containerd/config"
+	"github.com/firecracker-microvm/firecracker-containerd/internal"
+	fcShim "github.com/firecracker-microvm/firecracker-containerd/internal/shim"
+	"github.com/firecracker-microvm/firecracker-containerd/internal/vm"
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+	fccontrol "github.com/firecracker-microvm/firecracker-containerd/proto/service/fccontrol/ttrpc"
+)
+
+const testNamespace = "test"
+
+// fakeShim serves the fccontrol API of a shim, failing the calls it doesn't implement.
+type fakeShim struct {
+	fccontrol.FirecrackerService
+	boundVMID string
+}
+
+func (f *fakeShim) GetVMInfo(_ context.Context, req *proto.GetVMInfoRequest) (*proto.GetVMInfoResponse, error) {
+	vmID := req.VMID
+	if f.boundVMID != "" {
+		vmID = f.boundVMID
+	}
+	return &proto.GetVMInfoResponse{VMID: vmID}, nil
+}
+
+func newTestLocal(t *testing.T) *local {
+	s := &local{
+		containerdAddress: filepath.Join(t.TempDir(), "containerd.sock"),
+		logger:            logrus.NewEntry(logrus.New()),
+		config:            &config.Config{ShimBaseDir: t.TempDir()},
+		processes:         make(map[string]shimProcess),
+		aliases:           make(map[string]string),
+	}
+	s.metrics = newControlMetrics(s)
+	return s
+}
+
+// serveShim serves svc on the fccontrol socket of the shim of the given VM.
+func serveShim(t *testing.T, s *local, vmID string, svc fccontrol.FirecrackerService) {
+	ctx := namespaces.WithNamespace(context.Background(), testNamespace)
+	address, err := fcShim.FCControlSocketAddress(ctx, s.containerdAddress, vmID)
+	require.NoError(t, err)
+
+	listener, err := shim.NewSocket(address)
+	require.NoError(t, err)
+	server, err := ttrpc.NewServer()
+	require.NoError(t, err)
+	fccontrol.RegisterFirecrackerService(server, svc)
+	go server.Serve(ctx, listener)
+
+	t.Cleanup(func() {
+		server.Close()
+		shim.RemoveSocket(address)
+	})
+}
+
+func createShimDir(t *testing.T, s *local, vmID string) string {
+	dir, err := vm.ShimDir(s.config.ShimBaseDir, testNamespace, vmID)
+	require.NoError(t, err)
+	require.NoError(t, dir.Mkdir())
+	return dir.RootPath()
+}
+
+func TestRediscoverShims(t *testing.T) {
+	internal.RequiresRoot(t)
+	s := newTestLocal(t)
+	ctx := namespaces.WithNamespace(context.Background(), testNamespace)
+
+	liveDir := createShimDir(t, s, "live")
+	serveShim(t, s, "live", &fakeShim{})
+
+	claimedDir := createShimDir(t, s, "claimed-pooled")
+	serveShim(t, s, "claimed-pooled", &fakeShim{boundVMID: "claimed"})
+
+	// nothing listens on the socket of a shim which was killed
+	deadDir := createShimDir(t, s, "dead")
+	deadAddress, err := fcShim.FCControlSocketAddress(ctx, s.containerdAddress, "dead")
+	require.NoError(t, err)
+	listener, err := shim.NewSocket(deadAddress)
+	require.NoError(t, err)
+	listener.SetUnlinkOnClose(false)
+	require.NoError(t, listener.Close())
+	t.Cleanup(func() { shim.RemoveSocket(deadAddress) })
+
+	goneDir := createShimDir(t, s, "gone")
+
+	require.NoError(t, s.rediscoverShims(ctx))
+
+	assert.DirExists(t, liveDir)
+	assert.DirExists(t, claimedDir)
+	assert.NoDirExists(t, deadDir, "the files of a shim whose socket refuses connections are removed")
+	assert.NoDirExists(t, goneDir, "the files of a shim without a socket are removed")
+	_, err = os.Stat(deadAddress[len("unix://"):])
+	assert.True(t, os.IsNotExist(err), "the socket of a dead shim is removed")
+
+	liveAddress, err := shim.SocketAddress(ctx, s.containerdAddress, "live")
+	require.NoError(t, err)
+	claimedAddress, err := shim.SocketAddress(ctx, s.containerdAddress, "claimed")
+	require.NoError(t, err)
+	pooledAddress, err := shim.SocketAddress(ctx, s.containerdAddress, "claimed-pooled")
+	require.NoError(t, err)
+
+	s.processesMu.Lock()
+	defer s.processesMu.Unlock()
+	assert.Equal(t, map[string]shimProcess{
+		liveAddress:    {namespace: testNamespace, vmID: "live", pid: int32(os.Getpid())},
+		claimedAddress: {namespace: testNamespace, vmID: "claimed", pid: int32(os.Getpid())},
+	}, s.processes)
+	assert.Equal(t, map[string]string{pooledAddress: "claimed"}, s.aliases)
+}
@@ -87,11 +87,46 @@ func (s *service) StopVM(ctx context.Context, req *proto.StopVMRequest) (*types.
 	return s.local.StopVM(ctx, req)
 }
 
+func (s *service) CreateSnapshot(ctx context.Context, req *proto.CreateSnapshotRequest) (*types.Empty, error) {
+	log.G(ctx).Debugf("create snapshot: %+v", req)
+	return s.local.CreateSnapshot(ctx, req)
+}
+
+func (s *service) AttachDriveMount(ctx context.Context, req *proto.AttachDriveMountRequest) (*types.Empty, error) {
+	log.G(ctx).Debugf("attach drive mount: %+v", req)
+	return s.local.AttachDriveMount(ctx, req)
+}
+
+func (s *service) DetachDriveMount(ctx context.Context, req *proto.DetachDriveMountRequest) (*types.Empty, error) {
+	log.G(ctx).Debugf("detach drive mount: %+v", req)
+	return s.local.DetachDriveMount(ctx, req)
+}
+
+func (s *service) UpdateDrive(ctx context.Context, req *proto.UpdateDriveRequest) (*types.Empty, error) {
+	log.G(ctx).Debugf("update drive: %+v", req)
+	return s.local.UpdateDrive(ctx, req)
+}
+
+func (s *service) ListDrives(ctx context.Context, req *proto.ListDrivesRequest) (*proto.ListDrivesResponse, error) {
+	log.G(ctx).Debugf("list drives: %+v", req)
+	return s.local.ListDrives(ctx, req)
+}
+
 func (s *service) GetVMInfo(ctx context.Context, req *proto.GetVMInfoRequest) (*proto.GetVMInfoResponse, error) {
 	log.G(ctx).Debugf("get VM info: %+v", req)
 	return s.local.GetVMInfo(ctx, req)
 }
 
+func (s *service) ListVMs(ctx context.Context, req *proto.ListVMsRequest) (*proto.ListVMsResponse, error) {
+	log.G(ctx).Debug("listing VMs")
+	return s.local.ListVMs(ctx, req)
+}
+
+func (s *service) GetVMMetrics(ctx context.Context, req *proto.GetVMMetricsRequest) (*proto.GetVMMetricsResponse, error) {
+	log.G(ctx).Debugf("get VM metrics: %+v", req)
+	return s.local.GetVMMetrics(ctx, req)
+}
+
 func (s *service) SetVMMetadata(ctx context.Context, req *proto.SetVMMetadataRequest) (*types.Empty, error) {
 	log.G(ctx).Debug("Setting vm metadata")
 	return s.local.SetVMMetadata(ctx, req)
@@ -117,6 +152,11 @@ func (s *service) UpdateBalloon(ctx context.Context, req *proto.UpdateBalloonReq
 	return s.local.UpdateBalloon(ctx, req)
 }
 
+func (s *service) UpdateNetworkInterface(ctx context.Context, req *proto.UpdateNetworkInterfaceRequest) (*types.Empty, error) {
+	log.G(ctx).Debugf("Updating rate limiters of network interface %d", req.InterfaceIndex)
+	return s.local.UpdateNetworkInterface(ctx, req)
+}
+
 func (s *service) GetBalloonStats(ctx context.Context, req *proto.GetBalloonStatsRequest) (*proto.GetBalloonStatsResponse, error) {
 	log.G(ctx).Debug("Getting balloon statistics")
 	return s.local.GetBalloonStats(ctx, req)
@@ -126,3 +166,28 @@ func (s *service) UpdateBalloonStats(ctx context.Context, req *proto.UpdateBallo
 	log.G(ctx).Debug("Updating balloon device statistics polling interval")
 	return s.local.UpdateBalloonStats(ctx, req)
 }
+
+func (s *service) GetConsoleLog(ctx context.Context, req *proto.GetConsoleLogRequest) (*proto.GetConsoleLogResponse, error) {
+	log.G(ctx).Debugf("get console log request: %+v", req)
+	return s.local.GetConsoleLog(ctx, req)
+}
+
+func (s *service) AttachConsole(ctx context.Context, req *proto.AttachConsoleRequest) (*types.Empty, error) {
+	log.G(ctx).Debugf("attach console request: %+v", req)
+	return s.local.AttachConsole(ctx, req)
+}
+
+func (s *service) ExecInVM(ctx context.Context, req *proto.ExecInVMRequest) (*proto.ExecInVMResponse, error) {
+	log.G(ctx).Debugf("exec in VM request: %+v", req)
+	return s.local.ExecInVM(ctx, req)
+}
+
+func (s *service) CopyToVM(ctx context.Context, req *proto.CopyToVMRequest) (*types.Empty, error) {
+	log.G(ctx).Debugf("copy to VM request: %+v", req)
+	return s.local.CopyToVM(ctx, req)
+}
+
+func (s *service) CopyFromVM(ctx context.Context, req *proto.CopyFromVMRequest) (*types.Empty, error) {
+	log.G(ctx).Debugf("copy from VM request: %+v", req)
+	return s.local.CopyFromVM(ctx, req)
+}
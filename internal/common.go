@@ -36,6 +36,10 @@ const (
 	FirecrackerVSockName = "firecracker.vsock"
 	// FirecrackerLogFifoName is the name of the Firecracker VMM log FIFO
 	FirecrackerLogFifoName = "fc-logs.fifo"
+	// FirecrackerLogFileName is the name of the file the Firecracker VMM logs are written to, if enabled
+	FirecrackerLogFileName = "fc.log"
+	// ConsoleLogFileName is the name of the file the serial console output of a VM is written to
+	ConsoleLogFileName = "console.log"
 	// FirecrackerMetricsFifoName is the name of the Firecracker VMM metrics FIFO
 	FirecrackerMetricsFifoName = "fc-metrics.fifo"
 
@@ -59,11 +63,19 @@ const (
 	// VMIDEnvVarKey is the environment variable key used to provide a VMID to a shim process
 	VMIDEnvVarKey = "FIRECRACKER_VM_ID"
 
+	// PooledVMEnvVarKey is the environment variable key set for shims whose VM is booted into a warm pool.
+	// The VM of such a shim can be claimed under another VMID by a later CreateVM request.
+	PooledVMEnvVarKey = "FIRECRACKER_POOLED_VM"
+
 	// FCSocketFDEnvKey is the environment variable key used to provide the FD of the fccontrol listening socket to a shim
 	FCSocketFDEnvKey = "FCCONTROL_SOCKET_FD"
 
 	// ShimBinaryName is the name of the runtime shim binary
 	ShimBinaryName = "containerd-shim-aws-firecracker"
+
+	// ClockSyncEventName is the topic the agent publishes to once it has set the clock of the VM
+	// to the time of the host
+	ClockSyncEventName = "/firecracker-vm/clock-sync"
 )
 
 // MagicStubBytes used to determine whether or not a drive is a stub drive
@@ -19,53 +19,113 @@ import (
 	"os"
 	"regexp"
 	"runtime"
+	"strconv"
 	"sync"
 )
 
+const (
+	// CPUVendorIntel is the vendor ID of Intel CPUs.
+	CPUVendorIntel = "GenuineIntel"
+	// CPUVendorAMD is the vendor ID of AMD CPUs.
+	CPUVendorAMD = "AuthenticAMD"
+)
+
+// HostCPU describes the CPU of the host, as far as CPU templates are concerned.
+type HostCPU struct {
+	// Arch is the architecture of the host, as GOARCH names it.
+	Arch string
+	// Vendor is the vendor ID of x86 CPUs, or the implementer code of aarch64 ones, like 0x41.
+	Vendor string
+	// CPUIDLevel is the highest basic CPUID leaf of x86 CPUs.
+	CPUIDLevel uint64
+}
+
 var (
-	isIntel     bool
-	isIntelOnce sync.Once
+	hostCPU     HostCPU
+	hostCPUErr  error
+	hostCPUOnce sync.Once
 )
 
-// SupportCPUTemplate returns true if Firecracker supports CPU templates on
-// the current architecture.
+// DetectHostCPU returns the CPU of the host, read from /proc/cpuinfo.
+func DetectHostCPU() (HostCPU, error) {
+	hostCPUOnce.Do(func() {
+		hostCPU, hostCPUErr = readCPUInfo("/proc/cpuinfo")
+		hostCPU.Arch = runtime.GOARCH
+	})
+	return hostCPU, hostCPUErr
+}
+
+// SupportCPUTemplate returns true if Firecracker supports its static CPU templates, like T2 or C3,
+// on the current host. They only exist for Intel CPUs.
 func SupportCPUTemplate() (bool, error) {
 	if runtime.GOARCH != "amd64" {
 		return false, nil
 	}
 
-	var err error
-	isIntelOnce.Do(func() {
-		isIntel, err = checkIsIntel()
-	})
-	return isIntel, err
+	cpu, err := DetectHostCPU()
+	if err != nil {
+		return false, err
+	}
+	return cpu.Vendor == CPUVendorIntel, nil
 }
 
-var vendorID = regexp.MustCompile(`^vendor_id\s*:\s*(.+)$`)
-
-func checkIsIntel() (bool, error) {
-	f, err := os.Open("/proc/cpuinfo")
+// SupportCustomCPUTemplate returns true if Firecracker supports custom CPU templates on the
+// current host, which are available on Intel and AMD CPUs and on aarch64.
+func SupportCustomCPUTemplate() (bool, error) {
+	cpu, err := DetectHostCPU()
 	if err != nil {
 		return false, err
 	}
-	defer f.Close()
+	return cpu.supportCustomCPUTemplate(), nil
+}
+
+func (c HostCPU) supportCustomCPUTemplate() bool {
+	switch c.Arch {
+	case "amd64":
+		return c.Vendor == CPUVendorIntel || c.Vendor == CPUVendorAMD
+	case "arm64":
+		return true
+	default:
+		return false
+	}
+}
+
+var (
+	vendorID       = regexp.MustCompile(`^vendor_id\s*:\s*(.+)$`)
+	cpuImplementer = regexp.MustCompile(`^CPU implementer\s*:\s*(.+)$`)
+	cpuidLevel     = regexp.MustCompile(`^cpuid level\s*:\s*(\d+)$`)
+)
 
-	id, err := findFirstVendorID(f)
+func readCPUInfo(path string) (HostCPU, error) {
+	f, err := os.Open(path)
 	if err != nil {
-		return false, err
+		return HostCPU{}, err
 	}
+	defer f.Close()
 
-	return id == "GenuineIntel", nil
+	return parseCPUInfo(f)
 }
 
-func findFirstVendorID(r io.Reader) (string, error) {
+// parseCPUInfo returns the vendor and the CPUID level of the first CPU of the given
+// /proc/cpuinfo. All the CPUs of a host are expected to be the same.
+func parseCPUInfo(r io.Reader) (HostCPU, error) {
+	var cpu HostCPU
 	s := bufio.NewScanner(r)
 	for s.Scan() {
 		line := s.Text()
-		matches := vendorID.FindStringSubmatch(line)
-		if len(matches) == 2 {
-			return matches[1], nil
+		if line == "" && cpu.Vendor != "" {
+			// end of the first CPU
+			break
+		}
+
+		if matches := vendorID.FindStringSubmatch(line); len(matches) == 2 {
+			cpu.Vendor = matches[1]
+		} else if matches := cpuImplementer.FindStringSubmatch(line); len(matches) == 2 {
+			cpu.Vendor = matches[1]
+		} else if matches := cpuidLevel.FindStringSubmatch(line); len(matches) == 2 {
+			// the regexp only matches digits
+			cpu.CPUIDLevel, _ = strconv.ParseUint(matches[1], 10, 64)
 		}
 	}
-	return "", nil
+	return cpu, s.Err()
 }
@@ -21,21 +21,34 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func TestFindFirstVendorID(t *testing.T) {
+func TestParseCPUInfo(t *testing.T) {
 	cases := []struct {
-		input    string
-		vendorID string
+		input string
+		cpu   HostCPU
 	}{
-		{"vendor_id : GenuineIntel", "GenuineIntel"},
-		{"vendor_id : AuthenticAMD", "AuthenticAMD"},
+		{"vendor_id : GenuineIntel", HostCPU{Vendor: CPUVendorIntel}},
+		{"vendor_id : AuthenticAMD", HostCPU{Vendor: CPUVendorAMD}},
+		{
+			"processor\t: 0\nvendor_id\t: AuthenticAMD\ncpuid level\t: 16\n\nprocessor\t: 1\nvendor_id\t: GenuineIntel\ncpuid level\t: 22\n",
+			HostCPU{Vendor: CPUVendorAMD, CPUIDLevel: 16},
+		},
+		{"processor\t: 0\nBogoMIPS\t: 243.75\nCPU implementer\t: 0x41\n", HostCPU{Vendor: "0x41"}},
 
-		// aarch64 doesn't have vendor IDs.
-		{"", ""},
+		// aarch64 may not have implementers.
+		{"", HostCPU{}},
 	}
 	for _, c := range cases {
 		r := strings.NewReader(c.input)
-		id, err := findFirstVendorID(r)
+		cpu, err := parseCPUInfo(r)
 		require.NoError(t, err)
-		assert.Equal(t, c.vendorID, id)
+		assert.Equal(t, c.cpu, cpu)
 	}
 }
+
+func TestHostCPUSupportCustomCPUTemplate(t *testing.T) {
+	assert.True(t, HostCPU{Arch: "amd64", Vendor: CPUVendorIntel}.supportCustomCPUTemplate())
+	assert.True(t, HostCPU{Arch: "amd64", Vendor: CPUVendorAMD}.supportCustomCPUTemplate())
+	assert.False(t, HostCPU{Arch: "amd64", Vendor: "HygonGenuine"}.supportCustomCPUTemplate())
+	assert.True(t, HostCPU{Arch: "arm64", Vendor: "0x41"}.supportCustomCPUTemplate())
+	assert.False(t, HostCPU{Arch: "riscv64"}.supportCustomCPUTemplate())
+}
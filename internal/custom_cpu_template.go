@@ -0,0 +1,189 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// CustomCPUTemplate is a custom CPU template of Firecracker, which modifies the CPUID and the
+// MSRs of x86 guests, or the registers and the vCPU features of aarch64 ones, so that VMs see the
+// same CPU on different hosts.
+type CustomCPUTemplate struct {
+	KVMCapabilities []string `json:"kvm_capabilities,omitempty"`
+
+	// x86_64
+	CPUIDModifiers []CPUIDModifier    `json:"cpuid_modifiers,omitempty"`
+	MSRModifiers   []RegisterModifier `json:"msr_modifiers,omitempty"`
+
+	// aarch64
+	RegModifiers []RegisterModifier `json:"reg_modifiers,omitempty"`
+	VCPUFeatures []VCPUFeature      `json:"vcpu_features,omitempty"`
+}
+
+// CPUIDModifier modifies the registers of a CPUID leaf.
+type CPUIDModifier struct {
+	Leaf      string                  `json:"leaf"`
+	Subleaf   string                  `json:"subleaf"`
+	Flags     uint32                  `json:"flags"`
+	Modifiers []CPUIDRegisterModifier `json:"modifiers"`
+}
+
+// CPUIDRegisterModifier modifies the bits of a register of a CPUID leaf.
+type CPUIDRegisterModifier struct {
+	Register string `json:"register"`
+	Bitmap   string `json:"bitmap"`
+}
+
+// RegisterModifier modifies the bits of the register at the given address, a MSR on x86 or a
+// system register on aarch64.
+type RegisterModifier struct {
+	Addr   string `json:"addr"`
+	Bitmap string `json:"bitmap"`
+}
+
+// VCPUFeature modifies the bits of a vCPU feature of aarch64.
+type VCPUFeature struct {
+	Index  uint32 `json:"index"`
+	Bitmap string `json:"bitmap"`
+}
+
+// extendedCPUIDLeaves is the first extended CPUID leaf, which CPUID levels don't cover.
+const extendedCPUIDLeaves = 0x80000000
+
+// LoadCustomCPUTemplate returns the custom CPU template of the given JSON, or of the JSON file
+// at the given path.
+func LoadCustomCPUTemplate(template string) (*CustomCPUTemplate, error) {
+	data := []byte(template)
+	if !strings.HasPrefix(strings.TrimSpace(template), "{") {
+		var err error
+		data, err = os.ReadFile(template)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read custom CPU template: %w", err)
+		}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var t CustomCPUTemplate
+	if err := decoder.Decode(&t); err != nil {
+		return nil, fmt.Errorf("failed to parse custom CPU template: %w", err)
+	}
+	return &t, nil
+}
+
+// Validate returns an error if the template is not valid or not applicable to the given CPU.
+func (t *CustomCPUTemplate) Validate(cpu HostCPU) error {
+	if !cpu.supportCustomCPUTemplate() {
+		return fmt.Errorf("custom CPU templates are not supported on %s CPUs of vendor %q", cpu.Arch, cpu.Vendor)
+	}
+
+	x86 := len(t.CPUIDModifiers) > 0 || len(t.MSRModifiers) > 0
+	aarch64 := len(t.RegModifiers) > 0 || len(t.VCPUFeatures) > 0
+	switch {
+	case x86 && aarch64:
+		return errors.New("custom CPU template modifies both x86_64 and aarch64 CPUs")
+	case x86 && cpu.Arch != "amd64":
+		return fmt.Errorf("custom CPU template of x86_64 cannot be used on %s", cpu.Arch)
+	case aarch64 && cpu.Arch != "arm64":
+		return fmt.Errorf("custom CPU template of aarch64 cannot be used on %s", cpu.Arch)
+	}
+
+	for _, c := range t.KVMCapabilities {
+		if _, err := strconv.ParseUint(strings.TrimPrefix(c, "!"), 10, 32); err != nil {
+			return fmt.Errorf("invalid KVM capability %q", c)
+		}
+	}
+
+	for _, m := range t.CPUIDModifiers {
+		leaf, err := parseAddr("CPUID leaf", m.Leaf, 32)
+		if err != nil {
+			return err
+		}
+		if _, err := parseAddr("CPUID subleaf", m.Subleaf, 32); err != nil {
+			return err
+		}
+		if leaf < extendedCPUIDLeaves && leaf > cpu.CPUIDLevel {
+			return fmt.Errorf("CPUID leaf %s is beyond the CPUID level %#x of the host", m.Leaf, cpu.CPUIDLevel)
+		}
+		for _, r := range m.Modifiers {
+			switch r.Register {
+			case "eax", "ebx", "ecx", "edx":
+			default:
+				return fmt.Errorf("invalid register %q of CPUID leaf %s", r.Register, m.Leaf)
+			}
+			if err := checkBitmap(r.Bitmap, 32); err != nil {
+				return fmt.Errorf("invalid bitmap of register %s of CPUID leaf %s: %w", r.Register, m.Leaf, err)
+			}
+		}
+	}
+
+	for _, m := range t.MSRModifiers {
+		if _, err := parseAddr("MSR address", m.Addr, 32); err != nil {
+			return err
+		}
+		if err := checkBitmap(m.Bitmap, 64); err != nil {
+			return fmt.Errorf("invalid bitmap of MSR %s: %w", m.Addr, err)
+		}
+	}
+
+	for _, m := range t.RegModifiers {
+		if _, err := parseAddr("register address", m.Addr, 64); err != nil {
+			return err
+		}
+		if err := checkBitmap(m.Bitmap, 128); err != nil {
+			return fmt.Errorf("invalid bitmap of register %s: %w", m.Addr, err)
+		}
+	}
+
+	for _, f := range t.VCPUFeatures {
+		if err := checkBitmap(f.Bitmap, 32); err != nil {
+			return fmt.Errorf("invalid bitmap of vCPU feature %d: %w", f.Index, err)
+		}
+	}
+
+	return nil
+}
+
+// parseAddr parses a leaf or an address of a template, written in hexadecimal like Firecracker's
+// own templates do, or in decimal.
+func parseAddr(name, addr string, bitSize int) (uint64, error) {
+	v, err := strconv.ParseUint(addr, 0, bitSize)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q", name, addr)
+	}
+	return v, nil
+}
+
+// checkBitmap returns an error if the bitmap is not "0b" followed by at most size bits of 0, 1, or
+// x to keep the bit of the host.
+func checkBitmap(bitmap string, size int) error {
+	bits := strings.TrimPrefix(bitmap, "0b")
+	if bits == bitmap {
+		return fmt.Errorf("%q does not start with 0b", bitmap)
+	}
+	if bits == "" || len(bits) > size {
+		return fmt.Errorf("%q does not have between 1 and %d bits", bitmap, size)
+	}
+	if i := strings.IndexFunc(bits, func(r rune) bool { return r != '0' && r != '1' && r != 'x' }); i >= 0 {
+		return fmt.Errorf("%q has an invalid bit %q", bitmap, bits[i])
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const x86CustomCPUTemplate = `{
+  "cpuid_modifiers": [{
+    "leaf": "0x1",
+    "subleaf": "0x0",
+    "flags": 0,
+    "modifiers": [{"register": "ecx", "bitmap": "0bxxxxxxxxxxxxxxxxxxxxxxxxxxx0xxxx"}]
+  }],
+  "msr_modifiers": [{"addr": "0x10a", "bitmap": "0b0"}]
+}`
+
+func TestLoadCustomCPUTemplate(t *testing.T) {
+	inline, err := LoadCustomCPUTemplate(x86CustomCPUTemplate)
+	require.NoError(t, err)
+	require.Len(t, inline.CPUIDModifiers, 1)
+	assert.Equal(t, "ecx", inline.CPUIDModifiers[0].Modifiers[0].Register)
+	assert.Equal(t, []RegisterModifier{{Addr: "0x10a", Bitmap: "0b0"}}, inline.MSRModifiers)
+
+	path := filepath.Join(t.TempDir(), "template.json")
+	require.NoError(t, os.WriteFile(path, []byte(x86CustomCPUTemplate), 0600))
+	file, err := LoadCustomCPUTemplate(path)
+	require.NoError(t, err)
+	assert.Equal(t, inline, file)
+
+	_, err = LoadCustomCPUTemplate(`{"cpuid_modifier": []}`)
+	assert.Error(t, err, "unknown fields are rejected")
+
+	_, err = LoadCustomCPUTemplate(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
+
+func TestCustomCPUTemplateValidate(t *testing.T) {
+	intel := HostCPU{Arch: "amd64", Vendor: CPUVendorIntel, CPUIDLevel: 0xd}
+	amd := HostCPU{Arch: "amd64", Vendor: CPUVendorAMD, CPUIDLevel: 0x10}
+	arm := HostCPU{Arch: "arm64", Vendor: "0x41"}
+
+	cases := []struct {
+		name     string
+		template CustomCPUTemplate
+		cpu      HostCPU
+		valid    bool
+	}{
+		{
+			name: "x86 on Intel",
+			template: CustomCPUTemplate{
+				KVMCapabilities: []string{"171", "!172"},
+				CPUIDModifiers: []CPUIDModifier{{Leaf: "0x7", Subleaf: "0x0", Modifiers: []CPUIDRegisterModifier{
+					{Register: "ebx", Bitmap: "0bxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx00"},
+				}}},
+				MSRModifiers: []RegisterModifier{{Addr: "0x10a", Bitmap: "0b0"}},
+			},
+			cpu:   intel,
+			valid: true,
+		},
+		{
+			name: "x86 extended leaf on AMD",
+			template: CustomCPUTemplate{
+				CPUIDModifiers: []CPUIDModifier{{Leaf: "0x80000001", Subleaf: "0x0", Modifiers: []CPUIDRegisterModifier{
+					{Register: "ecx", Bitmap: "0b1x"},
+				}}},
+			},
+			cpu:   amd,
+			valid: true,
+		},
+		{
+			name: "aarch64 on aarch64",
+			template: CustomCPUTemplate{
+				RegModifiers: []RegisterModifier{{Addr: "0x603000000013c020", Bitmap: "0bxxxx0000"}},
+				VCPUFeatures: []VCPUFeature{{Index: 0, Bitmap: "0b1100000"}},
+			},
+			cpu:   arm,
+			valid: true,
+		},
+		{
+			name:     "empty",
+			template: CustomCPUTemplate{},
+			cpu:      arm,
+			valid:    true,
+		},
+		{
+			name:     "x86 on aarch64",
+			template: CustomCPUTemplate{MSRModifiers: []RegisterModifier{{Addr: "0x10a", Bitmap: "0b0"}}},
+			cpu:      arm,
+		},
+		{
+			name:     "aarch64 on x86",
+			template: CustomCPUTemplate{VCPUFeatures: []VCPUFeature{{Index: 0, Bitmap: "0b1"}}},
+			cpu:      amd,
+		},
+		{
+			name:     "unsupported vendor",
+			template: CustomCPUTemplate{},
+			cpu:      HostCPU{Arch: "amd64", Vendor: "HygonGenuine"},
+		},
+		{
+			name: "leaf beyond the CPUID level",
+			template: CustomCPUTemplate{
+				CPUIDModifiers: []CPUIDModifier{{Leaf: "0x14", Subleaf: "0x0"}},
+			},
+			cpu: intel,
+		},
+		{
+			name: "invalid register",
+			template: CustomCPUTemplate{
+				CPUIDModifiers: []CPUIDModifier{{Leaf: "0x1", Subleaf: "0x0", Modifiers: []CPUIDRegisterModifier{
+					{Register: "rax", Bitmap: "0b1"},
+				}}},
+			},
+			cpu: intel,
+		},
+		{
+			name: "bitmap too long",
+			template: CustomCPUTemplate{
+				CPUIDModifiers: []CPUIDModifier{{Leaf: "0x1", Subleaf: "0x0", Modifiers: []CPUIDRegisterModifier{
+					{Register: "eax", Bitmap: "0b" + "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx"},
+				}}},
+			},
+			cpu: intel,
+		},
+		{
+			name:     "invalid bit",
+			template: CustomCPUTemplate{MSRModifiers: []RegisterModifier{{Addr: "0x10a", Bitmap: "0b102"}}},
+			cpu:      intel,
+		},
+		{
+			name:     "bitmap without prefix",
+			template: CustomCPUTemplate{MSRModifiers: []RegisterModifier{{Addr: "0x10a", Bitmap: "0101"}}},
+			cpu:      intel,
+		},
+		{
+			name:     "invalid address",
+			template: CustomCPUTemplate{RegModifiers: []RegisterModifier{{Addr: "0xzz", Bitmap: "0b1"}}},
+			cpu:      arm,
+		},
+		{
+			name:     "invalid KVM capability",
+			template: CustomCPUTemplate{KVMCapabilities: []string{"KVM_CAP_XSAVE"}},
+			cpu:      arm,
+		},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			err := c.template.Validate(c.cpu)
+			if c.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+)
+
+// FirecrackerVersion is the version a Firecracker binary reports.
+type FirecrackerVersion struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+func (v FirecrackerVersion) String() string {
+	return fmt.Sprintf("v%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// AtLeast returns true if v is the given version or a later one.
+func (v FirecrackerVersion) AtLeast(major, minor, patch int) bool {
+	if v.Major != major {
+		return v.Major > major
+	}
+	if v.Minor != minor {
+		return v.Minor > minor
+	}
+	return v.Patch >= patch
+}
+
+// FirecrackerCapabilities are the features of the API of a Firecracker binary the runtime gates
+// requests on.
+type FirecrackerCapabilities struct {
+	Version FirecrackerVersion
+	// Balloon is true if VMs can have a balloon device.
+	Balloon bool
+	// Snapshots is true if VMs can be snapshotted and resumed right away once restored.
+	Snapshots bool
+	// MMDSV2 is true if the MMDS supports its session token based version 2.
+	MMDSV2 bool
+	// CustomCPUTemplates is true if VMs can have custom CPU templates.
+	CustomCPUTemplates bool
+}
+
+// CapabilitiesOf returns the capabilities of the given version of Firecracker.
+func CapabilitiesOf(v FirecrackerVersion) FirecrackerCapabilities {
+	return FirecrackerCapabilities{
+		Version:            v,
+		Balloon:            v.AtLeast(0, 24, 0),
+		Snapshots:          v.AtLeast(0, 25, 0),
+		MMDSV2:             v.AtLeast(1, 0, 0),
+		CustomCPUTemplates: v.AtLeast(1, 4, 0),
+	}
+}
+
+var firecrackerVersion = regexp.MustCompile(`Firecracker v(\d+)\.(\d+)\.(\d+)`)
+
+// DetectFirecrackerVersion runs the given Firecracker binary to get its version.
+func DetectFirecrackerVersion(ctx context.Context, path string) (FirecrackerVersion, error) {
+	output, err := exec.CommandContext(ctx, path, "--version").Output()
+	if err != nil {
+		return FirecrackerVersion{}, fmt.Errorf("failed to run %q: %w", path, err)
+	}
+	return parseFirecrackerVersion(string(output))
+}
+
+func parseFirecrackerVersion(output string) (FirecrackerVersion, error) {
+	m := firecrackerVersion.FindStringSubmatch(output)
+	if m == nil {
+		return FirecrackerVersion{}, fmt.Errorf("no version in %q", output)
+	}
+
+	var v FirecrackerVersion
+	for i, n := range []*int{&v.Major, &v.Minor, &v.Patch} {
+		// the regexp only matches digits
+		*n, _ = strconv.Atoi(m[i+1])
+	}
+	return v, nil
+}
@@ -0,0 +1,54 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFirecrackerVersion(t *testing.T) {
+	v, err := parseFirecrackerVersion("Firecracker v1.1.0\n\nSupported snapshot data format versions: v1.0.0\n")
+	require.NoError(t, err)
+	assert.Equal(t, FirecrackerVersion{Major: 1, Minor: 1, Patch: 0}, v)
+	assert.Equal(t, "v1.1.0", v.String())
+
+	v, err = parseFirecrackerVersion("Firecracker v0.25.2-dirty\n")
+	require.NoError(t, err)
+	assert.Equal(t, FirecrackerVersion{Major: 0, Minor: 25, Patch: 2}, v)
+
+	_, err = parseFirecrackerVersion("jailer v1.1.0\n")
+	assert.Error(t, err)
+}
+
+func TestFirecrackerCapabilities(t *testing.T) {
+	caps := CapabilitiesOf(FirecrackerVersion{Major: 0, Minor: 24, Patch: 6})
+	assert.True(t, caps.Balloon)
+	assert.False(t, caps.Snapshots)
+	assert.False(t, caps.MMDSV2)
+
+	caps = CapabilitiesOf(FirecrackerVersion{Major: 1, Minor: 0, Patch: 0})
+	assert.True(t, caps.Balloon)
+	assert.True(t, caps.Snapshots)
+	assert.True(t, caps.MMDSV2)
+	assert.False(t, caps.CustomCPUTemplates)
+
+	caps = CapabilitiesOf(FirecrackerVersion{Major: 1, Minor: 4, Patch: 0})
+	assert.True(t, caps.CustomCPUTemplates)
+
+	assert.False(t, FirecrackerVersion{Major: 0, Minor: 25, Patch: 0}.AtLeast(1, 0, 0))
+	assert.True(t, FirecrackerVersion{Major: 1, Minor: 0, Patch: 1}.AtLeast(0, 25, 0))
+}
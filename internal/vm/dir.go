@@ -113,6 +113,18 @@ func (d Dir) FirecrackerLogFifoPath() string {
 	return filepath.Join(d.RootPath(), internal.FirecrackerLogFifoName)
 }
 
+// FirecrackerLogFilePath returns the path to the file the shim copies the logs of the
+// firecracker VMM to, if enabled
+func (d Dir) FirecrackerLogFilePath() string {
+	return filepath.Join(d.RootPath(), internal.FirecrackerLogFileName)
+}
+
+// ConsoleLogFilePath returns the path to the file the shim writes the serial console output
+// of the VM to
+func (d Dir) ConsoleLogFilePath() string {
+	return filepath.Join(d.RootPath(), internal.ConsoleLogFileName)
+}
+
 // FirecrackerMetricsFifoPath returns the path to the FIFO at which the firecracker VMM writes
 // metrics
 func (d Dir) FirecrackerMetricsFifoPath() string {
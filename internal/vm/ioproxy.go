@@ -183,6 +183,13 @@ func NewIOConnectorProxy(stdin, stdout, stderr *IOConnectorPair) IOProxy {
 	}
 }
 
+// StartIOProxy starts proxying the stdio of a process which is not managed by a TaskManager.
+// procCtx must be canceled once the process has exited, after which the streams are flushed
+// and closed. It returns the same channels as IOProxy's start.
+func StartIOProxy(procCtx context.Context, logger *logrus.Entry, proxy IOProxy) (ioInitDone <-chan error, ioCopyDone <-chan error) {
+	return proxy.start(&vmProc{ctx: procCtx, logger: logger, proxy: proxy})
+}
+
 func (ioConnectorSet *ioConnectorSet) Close() {
 	ioConnectorSet.closeMu.Lock()
 	defer ioConnectorSet.closeMu.Unlock()
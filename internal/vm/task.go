@@ -16,6 +16,7 @@ package vm
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -62,6 +63,14 @@ type TaskManager interface {
 	// It returns a bool indicating whether TaskManager shut down as a result of the call.
 	ShutdownIfEmpty() bool
 
+	// TaskIDs returns the IDs of the tasks being managed, sorted.
+	TaskIDs() []string
+
+	// AbandonAll removes every task and exec being managed without deleting them from a
+	// TaskService, as their processes were lost along with the VM running them. Their IO
+	// streams are closed. It returns the sorted exec IDs of the removed processes by task ID.
+	AbandonAll() map[string][]string
+
 	// AttachIO attaches the given IO proxy to a task or exec.
 	AttachIO(context.Context, string, string, IOProxy) error
 
@@ -172,6 +181,37 @@ func (m *taskManager) ShutdownIfEmpty() bool {
 	return false
 }
 
+func (m *taskManager) TaskIDs() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ids := make([]string, 0, len(m.tasks))
+	for id := range m.tasks {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func (m *taskManager) AbandonAll() map[string][]string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	abandoned := make(map[string][]string, len(m.tasks))
+	for taskID, procs := range m.tasks {
+		execIDs := make([]string, 0, len(procs))
+		for execID, proc := range procs {
+			// cancelling the process stops waiting for its exit and closes its IO once flushed
+			proc.cancel()
+			execIDs = append(execIDs, execID)
+		}
+		sort.Strings(execIDs)
+		abandoned[taskID] = execIDs
+	}
+	m.tasks = make(map[string]map[string]*vmProc)
+	return abandoned
+}
+
 func (m *taskManager) CreateTask(
 	reqCtx context.Context,
 	req *taskAPI.CreateTaskRequest,
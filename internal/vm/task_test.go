@@ -338,6 +338,7 @@ func TestTaskManager_CreateExecDeleteTask(t *testing.T) {
 	_, err := tm.CreateTask(createReqCtx, &taskAPI.CreateTaskRequest{ID: mockTask.TaskID}, ts, mockTask.IOConnectorSet)
 	require.NoError(t, err, "create task failed")
 	createReqCancel()
+	require.Equal(t, []string{mockTask.TaskID}, tm.TaskIDs(), "created task not managed")
 
 	taskStdinData := []byte("stdin")
 	err = mockTask.WriteStdin(taskStdinData)
@@ -418,6 +419,8 @@ func TestTaskManager_CreateExecDeleteTask(t *testing.T) {
 	require.NoError(t, err, "delete task failed")
 	deleteReqCancel()
 
+	require.Empty(t, tm.TaskIDs(), "deleted task still managed")
+
 	didShutdown = tm.ShutdownIfEmpty()
 	require.True(t, didShutdown, "task manager didn't shutdown when all tasks deleted")
 
@@ -508,3 +511,49 @@ func TestTaskManager_IOCreateFails(t *testing.T) {
 	mockTaskWaitReqs := ts.PopWaitRequests(mockTask.TaskID)
 	require.Lenf(t, mockTaskWaitReqs, 0, "Wait called unexpected number of times for %q", mockTask.TaskID)
 }
+
+// verifies that abandoned processes stop being managed without being deleted from the TaskService
+func TestTaskManager_AbandonAll(t *testing.T) {
+	shimCtx, shimCancel := context.WithCancel(context.Background())
+	defer shimCancel()
+
+	logger, logHook := test.NewNullLogger()
+	logger.SetLevel(logrus.DebugLevel)
+	defer func() {
+		for _, entry := range logHook.AllEntries() {
+			logLine, _ := entry.String()
+			t.Log(logLine)
+		}
+	}()
+
+	tm := NewTaskManager(shimCtx, logger.WithField("test", t.Name()))
+	ts := &mockTaskService{}
+
+	mockTask := newMockProc("fakeTask", "", mockIOConnector, mockIOConnector, mockIOConnector)
+	ts.SetWaitCh(mockTask.TaskID, mockTask.ExecID, mockTask.WaitCh)
+	defer close(mockTask.WaitCh)
+	_, err := tm.CreateTask(shimCtx, &taskAPI.CreateTaskRequest{ID: mockTask.TaskID}, ts, mockTask.IOConnectorSet)
+	require.NoError(t, err, "create task failed")
+
+	mockExec := newMockProc(mockTask.TaskID, "fakeExec", mockIOConnector, mockIOConnector, mockIOConnector)
+	ts.SetWaitCh(mockExec.TaskID, mockExec.ExecID, mockExec.WaitCh)
+	defer close(mockExec.WaitCh)
+	_, err = tm.ExecProcess(shimCtx, &taskAPI.ExecProcessRequest{
+		ID:     mockExec.TaskID,
+		ExecID: mockExec.ExecID,
+	}, ts, mockExec.IOConnectorSet)
+	require.NoError(t, err, "exec failed")
+
+	abandoned := tm.AbandonAll()
+	require.Equal(t, map[string][]string{mockTask.TaskID: {"", mockExec.ExecID}}, abandoned)
+	require.Empty(t, tm.TaskIDs(), "abandoned task still managed")
+	require.Empty(t, ts.PopDeleteRequests(mockTask.TaskID), "abandoned task was deleted")
+
+	// a task with the same ID can be created again, e.g. in a relaunched VM
+	recreatedTask := newMockProc(mockTask.TaskID, "", mockIOConnector, mockIOConnector, mockIOConnector)
+	ts.SetWaitCh(recreatedTask.TaskID, recreatedTask.ExecID, recreatedTask.WaitCh)
+	defer close(recreatedTask.WaitCh)
+	_, err = tm.CreateTask(shimCtx, &taskAPI.CreateTaskRequest{ID: recreatedTask.TaskID}, ts, recreatedTask.IOConnectorSet)
+	require.NoError(t, err, "create task after abandoning it failed")
+	require.Equal(t, []string{mockTask.TaskID}, tm.TaskIDs())
+}
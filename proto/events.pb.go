@@ -20,31 +20,634 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// VMStopStage is a stage of stopping a VM. Each stage is more forceful than the previous one and
+// is only reached if the VM didn't stop at the previous one.
+type VMStopStage int32
+
+const (
+	// The VM wasn't stopped by StopVM or Shutdown, e.g. it crashed
+	VMStopStage_NO_STOP_STAGE VMStopStage = 0
+	// The tasks exited on SIGTERM and the agent shut the VM down
+	VMStopStage_TASKS_TERMINATED VMStopStage = 1
+	// The tasks were sent SIGKILL after the grace period and the agent shut the VM down
+	VMStopStage_TASKS_KILLED VMStopStage = 2
+	// The VM was sent Ctrl+Alt+Del
+	VMStopStage_CTRL_ALT_DEL VMStopStage = 3
+	// The Firecracker process was killed
+	VMStopStage_VMM_KILLED VMStopStage = 4
+)
+
+// Enum value maps for VMStopStage.
+var (
+	VMStopStage_name = map[int32]string{
+		0: "NO_STOP_STAGE",
+		1: "TASKS_TERMINATED",
+		2: "TASKS_KILLED",
+		3: "CTRL_ALT_DEL",
+		4: "VMM_KILLED",
+	}
+	VMStopStage_value = map[string]int32{
+		"NO_STOP_STAGE":    0,
+		"TASKS_TERMINATED": 1,
+		"TASKS_KILLED":     2,
+		"CTRL_ALT_DEL":     3,
+		"VMM_KILLED":       4,
+	}
+)
+
+func (x VMStopStage) Enum() *VMStopStage {
+	p := new(VMStopStage)
+	*p = x
+	return p
+}
+
+func (x VMStopStage) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (VMStopStage) Descriptor() protoreflect.EnumDescriptor {
+	return file_events_proto_enumTypes[0].Descriptor()
+}
+
+func (VMStopStage) Type() protoreflect.EnumType {
+	return &file_events_proto_enumTypes[0]
+}
+
+func (x VMStopStage) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use VMStopStage.Descriptor instead.
+func (VMStopStage) EnumDescriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{0}
+}
+
 type VMStart struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	VMID string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+	VMID string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+}
+
+func (x *VMStart) Reset() {
+	*x = VMStart{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_events_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VMStart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VMStart) ProtoMessage() {}
+
+func (x *VMStart) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VMStart.ProtoReflect.Descriptor instead.
+func (*VMStart) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *VMStart) GetVMID() string {
+	if x != nil {
+		return x.VMID
+	}
+	return ""
+}
+
+type VMStop struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VMID string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+	// Why the VM stopped, e.g. whether it was requested, forcefully terminated or crashed
+	Reason string `protobuf:"bytes,2,opt,name=Reason,proto3" json:"Reason,omitempty"`
+	// The stage of StopVM or Shutdown the VM stopped at
+	Stage VMStopStage `protobuf:"varint,3,opt,name=Stage,proto3,enum=VMStopStage" json:"Stage,omitempty"`
+}
+
+func (x *VMStop) Reset() {
+	*x = VMStop{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_events_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VMStop) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VMStop) ProtoMessage() {}
+
+func (x *VMStop) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VMStop.ProtoReflect.Descriptor instead.
+func (*VMStop) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *VMStop) GetVMID() string {
+	if x != nil {
+		return x.VMID
+	}
+	return ""
+}
+
+func (x *VMStop) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *VMStop) GetStage() VMStopStage {
+	if x != nil {
+		return x.Stage
+	}
+	return VMStopStage_NO_STOP_STAGE
+}
+
+type VMPause struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VMID   string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+	Reason string `protobuf:"bytes,2,opt,name=Reason,proto3" json:"Reason,omitempty"`
+}
+
+func (x *VMPause) Reset() {
+	*x = VMPause{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_events_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VMPause) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VMPause) ProtoMessage() {}
+
+func (x *VMPause) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VMPause.ProtoReflect.Descriptor instead.
+func (*VMPause) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *VMPause) GetVMID() string {
+	if x != nil {
+		return x.VMID
+	}
+	return ""
+}
+
+func (x *VMPause) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type VMResume struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VMID   string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+	Reason string `protobuf:"bytes,2,opt,name=Reason,proto3" json:"Reason,omitempty"`
+}
+
+func (x *VMResume) Reset() {
+	*x = VMResume{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_events_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VMResume) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VMResume) ProtoMessage() {}
+
+func (x *VMResume) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VMResume.ProtoReflect.Descriptor instead.
+func (*VMResume) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *VMResume) GetVMID() string {
+	if x != nil {
+		return x.VMID
+	}
+	return ""
+}
+
+func (x *VMResume) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type VMBalloonUpdate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VMID   string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+	Reason string `protobuf:"bytes,2,opt,name=Reason,proto3" json:"Reason,omitempty"`
+	// The new target size of the balloon
+	AmountMib int64 `protobuf:"varint,3,opt,name=AmountMib,proto3" json:"AmountMib,omitempty"`
+}
+
+func (x *VMBalloonUpdate) Reset() {
+	*x = VMBalloonUpdate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_events_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VMBalloonUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VMBalloonUpdate) ProtoMessage() {}
+
+func (x *VMBalloonUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VMBalloonUpdate.ProtoReflect.Descriptor instead.
+func (*VMBalloonUpdate) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *VMBalloonUpdate) GetVMID() string {
+	if x != nil {
+		return x.VMID
+	}
+	return ""
+}
+
+func (x *VMBalloonUpdate) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *VMBalloonUpdate) GetAmountMib() int64 {
+	if x != nil {
+		return x.AmountMib
+	}
+	return 0
+}
+
+type VMDriveMount struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VMID     string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+	Reason   string `protobuf:"bytes,2,opt,name=Reason,proto3" json:"Reason,omitempty"`
+	HostPath string `protobuf:"bytes,3,opt,name=HostPath,proto3" json:"HostPath,omitempty"`
+	VMPath   string `protobuf:"bytes,4,opt,name=VMPath,proto3" json:"VMPath,omitempty"`
+}
+
+func (x *VMDriveMount) Reset() {
+	*x = VMDriveMount{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_events_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VMDriveMount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VMDriveMount) ProtoMessage() {}
+
+func (x *VMDriveMount) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VMDriveMount.ProtoReflect.Descriptor instead.
+func (*VMDriveMount) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *VMDriveMount) GetVMID() string {
+	if x != nil {
+		return x.VMID
+	}
+	return ""
+}
+
+func (x *VMDriveMount) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *VMDriveMount) GetHostPath() string {
+	if x != nil {
+		return x.HostPath
+	}
+	return ""
+}
+
+func (x *VMDriveMount) GetVMPath() string {
+	if x != nil {
+		return x.VMPath
+	}
+	return ""
+}
+
+type VMDriveUnmount struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VMID     string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+	Reason   string `protobuf:"bytes,2,opt,name=Reason,proto3" json:"Reason,omitempty"`
+	HostPath string `protobuf:"bytes,3,opt,name=HostPath,proto3" json:"HostPath,omitempty"`
+	VMPath   string `protobuf:"bytes,4,opt,name=VMPath,proto3" json:"VMPath,omitempty"`
+}
+
+func (x *VMDriveUnmount) Reset() {
+	*x = VMDriveUnmount{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_events_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VMDriveUnmount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VMDriveUnmount) ProtoMessage() {}
+
+func (x *VMDriveUnmount) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VMDriveUnmount.ProtoReflect.Descriptor instead.
+func (*VMDriveUnmount) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *VMDriveUnmount) GetVMID() string {
+	if x != nil {
+		return x.VMID
+	}
+	return ""
+}
+
+func (x *VMDriveUnmount) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *VMDriveUnmount) GetHostPath() string {
+	if x != nil {
+		return x.HostPath
+	}
+	return ""
+}
+
+func (x *VMDriveUnmount) GetVMPath() string {
+	if x != nil {
+		return x.VMPath
+	}
+	return ""
+}
+
+type VMAgentDisconnect struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VMID   string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+	Reason string `protobuf:"bytes,2,opt,name=Reason,proto3" json:"Reason,omitempty"`
+}
+
+func (x *VMAgentDisconnect) Reset() {
+	*x = VMAgentDisconnect{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_events_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VMAgentDisconnect) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VMAgentDisconnect) ProtoMessage() {}
+
+func (x *VMAgentDisconnect) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VMAgentDisconnect.ProtoReflect.Descriptor instead.
+func (*VMAgentDisconnect) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *VMAgentDisconnect) GetVMID() string {
+	if x != nil {
+		return x.VMID
+	}
+	return ""
+}
+
+func (x *VMAgentDisconnect) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type VMForceTerminate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VMID   string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+	Reason string `protobuf:"bytes,2,opt,name=Reason,proto3" json:"Reason,omitempty"`
+}
+
+func (x *VMForceTerminate) Reset() {
+	*x = VMForceTerminate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_events_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VMForceTerminate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VMForceTerminate) ProtoMessage() {}
+
+func (x *VMForceTerminate) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VMForceTerminate.ProtoReflect.Descriptor instead.
+func (*VMForceTerminate) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *VMForceTerminate) GetVMID() string {
+	if x != nil {
+		return x.VMID
+	}
+	return ""
+}
+
+func (x *VMForceTerminate) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type VMUnexpectedExit struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VMID   string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+	Reason string `protobuf:"bytes,2,opt,name=Reason,proto3" json:"Reason,omitempty"`
+	// The exit status of the Firecracker process, 128+n if it was killed by signal n
+	ExitStatus int32 `protobuf:"varint,3,opt,name=ExitStatus,proto3" json:"ExitStatus,omitempty"`
 }
 
-func (x *VMStart) Reset() {
-	*x = VMStart{}
+func (x *VMUnexpectedExit) Reset() {
+	*x = VMUnexpectedExit{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_events_proto_msgTypes[0]
+		mi := &file_events_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *VMStart) String() string {
+func (x *VMUnexpectedExit) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VMStart) ProtoMessage() {}
+func (*VMUnexpectedExit) ProtoMessage() {}
 
-func (x *VMStart) ProtoReflect() protoreflect.Message {
-	mi := &file_events_proto_msgTypes[0]
+func (x *VMUnexpectedExit) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -55,43 +658,60 @@ func (x *VMStart) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VMStart.ProtoReflect.Descriptor instead.
-func (*VMStart) Descriptor() ([]byte, []int) {
-	return file_events_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use VMUnexpectedExit.ProtoReflect.Descriptor instead.
+func (*VMUnexpectedExit) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *VMStart) GetVMID() string {
+func (x *VMUnexpectedExit) GetVMID() string {
 	if x != nil {
 		return x.VMID
 	}
 	return ""
 }
 
-type VMStop struct {
+func (x *VMUnexpectedExit) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *VMUnexpectedExit) GetExitStatus() int32 {
+	if x != nil {
+		return x.ExitStatus
+	}
+	return 0
+}
+
+type VMRestart struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	VMID string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+	VMID   string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+	Reason string `protobuf:"bytes,2,opt,name=Reason,proto3" json:"Reason,omitempty"`
+	// The number of times the VM has been relaunched, including this time
+	Attempt uint32 `protobuf:"varint,3,opt,name=Attempt,proto3" json:"Attempt,omitempty"`
 }
 
-func (x *VMStop) Reset() {
-	*x = VMStop{}
+func (x *VMRestart) Reset() {
+	*x = VMRestart{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_events_proto_msgTypes[1]
+		mi := &file_events_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *VMStop) String() string {
+func (x *VMRestart) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VMStop) ProtoMessage() {}
+func (*VMRestart) ProtoMessage() {}
 
-func (x *VMStop) ProtoReflect() protoreflect.Message {
-	mi := &file_events_proto_msgTypes[1]
+func (x *VMRestart) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -102,27 +722,238 @@ func (x *VMStop) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VMStop.ProtoReflect.Descriptor instead.
-func (*VMStop) Descriptor() ([]byte, []int) {
-	return file_events_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use VMRestart.ProtoReflect.Descriptor instead.
+func (*VMRestart) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *VMStop) GetVMID() string {
+func (x *VMRestart) GetVMID() string {
+	if x != nil {
+		return x.VMID
+	}
+	return ""
+}
+
+func (x *VMRestart) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *VMRestart) GetAttempt() uint32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+type VMAgentUnhealthy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VMID   string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+	Reason string `protobuf:"bytes,2,opt,name=Reason,proto3" json:"Reason,omitempty"`
+	// The number of consecutive pings the agent did not answer
+	MissedPings uint32 `protobuf:"varint,3,opt,name=MissedPings,proto3" json:"MissedPings,omitempty"`
+}
+
+func (x *VMAgentUnhealthy) Reset() {
+	*x = VMAgentUnhealthy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_events_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VMAgentUnhealthy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VMAgentUnhealthy) ProtoMessage() {}
+
+func (x *VMAgentUnhealthy) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VMAgentUnhealthy.ProtoReflect.Descriptor instead.
+func (*VMAgentUnhealthy) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *VMAgentUnhealthy) GetVMID() string {
+	if x != nil {
+		return x.VMID
+	}
+	return ""
+}
+
+func (x *VMAgentUnhealthy) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *VMAgentUnhealthy) GetMissedPings() uint32 {
+	if x != nil {
+		return x.MissedPings
+	}
+	return 0
+}
+
+type VMClockSync struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VMID   string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+	Reason string `protobuf:"bytes,2,opt,name=Reason,proto3" json:"Reason,omitempty"`
+	// How far the clock of the VM was behind the host, negative if it was ahead
+	SkewNanoseconds int64 `protobuf:"varint,3,opt,name=SkewNanoseconds,proto3" json:"SkewNanoseconds,omitempty"`
+}
+
+func (x *VMClockSync) Reset() {
+	*x = VMClockSync{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_events_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VMClockSync) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VMClockSync) ProtoMessage() {}
+
+func (x *VMClockSync) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VMClockSync.ProtoReflect.Descriptor instead.
+func (*VMClockSync) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *VMClockSync) GetVMID() string {
 	if x != nil {
 		return x.VMID
 	}
 	return ""
 }
 
+func (x *VMClockSync) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *VMClockSync) GetSkewNanoseconds() int64 {
+	if x != nil {
+		return x.SkewNanoseconds
+	}
+	return 0
+}
+
 var File_events_proto protoreflect.FileDescriptor
 
 var file_events_proto_rawDesc = []byte{
 	0x0a, 0x0c, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x1d,
 	0x0a, 0x07, 0x56, 0x4d, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49,
-	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x22, 0x1c, 0x0a,
+	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x22, 0x58, 0x0a,
 	0x06, 0x56, 0x4d, 0x53, 0x74, 0x6f, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x42, 0x09, 0x5a, 0x07, 0x2e,
-	0x3b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x52,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x52, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x12, 0x22, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x56, 0x4d, 0x53, 0x74, 0x6f, 0x70, 0x53, 0x74, 0x61, 0x67, 0x65,
+	0x52, 0x05, 0x53, 0x74, 0x61, 0x67, 0x65, 0x22, 0x35, 0x0a, 0x07, 0x56, 0x4d, 0x50, 0x61, 0x75,
+	0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x36,
+	0x0a, 0x08, 0x56, 0x4d, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d,
+	0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x16,
+	0x0a, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x5b, 0x0a, 0x0f, 0x56, 0x4d, 0x42, 0x61, 0x6c, 0x6c,
+	0x6f, 0x6f, 0x6e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49,
+	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x16, 0x0a,
+	0x06, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x52,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x4d,
+	0x69, 0x62, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x4d, 0x69, 0x62, 0x22, 0x6e, 0x0a, 0x0c, 0x56, 0x4d, 0x44, 0x72, 0x69, 0x76, 0x65, 0x4d, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12,
+	0x1a, 0x0a, 0x08, 0x48, 0x6f, 0x73, 0x74, 0x50, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x48, 0x6f, 0x73, 0x74, 0x50, 0x61, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x56,
+	0x4d, 0x50, 0x61, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x56, 0x4d, 0x50,
+	0x61, 0x74, 0x68, 0x22, 0x70, 0x0a, 0x0e, 0x56, 0x4d, 0x44, 0x72, 0x69, 0x76, 0x65, 0x55, 0x6e,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x52, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x48, 0x6f, 0x73, 0x74, 0x50, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x48, 0x6f, 0x73, 0x74, 0x50, 0x61, 0x74, 0x68, 0x12, 0x16, 0x0a,
+	0x06, 0x56, 0x4d, 0x50, 0x61, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x56,
+	0x4d, 0x50, 0x61, 0x74, 0x68, 0x22, 0x3f, 0x0a, 0x11, 0x56, 0x4d, 0x41, 0x67, 0x65, 0x6e, 0x74,
+	0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d,
+	0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x16,
+	0x0a, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x3e, 0x0a, 0x10, 0x56, 0x4d, 0x46, 0x6f, 0x72, 0x63,
+	0x65, 0x54, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d,
+	0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x16,
+	0x0a, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x5e, 0x0a, 0x10, 0x56, 0x4d, 0x55, 0x6e, 0x65, 0x78,
+	0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x45, 0x78, 0x69, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d,
+	0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x16,
+	0x0a, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x45, 0x78, 0x69, 0x74, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x45, 0x78, 0x69, 0x74,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x51, 0x0a, 0x09, 0x56, 0x4d, 0x52, 0x65, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12,
+	0x18, 0x0a, 0x07, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x07, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x22, 0x60, 0x0a, 0x10, 0x56, 0x4d, 0x41,
+	0x67, 0x65, 0x6e, 0x74, 0x55, 0x6e, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x79, 0x12, 0x12, 0x0a,
+	0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49,
+	0x44, 0x12, 0x16, 0x0a, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x4d, 0x69, 0x73,
+	0x73, 0x65, 0x64, 0x50, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b,
+	0x4d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x50, 0x69, 0x6e, 0x67, 0x73, 0x22, 0x63, 0x0a, 0x0b, 0x56,
+	0x4d, 0x43, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x79, 0x6e, 0x63, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d,
+	0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x16,
+	0x0a, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x28, 0x0a, 0x0f, 0x53, 0x6b, 0x65, 0x77, 0x4e, 0x61,
+	0x6e, 0x6f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0f, 0x53, 0x6b, 0x65, 0x77, 0x4e, 0x61, 0x6e, 0x6f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73,
+	0x2a, 0x6a, 0x0a, 0x0b, 0x56, 0x4d, 0x53, 0x74, 0x6f, 0x70, 0x53, 0x74, 0x61, 0x67, 0x65, 0x12,
+	0x11, 0x0a, 0x0d, 0x4e, 0x4f, 0x5f, 0x53, 0x54, 0x4f, 0x50, 0x5f, 0x53, 0x54, 0x41, 0x47, 0x45,
+	0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x54, 0x41, 0x53, 0x4b, 0x53, 0x5f, 0x54, 0x45, 0x52, 0x4d,
+	0x49, 0x4e, 0x41, 0x54, 0x45, 0x44, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x54, 0x41, 0x53, 0x4b,
+	0x53, 0x5f, 0x4b, 0x49, 0x4c, 0x4c, 0x45, 0x44, 0x10, 0x02, 0x12, 0x10, 0x0a, 0x0c, 0x43, 0x54,
+	0x52, 0x4c, 0x5f, 0x41, 0x4c, 0x54, 0x5f, 0x44, 0x45, 0x4c, 0x10, 0x03, 0x12, 0x0e, 0x0a, 0x0a,
+	0x56, 0x4d, 0x4d, 0x5f, 0x4b, 0x49, 0x4c, 0x4c, 0x45, 0x44, 0x10, 0x04, 0x42, 0x09, 0x5a, 0x07,
+	0x2e, 0x3b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -137,17 +968,31 @@ func file_events_proto_rawDescGZIP() []byte {
 	return file_events_proto_rawDescData
 }
 
-var file_events_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_events_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_events_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_events_proto_goTypes = []interface{}{
-	(*VMStart)(nil), // 0: VMStart
-	(*VMStop)(nil),  // 1: VMStop
+	(VMStopStage)(0),          // 0: VMStopStage
+	(*VMStart)(nil),           // 1: VMStart
+	(*VMStop)(nil),            // 2: VMStop
+	(*VMPause)(nil),           // 3: VMPause
+	(*VMResume)(nil),          // 4: VMResume
+	(*VMBalloonUpdate)(nil),   // 5: VMBalloonUpdate
+	(*VMDriveMount)(nil),      // 6: VMDriveMount
+	(*VMDriveUnmount)(nil),    // 7: VMDriveUnmount
+	(*VMAgentDisconnect)(nil), // 8: VMAgentDisconnect
+	(*VMForceTerminate)(nil),  // 9: VMForceTerminate
+	(*VMUnexpectedExit)(nil),  // 10: VMUnexpectedExit
+	(*VMRestart)(nil),         // 11: VMRestart
+	(*VMAgentUnhealthy)(nil),  // 12: VMAgentUnhealthy
+	(*VMClockSync)(nil),       // 13: VMClockSync
 }
 var file_events_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0, // 0: VMStop.Stage:type_name -> VMStopStage
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_events_proto_init() }
@@ -180,19 +1025,152 @@ func file_events_proto_init() {
 				return nil
 			}
 		}
+		file_events_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VMPause); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_events_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VMResume); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_events_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VMBalloonUpdate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_events_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VMDriveMount); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_events_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VMDriveUnmount); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_events_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VMAgentDisconnect); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_events_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VMForceTerminate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_events_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VMUnexpectedExit); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_events_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VMRestart); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_events_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VMAgentUnhealthy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_events_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VMClockSync); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_events_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   2,
+			NumEnums:      1,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_events_proto_goTypes,
 		DependencyIndexes: file_events_proto_depIdxs,
+		EnumInfos:         file_events_proto_enumTypes,
 		MessageInfos:      file_events_proto_msgTypes,
 	}.Build()
 	File_events_proto = out.File
@@ -7,6 +7,7 @@
 package proto
 
 import (
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -20,6 +21,107 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// SnapshotType selects between a snapshot of the whole guest memory and a diff snapshot
+// containing only the pages dirtied since the previous snapshot.
+type SnapshotType int32
+
+const (
+	SnapshotType_FULL SnapshotType = 0
+	SnapshotType_DIFF SnapshotType = 1
+)
+
+// Enum value maps for SnapshotType.
+var (
+	SnapshotType_name = map[int32]string{
+		0: "FULL",
+		1: "DIFF",
+	}
+	SnapshotType_value = map[string]int32{
+		"FULL": 0,
+		"DIFF": 1,
+	}
+)
+
+func (x SnapshotType) Enum() *SnapshotType {
+	p := new(SnapshotType)
+	*p = x
+	return p
+}
+
+func (x SnapshotType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SnapshotType) Descriptor() protoreflect.EnumDescriptor {
+	return file_firecracker_proto_enumTypes[0].Descriptor()
+}
+
+func (SnapshotType) Type() protoreflect.EnumType {
+	return &file_firecracker_proto_enumTypes[0]
+}
+
+func (x SnapshotType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SnapshotType.Descriptor instead.
+func (SnapshotType) EnumDescriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{0}
+}
+
+// VMState is the lifecycle state of a VM as observed by its shim.
+type VMState int32
+
+const (
+	VMState_UNKNOWN  VMState = 0
+	VMState_RUNNING  VMState = 1
+	VMState_PAUSED   VMState = 2
+	VMState_STOPPING VMState = 3
+)
+
+// Enum value maps for VMState.
+var (
+	VMState_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "RUNNING",
+		2: "PAUSED",
+		3: "STOPPING",
+	}
+	VMState_value = map[string]int32{
+		"UNKNOWN":  0,
+		"RUNNING":  1,
+		"PAUSED":   2,
+		"STOPPING": 3,
+	}
+)
+
+func (x VMState) Enum() *VMState {
+	p := new(VMState)
+	*p = x
+	return p
+}
+
+func (x VMState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (VMState) Descriptor() protoreflect.EnumDescriptor {
+	return file_firecracker_proto_enumTypes[1].Descriptor()
+}
+
+func (VMState) Type() protoreflect.EnumType {
+	return &file_firecracker_proto_enumTypes[1]
+}
+
+func (x VMState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use VMState.Descriptor instead.
+func (VMState) EnumDescriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{1}
+}
+
 // DriveExposePolicy is used to configure the method to expose drive files.
 // "COPY" is copying the files to the jail, which is the default behavior.
 // "BIND" is bind-mounting the files on the jail, assuming a caller pre-configures the permissions of
@@ -54,11 +156,11 @@ func (x DriveExposePolicy) String() string {
 }
 
 func (DriveExposePolicy) Descriptor() protoreflect.EnumDescriptor {
-	return file_firecracker_proto_enumTypes[0].Descriptor()
+	return file_firecracker_proto_enumTypes[2].Descriptor()
 }
 
 func (DriveExposePolicy) Type() protoreflect.EnumType {
-	return &file_firecracker_proto_enumTypes[0]
+	return &file_firecracker_proto_enumTypes[2]
 }
 
 func (x DriveExposePolicy) Number() protoreflect.EnumNumber {
@@ -67,7 +169,55 @@ func (x DriveExposePolicy) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use DriveExposePolicy.Descriptor instead.
 func (DriveExposePolicy) EnumDescriptor() ([]byte, []int) {
-	return file_firecracker_proto_rawDescGZIP(), []int{0}
+	return file_firecracker_proto_rawDescGZIP(), []int{2}
+}
+
+// MMDSVersion is the version of the MMDS protocol. V2 requires guests to fetch a session token
+// before reading the metadata.
+type MMDSVersion int32
+
+const (
+	MMDSVersion_V1 MMDSVersion = 0
+	MMDSVersion_V2 MMDSVersion = 1
+)
+
+// Enum value maps for MMDSVersion.
+var (
+	MMDSVersion_name = map[int32]string{
+		0: "V1",
+		1: "V2",
+	}
+	MMDSVersion_value = map[string]int32{
+		"V1": 0,
+		"V2": 1,
+	}
+)
+
+func (x MMDSVersion) Enum() *MMDSVersion {
+	p := new(MMDSVersion)
+	*p = x
+	return p
+}
+
+func (x MMDSVersion) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MMDSVersion) Descriptor() protoreflect.EnumDescriptor {
+	return file_firecracker_proto_enumTypes[3].Descriptor()
+}
+
+func (MMDSVersion) Type() protoreflect.EnumType {
+	return &file_firecracker_proto_enumTypes[3]
+}
+
+func (x MMDSVersion) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MMDSVersion.Descriptor instead.
+func (MMDSVersion) EnumDescriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{3}
 }
 
 // CreateVMRequest specifies creation parameters for a new FC instance
@@ -99,6 +249,28 @@ type CreateVMRequest struct {
 	LogFifoPath              string                    `protobuf:"bytes,12,opt,name=LogFifoPath,proto3" json:"LogFifoPath,omitempty"`
 	MetricsFifoPath          string                    `protobuf:"bytes,13,opt,name=MetricsFifoPath,proto3" json:"MetricsFifoPath,omitempty"`
 	BalloonDevice            *FirecrackerBalloonDevice `protobuf:"bytes,14,opt,name=BalloonDevice,proto3" json:"BalloonDevice,omitempty"`
+	// Restores the VM from a snapshot created by CreateSnapshot instead of booting a kernel.
+	// The machine configuration, kernel and drive layout of the snapshotted VM are used.
+	LoadSnapshot *LoadSnapshotConfig `protobuf:"bytes,15,opt,name=LoadSnapshot,proto3" json:"LoadSnapshot,omitempty"`
+	// The number of spare stub drives to reserve for drive mounts attached with AttachDriveMount
+	// after the VM has started.
+	SpareDriveCount int32 `protobuf:"varint,16,opt,name=SpareDriveCount,proto3" json:"SpareDriveCount,omitempty"`
+	// Configures the MMDS of the VM and the metadata it serves from boot. Cannot be combined
+	// with LoadSnapshot, as the MMDS configuration is part of the snapshot.
+	MMDSConfig *MMDSConfig `protobuf:"bytes,17,opt,name=MMDSConfig,proto3" json:"MMDSConfig,omitempty"`
+	// Boots the kernel of an image of the containerd image store instead of KernelImagePath.
+	KernelImage *VMImage `protobuf:"bytes,18,opt,name=KernelImage,proto3" json:"KernelImage,omitempty"`
+	// Uses the root filesystem of an image of the containerd image store instead of the host
+	// path of RootDrive. The other settings of RootDrive still apply, but the drive cannot be
+	// writable as the file is shared by all the VMs using the image.
+	RootDriveImage *VMImage `protobuf:"bytes,19,opt,name=RootDriveImage,proto3" json:"RootDriveImage,omitempty"`
+	// The name of the Firecracker binary of the runtime config to run the VM with. Defaults to
+	// the firecracker_binary_path of the runtime config.
+	FirecrackerBinary string `protobuf:"bytes,20,opt,name=FirecrackerBinary,proto3" json:"FirecrackerBinary,omitempty"`
+	// Relaunches the VM with the same configuration when Firecracker exits unexpectedly. The tasks
+	// of the VM are reported as exited, and DriveMounts are mounted again in the relaunched VM.
+	// Cannot be set with JailerConfig or LoadSnapshot.
+	RestartPolicy *RestartPolicy `protobuf:"bytes,21,opt,name=RestartPolicy,proto3" json:"RestartPolicy,omitempty"`
 }
 
 func (x *CreateVMRequest) Reset() {
@@ -231,6 +403,55 @@ func (x *CreateVMRequest) GetBalloonDevice() *FirecrackerBalloonDevice {
 	return nil
 }
 
+func (x *CreateVMRequest) GetLoadSnapshot() *LoadSnapshotConfig {
+	if x != nil {
+		return x.LoadSnapshot
+	}
+	return nil
+}
+
+func (x *CreateVMRequest) GetSpareDriveCount() int32 {
+	if x != nil {
+		return x.SpareDriveCount
+	}
+	return 0
+}
+
+func (x *CreateVMRequest) GetMMDSConfig() *MMDSConfig {
+	if x != nil {
+		return x.MMDSConfig
+	}
+	return nil
+}
+
+func (x *CreateVMRequest) GetKernelImage() *VMImage {
+	if x != nil {
+		return x.KernelImage
+	}
+	return nil
+}
+
+func (x *CreateVMRequest) GetRootDriveImage() *VMImage {
+	if x != nil {
+		return x.RootDriveImage
+	}
+	return nil
+}
+
+func (x *CreateVMRequest) GetFirecrackerBinary() string {
+	if x != nil {
+		return x.FirecrackerBinary
+	}
+	return ""
+}
+
+func (x *CreateVMRequest) GetRestartPolicy() *RestartPolicy {
+	if x != nil {
+		return x.RestartPolicy
+	}
+	return nil
+}
+
 type CreateVMResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -241,6 +462,11 @@ type CreateVMResponse struct {
 	LogFifoPath     string `protobuf:"bytes,3,opt,name=LogFifoPath,proto3" json:"LogFifoPath,omitempty"`
 	MetricsFifoPath string `protobuf:"bytes,4,opt,name=MetricsFifoPath,proto3" json:"MetricsFifoPath,omitempty"`
 	CgroupPath      string `protobuf:"bytes,5,opt,name=CgroupPath,proto3" json:"CgroupPath,omitempty"`
+	// The digests of the images the kernel and the root drive were taken from, if any
+	KernelImageDigest    string `protobuf:"bytes,6,opt,name=KernelImageDigest,proto3" json:"KernelImageDigest,omitempty"`
+	RootDriveImageDigest string `protobuf:"bytes,7,opt,name=RootDriveImageDigest,proto3" json:"RootDriveImageDigest,omitempty"`
+	// The version of the Firecracker binary running the VM
+	FirecrackerVersion string `protobuf:"bytes,8,opt,name=FirecrackerVersion,proto3" json:"FirecrackerVersion,omitempty"`
 }
 
 func (x *CreateVMResponse) Reset() {
@@ -310,6 +536,27 @@ func (x *CreateVMResponse) GetCgroupPath() string {
 	return ""
 }
 
+func (x *CreateVMResponse) GetKernelImageDigest() string {
+	if x != nil {
+		return x.KernelImageDigest
+	}
+	return ""
+}
+
+func (x *CreateVMResponse) GetRootDriveImageDigest() string {
+	if x != nil {
+		return x.RootDriveImageDigest
+	}
+	return ""
+}
+
+func (x *CreateVMResponse) GetFirecrackerVersion() string {
+	if x != nil {
+		return x.FirecrackerVersion
+	}
+	return ""
+}
+
 type PauseVMRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -411,6 +658,10 @@ type StopVMRequest struct {
 
 	VMID           string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
 	TimeoutSeconds uint32 `protobuf:"varint,2,opt,name=TimeoutSeconds,proto3" json:"TimeoutSeconds,omitempty"`
+	// GracePeriodSeconds is the time the tasks of the VM have to exit after SIGTERM before they
+	// are sent SIGKILL. It is given in addition to TimeoutSeconds, as are the 5 seconds they have
+	// to exit after SIGKILL. Defaults to 5 seconds.
+	GracePeriodSeconds uint32 `protobuf:"varint,3,opt,name=GracePeriodSeconds,proto3" json:"GracePeriodSeconds,omitempty"`
 }
 
 func (x *StopVMRequest) Reset() {
@@ -459,16 +710,28 @@ func (x *StopVMRequest) GetTimeoutSeconds() uint32 {
 	return 0
 }
 
-type GetVMInfoRequest struct {
+func (x *StopVMRequest) GetGracePeriodSeconds() uint32 {
+	if x != nil {
+		return x.GracePeriodSeconds
+	}
+	return 0
+}
+
+type CreateSnapshotRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	VMID string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+	// Path of the file the guest memory will be written to
+	MemFilePath string `protobuf:"bytes,2,opt,name=MemFilePath,proto3" json:"MemFilePath,omitempty"`
+	// Path of the file the VM state will be written to
+	SnapshotPath string       `protobuf:"bytes,3,opt,name=SnapshotPath,proto3" json:"SnapshotPath,omitempty"`
+	SnapshotType SnapshotType `protobuf:"varint,4,opt,name=SnapshotType,proto3,enum=SnapshotType" json:"SnapshotType,omitempty"`
 }
 
-func (x *GetVMInfoRequest) Reset() {
-	*x = GetVMInfoRequest{}
+func (x *CreateSnapshotRequest) Reset() {
+	*x = CreateSnapshotRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_firecracker_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -476,13 +739,13 @@ func (x *GetVMInfoRequest) Reset() {
 	}
 }
 
-func (x *GetVMInfoRequest) String() string {
+func (x *CreateSnapshotRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetVMInfoRequest) ProtoMessage() {}
+func (*CreateSnapshotRequest) ProtoMessage() {}
 
-func (x *GetVMInfoRequest) ProtoReflect() protoreflect.Message {
+func (x *CreateSnapshotRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_firecracker_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -494,33 +757,54 @@ func (x *GetVMInfoRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetVMInfoRequest.ProtoReflect.Descriptor instead.
-func (*GetVMInfoRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CreateSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*CreateSnapshotRequest) Descriptor() ([]byte, []int) {
 	return file_firecracker_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *GetVMInfoRequest) GetVMID() string {
+func (x *CreateSnapshotRequest) GetVMID() string {
 	if x != nil {
 		return x.VMID
 	}
 	return ""
 }
 
-type GetVMInfoResponse struct {
+func (x *CreateSnapshotRequest) GetMemFilePath() string {
+	if x != nil {
+		return x.MemFilePath
+	}
+	return ""
+}
+
+func (x *CreateSnapshotRequest) GetSnapshotPath() string {
+	if x != nil {
+		return x.SnapshotPath
+	}
+	return ""
+}
+
+func (x *CreateSnapshotRequest) GetSnapshotType() SnapshotType {
+	if x != nil {
+		return x.SnapshotType
+	}
+	return SnapshotType_FULL
+}
+
+type LoadSnapshotConfig struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	VMID            string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
-	SocketPath      string `protobuf:"bytes,2,opt,name=SocketPath,proto3" json:"SocketPath,omitempty"`
-	LogFifoPath     string `protobuf:"bytes,3,opt,name=LogFifoPath,proto3" json:"LogFifoPath,omitempty"`
-	MetricsFifoPath string `protobuf:"bytes,4,opt,name=MetricsFifoPath,proto3" json:"MetricsFifoPath,omitempty"`
-	CgroupPath      string `protobuf:"bytes,5,opt,name=CgroupPath,proto3" json:"CgroupPath,omitempty"`
-	VSockPath       string `protobuf:"bytes,6,opt,name=VSockPath,proto3" json:"VSockPath,omitempty"`
+	// Path of the guest memory file written by CreateSnapshot
+	MemFilePath string `protobuf:"bytes,1,opt,name=MemFilePath,proto3" json:"MemFilePath,omitempty"`
+	// Path of the VM state file written by CreateSnapshot
+	SnapshotPath string `protobuf:"bytes,2,opt,name=SnapshotPath,proto3" json:"SnapshotPath,omitempty"`
+	// Enables dirty page tracking on the restored VM so diff snapshots can be taken from it
+	EnableDiffSnapshots bool `protobuf:"varint,3,opt,name=EnableDiffSnapshots,proto3" json:"EnableDiffSnapshots,omitempty"`
 }
 
-func (x *GetVMInfoResponse) Reset() {
-	*x = GetVMInfoResponse{}
+func (x *LoadSnapshotConfig) Reset() {
+	*x = LoadSnapshotConfig{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_firecracker_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -528,13 +812,13 @@ func (x *GetVMInfoResponse) Reset() {
 	}
 }
 
-func (x *GetVMInfoResponse) String() string {
+func (x *LoadSnapshotConfig) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetVMInfoResponse) ProtoMessage() {}
+func (*LoadSnapshotConfig) ProtoMessage() {}
 
-func (x *GetVMInfoResponse) ProtoReflect() protoreflect.Message {
+func (x *LoadSnapshotConfig) ProtoReflect() protoreflect.Message {
 	mi := &file_firecracker_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -546,64 +830,45 @@ func (x *GetVMInfoResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetVMInfoResponse.ProtoReflect.Descriptor instead.
-func (*GetVMInfoResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use LoadSnapshotConfig.ProtoReflect.Descriptor instead.
+func (*LoadSnapshotConfig) Descriptor() ([]byte, []int) {
 	return file_firecracker_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *GetVMInfoResponse) GetVMID() string {
-	if x != nil {
-		return x.VMID
-	}
-	return ""
-}
-
-func (x *GetVMInfoResponse) GetSocketPath() string {
-	if x != nil {
-		return x.SocketPath
-	}
-	return ""
-}
-
-func (x *GetVMInfoResponse) GetLogFifoPath() string {
-	if x != nil {
-		return x.LogFifoPath
-	}
-	return ""
-}
-
-func (x *GetVMInfoResponse) GetMetricsFifoPath() string {
+func (x *LoadSnapshotConfig) GetMemFilePath() string {
 	if x != nil {
-		return x.MetricsFifoPath
+		return x.MemFilePath
 	}
 	return ""
 }
 
-func (x *GetVMInfoResponse) GetCgroupPath() string {
+func (x *LoadSnapshotConfig) GetSnapshotPath() string {
 	if x != nil {
-		return x.CgroupPath
+		return x.SnapshotPath
 	}
 	return ""
 }
 
-func (x *GetVMInfoResponse) GetVSockPath() string {
+func (x *LoadSnapshotConfig) GetEnableDiffSnapshots() bool {
 	if x != nil {
-		return x.VSockPath
+		return x.EnableDiffSnapshots
 	}
-	return ""
+	return false
 }
 
-type SetVMMetadataRequest struct {
+type AttachDriveMountRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	VMID     string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
-	Metadata string `protobuf:"bytes,2,opt,name=Metadata,proto3" json:"Metadata,omitempty"`
+	VMID string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+	// The drive mount to attach. The rate limiter is applied when the drive is attached.
+	// IsWritable only affects the mount inside the VM as spare drives are always read-write.
+	DriveMount *FirecrackerDriveMount `protobuf:"bytes,2,opt,name=DriveMount,proto3" json:"DriveMount,omitempty"`
 }
 
-func (x *SetVMMetadataRequest) Reset() {
-	*x = SetVMMetadataRequest{}
+func (x *AttachDriveMountRequest) Reset() {
+	*x = AttachDriveMountRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_firecracker_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -611,13 +876,13 @@ func (x *SetVMMetadataRequest) Reset() {
 	}
 }
 
-func (x *SetVMMetadataRequest) String() string {
+func (x *AttachDriveMountRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetVMMetadataRequest) ProtoMessage() {}
+func (*AttachDriveMountRequest) ProtoMessage() {}
 
-func (x *SetVMMetadataRequest) ProtoReflect() protoreflect.Message {
+func (x *AttachDriveMountRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_firecracker_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -629,36 +894,37 @@ func (x *SetVMMetadataRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetVMMetadataRequest.ProtoReflect.Descriptor instead.
-func (*SetVMMetadataRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use AttachDriveMountRequest.ProtoReflect.Descriptor instead.
+func (*AttachDriveMountRequest) Descriptor() ([]byte, []int) {
 	return file_firecracker_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *SetVMMetadataRequest) GetVMID() string {
+func (x *AttachDriveMountRequest) GetVMID() string {
 	if x != nil {
 		return x.VMID
 	}
 	return ""
 }
 
-func (x *SetVMMetadataRequest) GetMetadata() string {
+func (x *AttachDriveMountRequest) GetDriveMount() *FirecrackerDriveMount {
 	if x != nil {
-		return x.Metadata
+		return x.DriveMount
 	}
-	return ""
+	return nil
 }
 
-type UpdateVMMetadataRequest struct {
+type DetachDriveMountRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	VMID     string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
-	Metadata string `protobuf:"bytes,2,opt,name=Metadata,proto3" json:"Metadata,omitempty"`
+	VMID string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+	// The path inside the VM at which the drive mount was attached
+	VMPath string `protobuf:"bytes,2,opt,name=VMPath,proto3" json:"VMPath,omitempty"`
 }
 
-func (x *UpdateVMMetadataRequest) Reset() {
-	*x = UpdateVMMetadataRequest{}
+func (x *DetachDriveMountRequest) Reset() {
+	*x = DetachDriveMountRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_firecracker_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -666,13 +932,13 @@ func (x *UpdateVMMetadataRequest) Reset() {
 	}
 }
 
-func (x *UpdateVMMetadataRequest) String() string {
+func (x *DetachDriveMountRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateVMMetadataRequest) ProtoMessage() {}
+func (*DetachDriveMountRequest) ProtoMessage() {}
 
-func (x *UpdateVMMetadataRequest) ProtoReflect() protoreflect.Message {
+func (x *DetachDriveMountRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_firecracker_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -684,35 +950,42 @@ func (x *UpdateVMMetadataRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateVMMetadataRequest.ProtoReflect.Descriptor instead.
-func (*UpdateVMMetadataRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DetachDriveMountRequest.ProtoReflect.Descriptor instead.
+func (*DetachDriveMountRequest) Descriptor() ([]byte, []int) {
 	return file_firecracker_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *UpdateVMMetadataRequest) GetVMID() string {
+func (x *DetachDriveMountRequest) GetVMID() string {
 	if x != nil {
 		return x.VMID
 	}
 	return ""
 }
 
-func (x *UpdateVMMetadataRequest) GetMetadata() string {
+func (x *DetachDriveMountRequest) GetVMPath() string {
 	if x != nil {
-		return x.Metadata
+		return x.VMPath
 	}
 	return ""
 }
 
-type GetVMMetadataRequest struct {
+type UpdateDriveRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	VMID string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+	// The drive to update, identified either by the path it is mounted at inside the VM or by
+	// its Firecracker drive ID. Exactly one of them must be specified.
+	VMPath  string `protobuf:"bytes,2,opt,name=VMPath,proto3" json:"VMPath,omitempty"`
+	DriveID string `protobuf:"bytes,3,opt,name=DriveID,proto3" json:"DriveID,omitempty"`
+	// Rate limiter replacing the one of the drive. Unset token buckets are disabled, so an
+	// unset rate limiter removes the limit.
+	RateLimiter *FirecrackerRateLimiter `protobuf:"bytes,4,opt,name=RateLimiter,proto3" json:"RateLimiter,omitempty"`
 }
 
-func (x *GetVMMetadataRequest) Reset() {
-	*x = GetVMMetadataRequest{}
+func (x *UpdateDriveRequest) Reset() {
+	*x = UpdateDriveRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_firecracker_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -720,13 +993,13 @@ func (x *GetVMMetadataRequest) Reset() {
 	}
 }
 
-func (x *GetVMMetadataRequest) String() string {
+func (x *UpdateDriveRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetVMMetadataRequest) ProtoMessage() {}
+func (*UpdateDriveRequest) ProtoMessage() {}
 
-func (x *GetVMMetadataRequest) ProtoReflect() protoreflect.Message {
+func (x *UpdateDriveRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_firecracker_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -738,28 +1011,49 @@ func (x *GetVMMetadataRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetVMMetadataRequest.ProtoReflect.Descriptor instead.
-func (*GetVMMetadataRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateDriveRequest.ProtoReflect.Descriptor instead.
+func (*UpdateDriveRequest) Descriptor() ([]byte, []int) {
 	return file_firecracker_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *GetVMMetadataRequest) GetVMID() string {
+func (x *UpdateDriveRequest) GetVMID() string {
 	if x != nil {
 		return x.VMID
 	}
 	return ""
 }
 
-type GetVMMetadataResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *UpdateDriveRequest) GetVMPath() string {
+	if x != nil {
+		return x.VMPath
+	}
+	return ""
+}
 
-	Metadata string `protobuf:"bytes,1,opt,name=Metadata,proto3" json:"Metadata,omitempty"`
+func (x *UpdateDriveRequest) GetDriveID() string {
+	if x != nil {
+		return x.DriveID
+	}
+	return ""
 }
 
-func (x *GetVMMetadataResponse) Reset() {
-	*x = GetVMMetadataResponse{}
+func (x *UpdateDriveRequest) GetRateLimiter() *FirecrackerRateLimiter {
+	if x != nil {
+		return x.RateLimiter
+	}
+	return nil
+}
+
+type ListDrivesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VMID string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+}
+
+func (x *ListDrivesRequest) Reset() {
+	*x = ListDrivesRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_firecracker_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -767,13 +1061,13 @@ func (x *GetVMMetadataResponse) Reset() {
 	}
 }
 
-func (x *GetVMMetadataResponse) String() string {
+func (x *ListDrivesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetVMMetadataResponse) ProtoMessage() {}
+func (*ListDrivesRequest) ProtoMessage() {}
 
-func (x *GetVMMetadataResponse) ProtoReflect() protoreflect.Message {
+func (x *ListDrivesRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_firecracker_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -785,57 +1079,35 @@ func (x *GetVMMetadataResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetVMMetadataResponse.ProtoReflect.Descriptor instead.
-func (*GetVMMetadataResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListDrivesRequest.ProtoReflect.Descriptor instead.
+func (*ListDrivesRequest) Descriptor() ([]byte, []int) {
 	return file_firecracker_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *GetVMMetadataResponse) GetMetadata() string {
+func (x *ListDrivesRequest) GetVMID() string {
 	if x != nil {
-		return x.Metadata
+		return x.VMID
 	}
 	return ""
 }
 
-type JailerConfig struct {
+type FirecrackerDriveInfo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	NetNS string `protobuf:"bytes,1,opt,name=NetNS,proto3" json:"NetNS,omitempty"`
-	// List of the physical numbers of the CPUs on which processes in that
-	// cpuset are allowed to execute.  See List Format below for a description
-	// of the format of cpus.
-	//
-	// The CPUs allowed to a cpuset may be changed by writing a new list to its
-	// cpus file.
-	// Taken from http://man7.org/linux/man-pages/man7/cpuset.7.html
-	//
-	// This is formatted as specified in the cpuset man page under "List Format"
-	// http://man7.org/linux/man-pages/man7/cpuset.7.html
-	CPUs string `protobuf:"bytes,2,opt,name=CPUs,proto3" json:"CPUs,omitempty"`
-	// List of memory nodes on which processes in this cpuset are allowed to
-	// allocate memory.  See List Format below for a description of the format
-	// of mems.
-	// Taken from http://man7.org/linux/man-pages/man7/cpuset.7.html
-	//
-	// This is formatted as specified in the cpuset man page under "List Format"
-	// http://man7.org/linux/man-pages/man7/cpuset.7.html
-	Mems string `protobuf:"bytes,3,opt,name=Mems,proto3" json:"Mems,omitempty"`
-	UID  uint32 `protobuf:"varint,4,opt,name=UID,proto3" json:"UID,omitempty"`
-	GID  uint32 `protobuf:"varint,5,opt,name=GID,proto3" json:"GID,omitempty"`
-	// CgroupPath is used to dictate where the cgroup should be located
-	// relative to the cgroup directory which is
-	// /sys/fs/cgroup/cpu/<CgroupPath>/<vmID>
-	// if no value was provided, then /firecracker-containerd will be used as
-	// the default value
-	CgroupPath string `protobuf:"bytes,6,opt,name=CgroupPath,proto3" json:"CgroupPath,omitempty"`
-	// DriveExposePolicy is used to configure the method to expose drive files.
-	DriveExposePolicy DriveExposePolicy `protobuf:"varint,7,opt,name=DriveExposePolicy,proto3,enum=DriveExposePolicy" json:"DriveExposePolicy,omitempty"`
+	DriveID string `protobuf:"bytes,1,opt,name=DriveID,proto3" json:"DriveID,omitempty"`
+	// The file backing the drive on the host, which is the stub file of unused stub drives
+	HostPath string `protobuf:"bytes,2,opt,name=HostPath,proto3" json:"HostPath,omitempty"`
+	// The path the drive is mounted at inside the VM, empty if it is not mounted
+	VMPath       string                  `protobuf:"bytes,3,opt,name=VMPath,proto3" json:"VMPath,omitempty"`
+	RateLimiter  *FirecrackerRateLimiter `protobuf:"bytes,4,opt,name=RateLimiter,proto3" json:"RateLimiter,omitempty"`
+	IsRootDevice bool                    `protobuf:"varint,5,opt,name=IsRootDevice,proto3" json:"IsRootDevice,omitempty"`
+	IsWritable   bool                    `protobuf:"varint,6,opt,name=IsWritable,proto3" json:"IsWritable,omitempty"`
 }
 
-func (x *JailerConfig) Reset() {
-	*x = JailerConfig{}
+func (x *FirecrackerDriveInfo) Reset() {
+	*x = FirecrackerDriveInfo{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_firecracker_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -843,13 +1115,13 @@ func (x *JailerConfig) Reset() {
 	}
 }
 
-func (x *JailerConfig) String() string {
+func (x *FirecrackerDriveInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*JailerConfig) ProtoMessage() {}
+func (*FirecrackerDriveInfo) ProtoMessage() {}
 
-func (x *JailerConfig) ProtoReflect() protoreflect.Message {
+func (x *FirecrackerDriveInfo) ProtoReflect() protoreflect.Message {
 	mi := &file_firecracker_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -861,71 +1133,63 @@ func (x *JailerConfig) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use JailerConfig.ProtoReflect.Descriptor instead.
-func (*JailerConfig) Descriptor() ([]byte, []int) {
+// Deprecated: Use FirecrackerDriveInfo.ProtoReflect.Descriptor instead.
+func (*FirecrackerDriveInfo) Descriptor() ([]byte, []int) {
 	return file_firecracker_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *JailerConfig) GetNetNS() string {
+func (x *FirecrackerDriveInfo) GetDriveID() string {
 	if x != nil {
-		return x.NetNS
+		return x.DriveID
 	}
 	return ""
 }
 
-func (x *JailerConfig) GetCPUs() string {
+func (x *FirecrackerDriveInfo) GetHostPath() string {
 	if x != nil {
-		return x.CPUs
+		return x.HostPath
 	}
 	return ""
 }
 
-func (x *JailerConfig) GetMems() string {
+func (x *FirecrackerDriveInfo) GetVMPath() string {
 	if x != nil {
-		return x.Mems
+		return x.VMPath
 	}
 	return ""
 }
 
-func (x *JailerConfig) GetUID() uint32 {
-	if x != nil {
-		return x.UID
-	}
-	return 0
-}
-
-func (x *JailerConfig) GetGID() uint32 {
+func (x *FirecrackerDriveInfo) GetRateLimiter() *FirecrackerRateLimiter {
 	if x != nil {
-		return x.GID
+		return x.RateLimiter
 	}
-	return 0
+	return nil
 }
 
-func (x *JailerConfig) GetCgroupPath() string {
+func (x *FirecrackerDriveInfo) GetIsRootDevice() bool {
 	if x != nil {
-		return x.CgroupPath
+		return x.IsRootDevice
 	}
-	return ""
+	return false
 }
 
-func (x *JailerConfig) GetDriveExposePolicy() DriveExposePolicy {
+func (x *FirecrackerDriveInfo) GetIsWritable() bool {
 	if x != nil {
-		return x.DriveExposePolicy
+		return x.IsWritable
 	}
-	return DriveExposePolicy_COPY
+	return false
 }
 
-type UpdateBalloonRequest struct {
+type ListDrivesResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	VMID      string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
-	AmountMib int64  `protobuf:"varint,2,opt,name=AmountMib,proto3" json:"AmountMib,omitempty"`
+	Drives []*FirecrackerDriveInfo `protobuf:"bytes,1,rep,name=Drives,proto3" json:"Drives,omitempty"`
 }
 
-func (x *UpdateBalloonRequest) Reset() {
-	*x = UpdateBalloonRequest{}
+func (x *ListDrivesResponse) Reset() {
+	*x = ListDrivesResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_firecracker_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -933,13 +1197,13 @@ func (x *UpdateBalloonRequest) Reset() {
 	}
 }
 
-func (x *UpdateBalloonRequest) String() string {
+func (x *ListDrivesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateBalloonRequest) ProtoMessage() {}
+func (*ListDrivesResponse) ProtoMessage() {}
 
-func (x *UpdateBalloonRequest) ProtoReflect() protoreflect.Message {
+func (x *ListDrivesResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_firecracker_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -951,26 +1215,19 @@ func (x *UpdateBalloonRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateBalloonRequest.ProtoReflect.Descriptor instead.
-func (*UpdateBalloonRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListDrivesResponse.ProtoReflect.Descriptor instead.
+func (*ListDrivesResponse) Descriptor() ([]byte, []int) {
 	return file_firecracker_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *UpdateBalloonRequest) GetVMID() string {
-	if x != nil {
-		return x.VMID
-	}
-	return ""
-}
-
-func (x *UpdateBalloonRequest) GetAmountMib() int64 {
+func (x *ListDrivesResponse) GetDrives() []*FirecrackerDriveInfo {
 	if x != nil {
-		return x.AmountMib
+		return x.Drives
 	}
-	return 0
+	return nil
 }
 
-type GetBalloonConfigRequest struct {
+type GetVMInfoRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -978,8 +1235,8 @@ type GetBalloonConfigRequest struct {
 	VMID string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
 }
 
-func (x *GetBalloonConfigRequest) Reset() {
-	*x = GetBalloonConfigRequest{}
+func (x *GetVMInfoRequest) Reset() {
+	*x = GetVMInfoRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_firecracker_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -987,13 +1244,13 @@ func (x *GetBalloonConfigRequest) Reset() {
 	}
 }
 
-func (x *GetBalloonConfigRequest) String() string {
+func (x *GetVMInfoRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetBalloonConfigRequest) ProtoMessage() {}
+func (*GetVMInfoRequest) ProtoMessage() {}
 
-func (x *GetBalloonConfigRequest) ProtoReflect() protoreflect.Message {
+func (x *GetVMInfoRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_firecracker_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1005,28 +1262,39 @@ func (x *GetBalloonConfigRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetBalloonConfigRequest.ProtoReflect.Descriptor instead.
-func (*GetBalloonConfigRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetVMInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetVMInfoRequest) Descriptor() ([]byte, []int) {
 	return file_firecracker_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *GetBalloonConfigRequest) GetVMID() string {
+func (x *GetVMInfoRequest) GetVMID() string {
 	if x != nil {
 		return x.VMID
 	}
 	return ""
 }
 
-type GetBalloonConfigResponse struct {
+type GetVMInfoResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	BalloonConfig *FirecrackerBalloonDevice `protobuf:"bytes,1,opt,name=BalloonConfig,proto3" json:"BalloonConfig,omitempty"`
+	VMID            string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+	SocketPath      string `protobuf:"bytes,2,opt,name=SocketPath,proto3" json:"SocketPath,omitempty"`
+	LogFifoPath     string `protobuf:"bytes,3,opt,name=LogFifoPath,proto3" json:"LogFifoPath,omitempty"`
+	MetricsFifoPath string `protobuf:"bytes,4,opt,name=MetricsFifoPath,proto3" json:"MetricsFifoPath,omitempty"`
+	CgroupPath      string `protobuf:"bytes,5,opt,name=CgroupPath,proto3" json:"CgroupPath,omitempty"`
+	VSockPath       string `protobuf:"bytes,6,opt,name=VSockPath,proto3" json:"VSockPath,omitempty"`
+	// The process ID of the runtime shim managing the VM
+	ShimPID uint32 `protobuf:"varint,7,opt,name=ShimPID,proto3" json:"ShimPID,omitempty"`
+	// The current lifecycle state of the VM
+	State VMState `protobuf:"varint,8,opt,name=State,proto3,enum=VMState" json:"State,omitempty"`
+	// The time at which the runtime shim started creating the VM
+	CreatedAt *timestamp.Timestamp `protobuf:"bytes,9,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
 }
 
-func (x *GetBalloonConfigResponse) Reset() {
-	*x = GetBalloonConfigResponse{}
+func (x *GetVMInfoResponse) Reset() {
+	*x = GetVMInfoResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_firecracker_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1034,13 +1302,13 @@ func (x *GetBalloonConfigResponse) Reset() {
 	}
 }
 
-func (x *GetBalloonConfigResponse) String() string {
+func (x *GetVMInfoResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetBalloonConfigResponse) ProtoMessage() {}
+func (*GetVMInfoResponse) ProtoMessage() {}
 
-func (x *GetBalloonConfigResponse) ProtoReflect() protoreflect.Message {
+func (x *GetVMInfoResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_firecracker_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1052,28 +1320,82 @@ func (x *GetBalloonConfigResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetBalloonConfigResponse.ProtoReflect.Descriptor instead.
-func (*GetBalloonConfigResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetVMInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetVMInfoResponse) Descriptor() ([]byte, []int) {
 	return file_firecracker_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *GetBalloonConfigResponse) GetBalloonConfig() *FirecrackerBalloonDevice {
+func (x *GetVMInfoResponse) GetVMID() string {
 	if x != nil {
-		return x.BalloonConfig
+		return x.VMID
+	}
+	return ""
+}
+
+func (x *GetVMInfoResponse) GetSocketPath() string {
+	if x != nil {
+		return x.SocketPath
+	}
+	return ""
+}
+
+func (x *GetVMInfoResponse) GetLogFifoPath() string {
+	if x != nil {
+		return x.LogFifoPath
+	}
+	return ""
+}
+
+func (x *GetVMInfoResponse) GetMetricsFifoPath() string {
+	if x != nil {
+		return x.MetricsFifoPath
+	}
+	return ""
+}
+
+func (x *GetVMInfoResponse) GetCgroupPath() string {
+	if x != nil {
+		return x.CgroupPath
+	}
+	return ""
+}
+
+func (x *GetVMInfoResponse) GetVSockPath() string {
+	if x != nil {
+		return x.VSockPath
+	}
+	return ""
+}
+
+func (x *GetVMInfoResponse) GetShimPID() uint32 {
+	if x != nil {
+		return x.ShimPID
+	}
+	return 0
+}
+
+func (x *GetVMInfoResponse) GetState() VMState {
+	if x != nil {
+		return x.State
+	}
+	return VMState_UNKNOWN
+}
+
+func (x *GetVMInfoResponse) GetCreatedAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.CreatedAt
 	}
 	return nil
 }
 
-type GetBalloonStatsRequest struct {
+type ListVMsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	VMID string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
 }
 
-func (x *GetBalloonStatsRequest) Reset() {
-	*x = GetBalloonStatsRequest{}
+func (x *ListVMsRequest) Reset() {
+	*x = ListVMsRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_firecracker_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1081,13 +1403,13 @@ func (x *GetBalloonStatsRequest) Reset() {
 	}
 }
 
-func (x *GetBalloonStatsRequest) String() string {
+func (x *ListVMsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetBalloonStatsRequest) ProtoMessage() {}
+func (*ListVMsRequest) ProtoMessage() {}
 
-func (x *GetBalloonStatsRequest) ProtoReflect() protoreflect.Message {
+func (x *ListVMsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_firecracker_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1099,56 +1421,21 @@ func (x *GetBalloonStatsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetBalloonStatsRequest.ProtoReflect.Descriptor instead.
-func (*GetBalloonStatsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListVMsRequest.ProtoReflect.Descriptor instead.
+func (*ListVMsRequest) Descriptor() ([]byte, []int) {
 	return file_firecracker_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *GetBalloonStatsRequest) GetVMID() string {
-	if x != nil {
-		return x.VMID
-	}
-	return ""
-}
-
-type GetBalloonStatsResponse struct {
+type ListVMsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Actual amount of memory (in MiB) the device is holding.
-	ActualMib int64 `protobuf:"varint,1,opt,name=ActualMib,proto3" json:"ActualMib,omitempty"`
-	// Actual number of pages the device is holding.
-	ActualPages int64 `protobuf:"varint,2,opt,name=ActualPages,proto3" json:"ActualPages,omitempty"`
-	// An estimate of how much memory is available (in bytes) for starting new applications, without pushing the system to swap.
-	AvailableMemory int64 `protobuf:"varint,3,opt,name=AvailableMemory,proto3" json:"AvailableMemory,omitempty"`
-	// The amount of memory, in bytes, that can be quickly reclaimed without additional I/O.
-	// Typically these pages are used for caching files from disk.
-	DiskCaches int64 `protobuf:"varint,4,opt,name=DiskCaches,proto3" json:"DiskCaches,omitempty"`
-	// The amount of memory not being used for any purpose (in bytes).
-	FreeMemory int64 `protobuf:"varint,5,opt,name=FreeMemory,proto3" json:"FreeMemory,omitempty"`
-	// The number of successful hugetlb page allocations in the guest.
-	HugetlbAllocations int64 `protobuf:"varint,6,opt,name=HugetlbAllocations,proto3" json:"HugetlbAllocations,omitempty"`
-	// The number of failed hugetlb page allocations in the guest.
-	HugetlbFailures int64 `protobuf:"varint,7,opt,name=HugetlbFailures,proto3" json:"HugetlbFailures,omitempty"`
-	// The number of major page faults that have occurred.
-	MajorFaults int64 `protobuf:"varint,8,opt,name=MajorFaults,proto3" json:"MajorFaults,omitempty"`
-	// The number of minor page faults that have occurred.
-	MinorFaults int64 `protobuf:"varint,9,opt,name=MinorFaults,proto3" json:"MinorFaults,omitempty"`
-	// The amount of memory that has been swapped in (in bytes).
-	SwapIn int64 `protobuf:"varint,10,opt,name=SwapIn,proto3" json:"SwapIn,omitempty"`
-	// The amount of memory that has been swapped out to disk (in bytes).
-	SwapOut int64 `protobuf:"varint,11,opt,name=SwapOut,proto3" json:"SwapOut,omitempty"`
-	// Target amount of memory (in MiB) the device aims to hold.
-	TargetMib int64 `protobuf:"varint,12,opt,name=TargetMib,proto3" json:"TargetMib,omitempty"`
-	// Target number of pages the device aims to hold.
-	TargetPages int64 `protobuf:"varint,13,opt,name=TargetPages,proto3" json:"TargetPages,omitempty"`
-	// The total amount of memory available (in bytes).
-	TotalMemory int64 `protobuf:"varint,14,opt,name=TotalMemory,proto3" json:"TotalMemory,omitempty"`
+	VMs []*GetVMInfoResponse `protobuf:"bytes,1,rep,name=VMs,proto3" json:"VMs,omitempty"`
 }
 
-func (x *GetBalloonStatsResponse) Reset() {
-	*x = GetBalloonStatsResponse{}
+func (x *ListVMsResponse) Reset() {
+	*x = ListVMsResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_firecracker_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1156,13 +1443,13 @@ func (x *GetBalloonStatsResponse) Reset() {
 	}
 }
 
-func (x *GetBalloonStatsResponse) String() string {
+func (x *ListVMsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetBalloonStatsResponse) ProtoMessage() {}
+func (*ListVMsResponse) ProtoMessage() {}
 
-func (x *GetBalloonStatsResponse) ProtoReflect() protoreflect.Message {
+func (x *ListVMsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_firecracker_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1174,135 +1461,2327 @@ func (x *GetBalloonStatsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetBalloonStatsResponse.ProtoReflect.Descriptor instead.
-func (*GetBalloonStatsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListVMsResponse.ProtoReflect.Descriptor instead.
+func (*ListVMsResponse) Descriptor() ([]byte, []int) {
 	return file_firecracker_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *GetBalloonStatsResponse) GetActualMib() int64 {
+func (x *ListVMsResponse) GetVMs() []*GetVMInfoResponse {
 	if x != nil {
-		return x.ActualMib
+		return x.VMs
+	}
+	return nil
+}
+
+type GetVMMetricsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VMID string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+}
+
+func (x *GetVMMetricsRequest) Reset() {
+	*x = GetVMMetricsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetVMMetricsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVMMetricsRequest) ProtoMessage() {}
+
+func (x *GetVMMetricsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVMMetricsRequest.ProtoReflect.Descriptor instead.
+func (*GetVMMetricsRequest) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetVMMetricsRequest) GetVMID() string {
+	if x != nil {
+		return x.VMID
+	}
+	return ""
+}
+
+// GetVMMetricsResponse holds the metrics Firecracker reported for a VM. Counters are totals
+// since the VM started, while latencies are the last measured ones in microseconds.
+type GetVMMetricsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The time at which Firecracker last flushed its metrics, unset if it did not yet
+	FlushedAt *timestamp.Timestamp    `protobuf:"bytes,1,opt,name=FlushedAt,proto3" json:"FlushedAt,omitempty"`
+	Vcpu      *FirecrackerVcpuMetrics `protobuf:"bytes,2,opt,name=Vcpu,proto3" json:"Vcpu,omitempty"`
+	// Aggregated metrics of all the drives, followed by the metrics of each drive by drive ID
+	Block  *FirecrackerBlockMetrics            `protobuf:"bytes,3,opt,name=Block,proto3" json:"Block,omitempty"`
+	Drives map[string]*FirecrackerBlockMetrics `protobuf:"bytes,4,rep,name=Drives,proto3" json:"Drives,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Aggregated metrics of all the network interfaces, followed by the metrics of each
+	// interface by its index in the NetworkInterfaces the VM was created with
+	Net               *FirecrackerNetMetrics            `protobuf:"bytes,5,opt,name=Net,proto3" json:"Net,omitempty"`
+	NetworkInterfaces map[uint32]*FirecrackerNetMetrics `protobuf:"bytes,6,rep,name=NetworkInterfaces,proto3" json:"NetworkInterfaces,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Vsock             *FirecrackerVsockMetrics          `protobuf:"bytes,7,opt,name=Vsock,proto3" json:"Vsock,omitempty"`
+	Mmds              *FirecrackerMmdsMetrics           `protobuf:"bytes,8,opt,name=Mmds,proto3" json:"Mmds,omitempty"`
+	// The number of syscalls denied by the seccomp filters
+	SeccompFaults uint64                     `protobuf:"varint,9,opt,name=SeccompFaults,proto3" json:"SeccompFaults,omitempty"`
+	Latencies     *FirecrackerLatencyMetrics `protobuf:"bytes,10,opt,name=Latencies,proto3" json:"Latencies,omitempty"`
+	// The number of container creations and drive mount attachments which failed because the VM
+	// had no stub drive left
+	StubDrivesExhausted uint64 `protobuf:"varint,11,opt,name=StubDrivesExhausted,proto3" json:"StubDrivesExhausted,omitempty"`
+}
+
+func (x *GetVMMetricsResponse) Reset() {
+	*x = GetVMMetricsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetVMMetricsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVMMetricsResponse) ProtoMessage() {}
+
+func (x *GetVMMetricsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVMMetricsResponse.ProtoReflect.Descriptor instead.
+func (*GetVMMetricsResponse) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetVMMetricsResponse) GetFlushedAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.FlushedAt
+	}
+	return nil
+}
+
+func (x *GetVMMetricsResponse) GetVcpu() *FirecrackerVcpuMetrics {
+	if x != nil {
+		return x.Vcpu
+	}
+	return nil
+}
+
+func (x *GetVMMetricsResponse) GetBlock() *FirecrackerBlockMetrics {
+	if x != nil {
+		return x.Block
+	}
+	return nil
+}
+
+func (x *GetVMMetricsResponse) GetDrives() map[string]*FirecrackerBlockMetrics {
+	if x != nil {
+		return x.Drives
+	}
+	return nil
+}
+
+func (x *GetVMMetricsResponse) GetNet() *FirecrackerNetMetrics {
+	if x != nil {
+		return x.Net
+	}
+	return nil
+}
+
+func (x *GetVMMetricsResponse) GetNetworkInterfaces() map[uint32]*FirecrackerNetMetrics {
+	if x != nil {
+		return x.NetworkInterfaces
+	}
+	return nil
+}
+
+func (x *GetVMMetricsResponse) GetVsock() *FirecrackerVsockMetrics {
+	if x != nil {
+		return x.Vsock
+	}
+	return nil
+}
+
+func (x *GetVMMetricsResponse) GetMmds() *FirecrackerMmdsMetrics {
+	if x != nil {
+		return x.Mmds
+	}
+	return nil
+}
+
+func (x *GetVMMetricsResponse) GetSeccompFaults() uint64 {
+	if x != nil {
+		return x.SeccompFaults
+	}
+	return 0
+}
+
+func (x *GetVMMetricsResponse) GetLatencies() *FirecrackerLatencyMetrics {
+	if x != nil {
+		return x.Latencies
+	}
+	return nil
+}
+
+func (x *GetVMMetricsResponse) GetStubDrivesExhausted() uint64 {
+	if x != nil {
+		return x.StubDrivesExhausted
+	}
+	return 0
+}
+
+type FirecrackerVcpuMetrics struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ExitIOIn      uint64 `protobuf:"varint,1,opt,name=ExitIOIn,proto3" json:"ExitIOIn,omitempty"`
+	ExitIOOut     uint64 `protobuf:"varint,2,opt,name=ExitIOOut,proto3" json:"ExitIOOut,omitempty"`
+	ExitMMIORead  uint64 `protobuf:"varint,3,opt,name=ExitMMIORead,proto3" json:"ExitMMIORead,omitempty"`
+	ExitMMIOWrite uint64 `protobuf:"varint,4,opt,name=ExitMMIOWrite,proto3" json:"ExitMMIOWrite,omitempty"`
+	Failures      uint64 `protobuf:"varint,5,opt,name=Failures,proto3" json:"Failures,omitempty"`
+}
+
+func (x *FirecrackerVcpuMetrics) Reset() {
+	*x = FirecrackerVcpuMetrics{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FirecrackerVcpuMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FirecrackerVcpuMetrics) ProtoMessage() {}
+
+func (x *FirecrackerVcpuMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FirecrackerVcpuMetrics.ProtoReflect.Descriptor instead.
+func (*FirecrackerVcpuMetrics) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *FirecrackerVcpuMetrics) GetExitIOIn() uint64 {
+	if x != nil {
+		return x.ExitIOIn
+	}
+	return 0
+}
+
+func (x *FirecrackerVcpuMetrics) GetExitIOOut() uint64 {
+	if x != nil {
+		return x.ExitIOOut
+	}
+	return 0
+}
+
+func (x *FirecrackerVcpuMetrics) GetExitMMIORead() uint64 {
+	if x != nil {
+		return x.ExitMMIORead
+	}
+	return 0
+}
+
+func (x *FirecrackerVcpuMetrics) GetExitMMIOWrite() uint64 {
+	if x != nil {
+		return x.ExitMMIOWrite
+	}
+	return 0
+}
+
+func (x *FirecrackerVcpuMetrics) GetFailures() uint64 {
+	if x != nil {
+		return x.Failures
+	}
+	return 0
+}
+
+type FirecrackerBlockMetrics struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReadBytes                  uint64 `protobuf:"varint,1,opt,name=ReadBytes,proto3" json:"ReadBytes,omitempty"`
+	WriteBytes                 uint64 `protobuf:"varint,2,opt,name=WriteBytes,proto3" json:"WriteBytes,omitempty"`
+	ReadCount                  uint64 `protobuf:"varint,3,opt,name=ReadCount,proto3" json:"ReadCount,omitempty"`
+	WriteCount                 uint64 `protobuf:"varint,4,opt,name=WriteCount,proto3" json:"WriteCount,omitempty"`
+	FlushCount                 uint64 `protobuf:"varint,5,opt,name=FlushCount,proto3" json:"FlushCount,omitempty"`
+	RateLimiterThrottledEvents uint64 `protobuf:"varint,6,opt,name=RateLimiterThrottledEvents,proto3" json:"RateLimiterThrottledEvents,omitempty"`
+	ExecuteFails               uint64 `protobuf:"varint,7,opt,name=ExecuteFails,proto3" json:"ExecuteFails,omitempty"`
+	InvalidRequests            uint64 `protobuf:"varint,8,opt,name=InvalidRequests,proto3" json:"InvalidRequests,omitempty"`
+}
+
+func (x *FirecrackerBlockMetrics) Reset() {
+	*x = FirecrackerBlockMetrics{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FirecrackerBlockMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FirecrackerBlockMetrics) ProtoMessage() {}
+
+func (x *FirecrackerBlockMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FirecrackerBlockMetrics.ProtoReflect.Descriptor instead.
+func (*FirecrackerBlockMetrics) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *FirecrackerBlockMetrics) GetReadBytes() uint64 {
+	if x != nil {
+		return x.ReadBytes
+	}
+	return 0
+}
+
+func (x *FirecrackerBlockMetrics) GetWriteBytes() uint64 {
+	if x != nil {
+		return x.WriteBytes
+	}
+	return 0
+}
+
+func (x *FirecrackerBlockMetrics) GetReadCount() uint64 {
+	if x != nil {
+		return x.ReadCount
+	}
+	return 0
+}
+
+func (x *FirecrackerBlockMetrics) GetWriteCount() uint64 {
+	if x != nil {
+		return x.WriteCount
+	}
+	return 0
+}
+
+func (x *FirecrackerBlockMetrics) GetFlushCount() uint64 {
+	if x != nil {
+		return x.FlushCount
+	}
+	return 0
+}
+
+func (x *FirecrackerBlockMetrics) GetRateLimiterThrottledEvents() uint64 {
+	if x != nil {
+		return x.RateLimiterThrottledEvents
+	}
+	return 0
+}
+
+func (x *FirecrackerBlockMetrics) GetExecuteFails() uint64 {
+	if x != nil {
+		return x.ExecuteFails
+	}
+	return 0
+}
+
+func (x *FirecrackerBlockMetrics) GetInvalidRequests() uint64 {
+	if x != nil {
+		return x.InvalidRequests
+	}
+	return 0
+}
+
+type FirecrackerNetMetrics struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RxBytes                uint64 `protobuf:"varint,1,opt,name=RxBytes,proto3" json:"RxBytes,omitempty"`
+	RxPackets              uint64 `protobuf:"varint,2,opt,name=RxPackets,proto3" json:"RxPackets,omitempty"`
+	RxFails                uint64 `protobuf:"varint,3,opt,name=RxFails,proto3" json:"RxFails,omitempty"`
+	RxRateLimiterThrottled uint64 `protobuf:"varint,4,opt,name=RxRateLimiterThrottled,proto3" json:"RxRateLimiterThrottled,omitempty"`
+	TxBytes                uint64 `protobuf:"varint,5,opt,name=TxBytes,proto3" json:"TxBytes,omitempty"`
+	TxPackets              uint64 `protobuf:"varint,6,opt,name=TxPackets,proto3" json:"TxPackets,omitempty"`
+	TxFails                uint64 `protobuf:"varint,7,opt,name=TxFails,proto3" json:"TxFails,omitempty"`
+	TxRateLimiterThrottled uint64 `protobuf:"varint,8,opt,name=TxRateLimiterThrottled,proto3" json:"TxRateLimiterThrottled,omitempty"`
+}
+
+func (x *FirecrackerNetMetrics) Reset() {
+	*x = FirecrackerNetMetrics{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FirecrackerNetMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FirecrackerNetMetrics) ProtoMessage() {}
+
+func (x *FirecrackerNetMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FirecrackerNetMetrics.ProtoReflect.Descriptor instead.
+func (*FirecrackerNetMetrics) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *FirecrackerNetMetrics) GetRxBytes() uint64 {
+	if x != nil {
+		return x.RxBytes
+	}
+	return 0
+}
+
+func (x *FirecrackerNetMetrics) GetRxPackets() uint64 {
+	if x != nil {
+		return x.RxPackets
+	}
+	return 0
+}
+
+func (x *FirecrackerNetMetrics) GetRxFails() uint64 {
+	if x != nil {
+		return x.RxFails
+	}
+	return 0
+}
+
+func (x *FirecrackerNetMetrics) GetRxRateLimiterThrottled() uint64 {
+	if x != nil {
+		return x.RxRateLimiterThrottled
+	}
+	return 0
+}
+
+func (x *FirecrackerNetMetrics) GetTxBytes() uint64 {
+	if x != nil {
+		return x.TxBytes
+	}
+	return 0
+}
+
+func (x *FirecrackerNetMetrics) GetTxPackets() uint64 {
+	if x != nil {
+		return x.TxPackets
+	}
+	return 0
+}
+
+func (x *FirecrackerNetMetrics) GetTxFails() uint64 {
+	if x != nil {
+		return x.TxFails
+	}
+	return 0
+}
+
+func (x *FirecrackerNetMetrics) GetTxRateLimiterThrottled() uint64 {
+	if x != nil {
+		return x.TxRateLimiterThrottled
+	}
+	return 0
+}
+
+type FirecrackerVsockMetrics struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RxBytes      uint64 `protobuf:"varint,1,opt,name=RxBytes,proto3" json:"RxBytes,omitempty"`
+	RxPackets    uint64 `protobuf:"varint,2,opt,name=RxPackets,proto3" json:"RxPackets,omitempty"`
+	TxBytes      uint64 `protobuf:"varint,3,opt,name=TxBytes,proto3" json:"TxBytes,omitempty"`
+	TxPackets    uint64 `protobuf:"varint,4,opt,name=TxPackets,proto3" json:"TxPackets,omitempty"`
+	ConnsAdded   uint64 `protobuf:"varint,5,opt,name=ConnsAdded,proto3" json:"ConnsAdded,omitempty"`
+	ConnsKilled  uint64 `protobuf:"varint,6,opt,name=ConnsKilled,proto3" json:"ConnsKilled,omitempty"`
+	ConnsRemoved uint64 `protobuf:"varint,7,opt,name=ConnsRemoved,proto3" json:"ConnsRemoved,omitempty"`
+}
+
+func (x *FirecrackerVsockMetrics) Reset() {
+	*x = FirecrackerVsockMetrics{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FirecrackerVsockMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FirecrackerVsockMetrics) ProtoMessage() {}
+
+func (x *FirecrackerVsockMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FirecrackerVsockMetrics.ProtoReflect.Descriptor instead.
+func (*FirecrackerVsockMetrics) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *FirecrackerVsockMetrics) GetRxBytes() uint64 {
+	if x != nil {
+		return x.RxBytes
+	}
+	return 0
+}
+
+func (x *FirecrackerVsockMetrics) GetRxPackets() uint64 {
+	if x != nil {
+		return x.RxPackets
+	}
+	return 0
+}
+
+func (x *FirecrackerVsockMetrics) GetTxBytes() uint64 {
+	if x != nil {
+		return x.TxBytes
+	}
+	return 0
+}
+
+func (x *FirecrackerVsockMetrics) GetTxPackets() uint64 {
+	if x != nil {
+		return x.TxPackets
+	}
+	return 0
+}
+
+func (x *FirecrackerVsockMetrics) GetConnsAdded() uint64 {
+	if x != nil {
+		return x.ConnsAdded
+	}
+	return 0
+}
+
+func (x *FirecrackerVsockMetrics) GetConnsKilled() uint64 {
+	if x != nil {
+		return x.ConnsKilled
+	}
+	return 0
+}
+
+func (x *FirecrackerVsockMetrics) GetConnsRemoved() uint64 {
+	if x != nil {
+		return x.ConnsRemoved
+	}
+	return 0
+}
+
+type FirecrackerMmdsMetrics struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RxCount       uint64 `protobuf:"varint,1,opt,name=RxCount,proto3" json:"RxCount,omitempty"`
+	RxAccepted    uint64 `protobuf:"varint,2,opt,name=RxAccepted,proto3" json:"RxAccepted,omitempty"`
+	RxAcceptedErr uint64 `protobuf:"varint,3,opt,name=RxAcceptedErr,proto3" json:"RxAcceptedErr,omitempty"`
+	TxCount       uint64 `protobuf:"varint,4,opt,name=TxCount,proto3" json:"TxCount,omitempty"`
+	TxBytes       uint64 `protobuf:"varint,5,opt,name=TxBytes,proto3" json:"TxBytes,omitempty"`
+	TxErrors      uint64 `protobuf:"varint,6,opt,name=TxErrors,proto3" json:"TxErrors,omitempty"`
+}
+
+func (x *FirecrackerMmdsMetrics) Reset() {
+	*x = FirecrackerMmdsMetrics{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FirecrackerMmdsMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FirecrackerMmdsMetrics) ProtoMessage() {}
+
+func (x *FirecrackerMmdsMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FirecrackerMmdsMetrics.ProtoReflect.Descriptor instead.
+func (*FirecrackerMmdsMetrics) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *FirecrackerMmdsMetrics) GetRxCount() uint64 {
+	if x != nil {
+		return x.RxCount
+	}
+	return 0
+}
+
+func (x *FirecrackerMmdsMetrics) GetRxAccepted() uint64 {
+	if x != nil {
+		return x.RxAccepted
+	}
+	return 0
+}
+
+func (x *FirecrackerMmdsMetrics) GetRxAcceptedErr() uint64 {
+	if x != nil {
+		return x.RxAcceptedErr
+	}
+	return 0
+}
+
+func (x *FirecrackerMmdsMetrics) GetTxCount() uint64 {
+	if x != nil {
+		return x.TxCount
+	}
+	return 0
+}
+
+func (x *FirecrackerMmdsMetrics) GetTxBytes() uint64 {
+	if x != nil {
+		return x.TxBytes
+	}
+	return 0
+}
+
+func (x *FirecrackerMmdsMetrics) GetTxErrors() uint64 {
+	if x != nil {
+		return x.TxErrors
+	}
+	return 0
+}
+
+type FirecrackerLatencyMetrics struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FullCreateSnapshot uint64 `protobuf:"varint,1,opt,name=FullCreateSnapshot,proto3" json:"FullCreateSnapshot,omitempty"`
+	DiffCreateSnapshot uint64 `protobuf:"varint,2,opt,name=DiffCreateSnapshot,proto3" json:"DiffCreateSnapshot,omitempty"`
+	LoadSnapshot       uint64 `protobuf:"varint,3,opt,name=LoadSnapshot,proto3" json:"LoadSnapshot,omitempty"`
+	PauseVM            uint64 `protobuf:"varint,4,opt,name=PauseVM,proto3" json:"PauseVM,omitempty"`
+	ResumeVM           uint64 `protobuf:"varint,5,opt,name=ResumeVM,proto3" json:"ResumeVM,omitempty"`
+}
+
+func (x *FirecrackerLatencyMetrics) Reset() {
+	*x = FirecrackerLatencyMetrics{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FirecrackerLatencyMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FirecrackerLatencyMetrics) ProtoMessage() {}
+
+func (x *FirecrackerLatencyMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FirecrackerLatencyMetrics.ProtoReflect.Descriptor instead.
+func (*FirecrackerLatencyMetrics) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *FirecrackerLatencyMetrics) GetFullCreateSnapshot() uint64 {
+	if x != nil {
+		return x.FullCreateSnapshot
+	}
+	return 0
+}
+
+func (x *FirecrackerLatencyMetrics) GetDiffCreateSnapshot() uint64 {
+	if x != nil {
+		return x.DiffCreateSnapshot
+	}
+	return 0
+}
+
+func (x *FirecrackerLatencyMetrics) GetLoadSnapshot() uint64 {
+	if x != nil {
+		return x.LoadSnapshot
+	}
+	return 0
+}
+
+func (x *FirecrackerLatencyMetrics) GetPauseVM() uint64 {
+	if x != nil {
+		return x.PauseVM
+	}
+	return 0
+}
+
+func (x *FirecrackerLatencyMetrics) GetResumeVM() uint64 {
+	if x != nil {
+		return x.ResumeVM
+	}
+	return 0
+}
+
+type SetVMMetadataRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VMID     string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+	Metadata string `protobuf:"bytes,2,opt,name=Metadata,proto3" json:"Metadata,omitempty"`
+}
+
+func (x *SetVMMetadataRequest) Reset() {
+	*x = SetVMMetadataRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetVMMetadataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetVMMetadataRequest) ProtoMessage() {}
+
+func (x *SetVMMetadataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetVMMetadataRequest.ProtoReflect.Descriptor instead.
+func (*SetVMMetadataRequest) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SetVMMetadataRequest) GetVMID() string {
+	if x != nil {
+		return x.VMID
+	}
+	return ""
+}
+
+func (x *SetVMMetadataRequest) GetMetadata() string {
+	if x != nil {
+		return x.Metadata
+	}
+	return ""
+}
+
+type UpdateVMMetadataRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VMID     string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+	Metadata string `protobuf:"bytes,2,opt,name=Metadata,proto3" json:"Metadata,omitempty"`
+}
+
+func (x *UpdateVMMetadataRequest) Reset() {
+	*x = UpdateVMMetadataRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateVMMetadataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateVMMetadataRequest) ProtoMessage() {}
+
+func (x *UpdateVMMetadataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateVMMetadataRequest.ProtoReflect.Descriptor instead.
+func (*UpdateVMMetadataRequest) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *UpdateVMMetadataRequest) GetVMID() string {
+	if x != nil {
+		return x.VMID
+	}
+	return ""
+}
+
+func (x *UpdateVMMetadataRequest) GetMetadata() string {
+	if x != nil {
+		return x.Metadata
+	}
+	return ""
+}
+
+type GetVMMetadataRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VMID string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+}
+
+func (x *GetVMMetadataRequest) Reset() {
+	*x = GetVMMetadataRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetVMMetadataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVMMetadataRequest) ProtoMessage() {}
+
+func (x *GetVMMetadataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVMMetadataRequest.ProtoReflect.Descriptor instead.
+func (*GetVMMetadataRequest) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetVMMetadataRequest) GetVMID() string {
+	if x != nil {
+		return x.VMID
+	}
+	return ""
+}
+
+type GetVMMetadataResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Metadata string `protobuf:"bytes,1,opt,name=Metadata,proto3" json:"Metadata,omitempty"`
+}
+
+func (x *GetVMMetadataResponse) Reset() {
+	*x = GetVMMetadataResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetVMMetadataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVMMetadataResponse) ProtoMessage() {}
+
+func (x *GetVMMetadataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVMMetadataResponse.ProtoReflect.Descriptor instead.
+func (*GetVMMetadataResponse) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetVMMetadataResponse) GetMetadata() string {
+	if x != nil {
+		return x.Metadata
+	}
+	return ""
+}
+
+type JailerConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NetNS string `protobuf:"bytes,1,opt,name=NetNS,proto3" json:"NetNS,omitempty"`
+	// List of the physical numbers of the CPUs on which processes in that
+	// cpuset are allowed to execute.  See List Format below for a description
+	// of the format of cpus.
+	//
+	// The CPUs allowed to a cpuset may be changed by writing a new list to its
+	// cpus file.
+	// Taken from http://man7.org/linux/man-pages/man7/cpuset.7.html
+	//
+	// This is formatted as specified in the cpuset man page under "List Format"
+	// http://man7.org/linux/man-pages/man7/cpuset.7.html
+	CPUs string `protobuf:"bytes,2,opt,name=CPUs,proto3" json:"CPUs,omitempty"`
+	// List of memory nodes on which processes in this cpuset are allowed to
+	// allocate memory.  See List Format below for a description of the format
+	// of mems.
+	// Taken from http://man7.org/linux/man-pages/man7/cpuset.7.html
+	//
+	// This is formatted as specified in the cpuset man page under "List Format"
+	// http://man7.org/linux/man-pages/man7/cpuset.7.html
+	Mems string `protobuf:"bytes,3,opt,name=Mems,proto3" json:"Mems,omitempty"`
+	UID  uint32 `protobuf:"varint,4,opt,name=UID,proto3" json:"UID,omitempty"`
+	GID  uint32 `protobuf:"varint,5,opt,name=GID,proto3" json:"GID,omitempty"`
+	// CgroupPath is used to dictate where the cgroup should be located
+	// relative to the cgroup directory which is
+	// /sys/fs/cgroup/cpu/<CgroupPath>/<vmID>
+	// if no value was provided, then /firecracker-containerd will be used as
+	// the default value
+	CgroupPath string `protobuf:"bytes,6,opt,name=CgroupPath,proto3" json:"CgroupPath,omitempty"`
+	// DriveExposePolicy is used to configure the method to expose drive files.
+	DriveExposePolicy DriveExposePolicy `protobuf:"varint,7,opt,name=DriveExposePolicy,proto3,enum=DriveExposePolicy" json:"DriveExposePolicy,omitempty"`
+	// LimitMemory limits the memory of the cgroup of Firecracker to the memory of the VM plus
+	// MemoryOverheadMib, which the VMM itself needs. The memory of a VM loaded from a snapshot is
+	// the one it was snapshotted with. The memory is not limited if it is false.
+	LimitMemory bool `protobuf:"varint,8,opt,name=LimitMemory,proto3" json:"LimitMemory,omitempty"`
+	// MemoryOverheadMib is the memory Firecracker may use above the memory of the VM when
+	// LimitMemory is set, 64 MiB if it is 0.
+	MemoryOverheadMib uint32 `protobuf:"varint,9,opt,name=MemoryOverheadMib,proto3" json:"MemoryOverheadMib,omitempty"`
+	// IOLimits are the IO weight and bandwidth limits of the cgroup of Firecracker.
+	IOLimits *JailerIOLimits `protobuf:"bytes,10,opt,name=IOLimits,proto3" json:"IOLimits,omitempty"`
+	// PidsLimit is the maximum number of processes and threads in the cgroup of Firecracker.
+	// The number is not limited if it is 0.
+	PidsLimit uint32 `protobuf:"varint,11,opt,name=PidsLimit,proto3" json:"PidsLimit,omitempty"`
+}
+
+func (x *JailerConfig) Reset() {
+	*x = JailerConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JailerConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JailerConfig) ProtoMessage() {}
+
+func (x *JailerConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JailerConfig.ProtoReflect.Descriptor instead.
+func (*JailerConfig) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *JailerConfig) GetNetNS() string {
+	if x != nil {
+		return x.NetNS
+	}
+	return ""
+}
+
+func (x *JailerConfig) GetCPUs() string {
+	if x != nil {
+		return x.CPUs
+	}
+	return ""
+}
+
+func (x *JailerConfig) GetMems() string {
+	if x != nil {
+		return x.Mems
+	}
+	return ""
+}
+
+func (x *JailerConfig) GetUID() uint32 {
+	if x != nil {
+		return x.UID
+	}
+	return 0
+}
+
+func (x *JailerConfig) GetGID() uint32 {
+	if x != nil {
+		return x.GID
+	}
+	return 0
+}
+
+func (x *JailerConfig) GetCgroupPath() string {
+	if x != nil {
+		return x.CgroupPath
+	}
+	return ""
+}
+
+func (x *JailerConfig) GetDriveExposePolicy() DriveExposePolicy {
+	if x != nil {
+		return x.DriveExposePolicy
+	}
+	return DriveExposePolicy_COPY
+}
+
+func (x *JailerConfig) GetLimitMemory() bool {
+	if x != nil {
+		return x.LimitMemory
+	}
+	return false
+}
+
+func (x *JailerConfig) GetMemoryOverheadMib() uint32 {
+	if x != nil {
+		return x.MemoryOverheadMib
+	}
+	return 0
+}
+
+func (x *JailerConfig) GetIOLimits() *JailerIOLimits {
+	if x != nil {
+		return x.IOLimits
+	}
+	return nil
+}
+
+func (x *JailerConfig) GetPidsLimit() uint32 {
+	if x != nil {
+		return x.PidsLimit
+	}
+	return 0
+}
+
+type JailerIOLimits struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Weight is the IO weight of Firecracker relative to other cgroups on every device, from 10
+	// to 1000. The default weight is used if it is 0.
+	Weight uint32 `protobuf:"varint,1,opt,name=Weight,proto3" json:"Weight,omitempty"`
+	// Devices are the limits of Firecracker on particular block devices.
+	Devices []*JailerDeviceIOLimit `protobuf:"bytes,2,rep,name=Devices,proto3" json:"Devices,omitempty"`
+}
+
+func (x *JailerIOLimits) Reset() {
+	*x = JailerIOLimits{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JailerIOLimits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JailerIOLimits) ProtoMessage() {}
+
+func (x *JailerIOLimits) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JailerIOLimits.ProtoReflect.Descriptor instead.
+func (*JailerIOLimits) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *JailerIOLimits) GetWeight() uint32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *JailerIOLimits) GetDevices() []*JailerDeviceIOLimit {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+type JailerDeviceIOLimit struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Path is the path of the block device on the host, such as /dev/nvme0n1.
+	Path string `protobuf:"bytes,1,opt,name=Path,proto3" json:"Path,omitempty"`
+	// Weight overrides the IO weight of Firecracker on the device, from 10 to 1000.
+	Weight uint32 `protobuf:"varint,2,opt,name=Weight,proto3" json:"Weight,omitempty"`
+	// ReadBps and WriteBps limit the bytes per second Firecracker reads from and writes to the
+	// device, ReadIops and WriteIops its operations per second. A rate of 0 is not limited.
+	ReadBps   uint64 `protobuf:"varint,3,opt,name=ReadBps,proto3" json:"ReadBps,omitempty"`
+	WriteBps  uint64 `protobuf:"varint,4,opt,name=WriteBps,proto3" json:"WriteBps,omitempty"`
+	ReadIops  uint64 `protobuf:"varint,5,opt,name=ReadIops,proto3" json:"ReadIops,omitempty"`
+	WriteIops uint64 `protobuf:"varint,6,opt,name=WriteIops,proto3" json:"WriteIops,omitempty"`
+}
+
+func (x *JailerDeviceIOLimit) Reset() {
+	*x = JailerDeviceIOLimit{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JailerDeviceIOLimit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JailerDeviceIOLimit) ProtoMessage() {}
+
+func (x *JailerDeviceIOLimit) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JailerDeviceIOLimit.ProtoReflect.Descriptor instead.
+func (*JailerDeviceIOLimit) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *JailerDeviceIOLimit) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *JailerDeviceIOLimit) GetWeight() uint32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *JailerDeviceIOLimit) GetReadBps() uint64 {
+	if x != nil {
+		return x.ReadBps
+	}
+	return 0
+}
+
+func (x *JailerDeviceIOLimit) GetWriteBps() uint64 {
+	if x != nil {
+		return x.WriteBps
+	}
+	return 0
+}
+
+func (x *JailerDeviceIOLimit) GetReadIops() uint64 {
+	if x != nil {
+		return x.ReadIops
+	}
+	return 0
+}
+
+func (x *JailerDeviceIOLimit) GetWriteIops() uint64 {
+	if x != nil {
+		return x.WriteIops
+	}
+	return 0
+}
+
+type UpdateBalloonRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VMID      string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+	AmountMib int64  `protobuf:"varint,2,opt,name=AmountMib,proto3" json:"AmountMib,omitempty"`
+}
+
+func (x *UpdateBalloonRequest) Reset() {
+	*x = UpdateBalloonRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateBalloonRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBalloonRequest) ProtoMessage() {}
+
+func (x *UpdateBalloonRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBalloonRequest.ProtoReflect.Descriptor instead.
+func (*UpdateBalloonRequest) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *UpdateBalloonRequest) GetVMID() string {
+	if x != nil {
+		return x.VMID
+	}
+	return ""
+}
+
+func (x *UpdateBalloonRequest) GetAmountMib() int64 {
+	if x != nil {
+		return x.AmountMib
+	}
+	return 0
+}
+
+type UpdateNetworkInterfaceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VMID string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+	// Index of the interface in the NetworkInterfaces the VM was created with, starting at 0
+	InterfaceIndex uint32 `protobuf:"varint,2,opt,name=InterfaceIndex,proto3" json:"InterfaceIndex,omitempty"`
+	// Rate limiters replacing the ones of the interface. An unset rate limiter is left
+	// unchanged, while an empty one removes the limit.
+	InRateLimiter  *FirecrackerRateLimiter `protobuf:"bytes,3,opt,name=InRateLimiter,proto3" json:"InRateLimiter,omitempty"`
+	OutRateLimiter *FirecrackerRateLimiter `protobuf:"bytes,4,opt,name=OutRateLimiter,proto3" json:"OutRateLimiter,omitempty"`
+}
+
+func (x *UpdateNetworkInterfaceRequest) Reset() {
+	*x = UpdateNetworkInterfaceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateNetworkInterfaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateNetworkInterfaceRequest) ProtoMessage() {}
+
+func (x *UpdateNetworkInterfaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateNetworkInterfaceRequest.ProtoReflect.Descriptor instead.
+func (*UpdateNetworkInterfaceRequest) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *UpdateNetworkInterfaceRequest) GetVMID() string {
+	if x != nil {
+		return x.VMID
+	}
+	return ""
+}
+
+func (x *UpdateNetworkInterfaceRequest) GetInterfaceIndex() uint32 {
+	if x != nil {
+		return x.InterfaceIndex
+	}
+	return 0
+}
+
+func (x *UpdateNetworkInterfaceRequest) GetInRateLimiter() *FirecrackerRateLimiter {
+	if x != nil {
+		return x.InRateLimiter
+	}
+	return nil
+}
+
+func (x *UpdateNetworkInterfaceRequest) GetOutRateLimiter() *FirecrackerRateLimiter {
+	if x != nil {
+		return x.OutRateLimiter
+	}
+	return nil
+}
+
+type GetBalloonConfigRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VMID string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+}
+
+func (x *GetBalloonConfigRequest) Reset() {
+	*x = GetBalloonConfigRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBalloonConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalloonConfigRequest) ProtoMessage() {}
+
+func (x *GetBalloonConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalloonConfigRequest.ProtoReflect.Descriptor instead.
+func (*GetBalloonConfigRequest) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetBalloonConfigRequest) GetVMID() string {
+	if x != nil {
+		return x.VMID
+	}
+	return ""
+}
+
+type GetBalloonConfigResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BalloonConfig *FirecrackerBalloonDevice `protobuf:"bytes,1,opt,name=BalloonConfig,proto3" json:"BalloonConfig,omitempty"`
+}
+
+func (x *GetBalloonConfigResponse) Reset() {
+	*x = GetBalloonConfigResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBalloonConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalloonConfigResponse) ProtoMessage() {}
+
+func (x *GetBalloonConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalloonConfigResponse.ProtoReflect.Descriptor instead.
+func (*GetBalloonConfigResponse) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetBalloonConfigResponse) GetBalloonConfig() *FirecrackerBalloonDevice {
+	if x != nil {
+		return x.BalloonConfig
+	}
+	return nil
+}
+
+type GetBalloonStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VMID string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+}
+
+func (x *GetBalloonStatsRequest) Reset() {
+	*x = GetBalloonStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBalloonStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalloonStatsRequest) ProtoMessage() {}
+
+func (x *GetBalloonStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalloonStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetBalloonStatsRequest) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetBalloonStatsRequest) GetVMID() string {
+	if x != nil {
+		return x.VMID
+	}
+	return ""
+}
+
+type GetBalloonStatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Actual amount of memory (in MiB) the device is holding.
+	ActualMib int64 `protobuf:"varint,1,opt,name=ActualMib,proto3" json:"ActualMib,omitempty"`
+	// Actual number of pages the device is holding.
+	ActualPages int64 `protobuf:"varint,2,opt,name=ActualPages,proto3" json:"ActualPages,omitempty"`
+	// An estimate of how much memory is available (in bytes) for starting new applications, without pushing the system to swap.
+	AvailableMemory int64 `protobuf:"varint,3,opt,name=AvailableMemory,proto3" json:"AvailableMemory,omitempty"`
+	// The amount of memory, in bytes, that can be quickly reclaimed without additional I/O.
+	// Typically these pages are used for caching files from disk.
+	DiskCaches int64 `protobuf:"varint,4,opt,name=DiskCaches,proto3" json:"DiskCaches,omitempty"`
+	// The amount of memory not being used for any purpose (in bytes).
+	FreeMemory int64 `protobuf:"varint,5,opt,name=FreeMemory,proto3" json:"FreeMemory,omitempty"`
+	// The number of successful hugetlb page allocations in the guest.
+	HugetlbAllocations int64 `protobuf:"varint,6,opt,name=HugetlbAllocations,proto3" json:"HugetlbAllocations,omitempty"`
+	// The number of failed hugetlb page allocations in the guest.
+	HugetlbFailures int64 `protobuf:"varint,7,opt,name=HugetlbFailures,proto3" json:"HugetlbFailures,omitempty"`
+	// The number of major page faults that have occurred.
+	MajorFaults int64 `protobuf:"varint,8,opt,name=MajorFaults,proto3" json:"MajorFaults,omitempty"`
+	// The number of minor page faults that have occurred.
+	MinorFaults int64 `protobuf:"varint,9,opt,name=MinorFaults,proto3" json:"MinorFaults,omitempty"`
+	// The amount of memory that has been swapped in (in bytes).
+	SwapIn int64 `protobuf:"varint,10,opt,name=SwapIn,proto3" json:"SwapIn,omitempty"`
+	// The amount of memory that has been swapped out to disk (in bytes).
+	SwapOut int64 `protobuf:"varint,11,opt,name=SwapOut,proto3" json:"SwapOut,omitempty"`
+	// Target amount of memory (in MiB) the device aims to hold.
+	TargetMib int64 `protobuf:"varint,12,opt,name=TargetMib,proto3" json:"TargetMib,omitempty"`
+	// Target number of pages the device aims to hold.
+	TargetPages int64 `protobuf:"varint,13,opt,name=TargetPages,proto3" json:"TargetPages,omitempty"`
+	// The total amount of memory available (in bytes).
+	TotalMemory int64 `protobuf:"varint,14,opt,name=TotalMemory,proto3" json:"TotalMemory,omitempty"`
+}
+
+func (x *GetBalloonStatsResponse) Reset() {
+	*x = GetBalloonStatsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBalloonStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalloonStatsResponse) ProtoMessage() {}
+
+func (x *GetBalloonStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalloonStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetBalloonStatsResponse) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GetBalloonStatsResponse) GetActualMib() int64 {
+	if x != nil {
+		return x.ActualMib
+	}
+	return 0
+}
+
+func (x *GetBalloonStatsResponse) GetActualPages() int64 {
+	if x != nil {
+		return x.ActualPages
+	}
+	return 0
+}
+
+func (x *GetBalloonStatsResponse) GetAvailableMemory() int64 {
+	if x != nil {
+		return x.AvailableMemory
+	}
+	return 0
+}
+
+func (x *GetBalloonStatsResponse) GetDiskCaches() int64 {
+	if x != nil {
+		return x.DiskCaches
+	}
+	return 0
+}
+
+func (x *GetBalloonStatsResponse) GetFreeMemory() int64 {
+	if x != nil {
+		return x.FreeMemory
+	}
+	return 0
+}
+
+func (x *GetBalloonStatsResponse) GetHugetlbAllocations() int64 {
+	if x != nil {
+		return x.HugetlbAllocations
+	}
+	return 0
+}
+
+func (x *GetBalloonStatsResponse) GetHugetlbFailures() int64 {
+	if x != nil {
+		return x.HugetlbFailures
+	}
+	return 0
+}
+
+func (x *GetBalloonStatsResponse) GetMajorFaults() int64 {
+	if x != nil {
+		return x.MajorFaults
+	}
+	return 0
+}
+
+func (x *GetBalloonStatsResponse) GetMinorFaults() int64 {
+	if x != nil {
+		return x.MinorFaults
+	}
+	return 0
+}
+
+func (x *GetBalloonStatsResponse) GetSwapIn() int64 {
+	if x != nil {
+		return x.SwapIn
+	}
+	return 0
+}
+
+func (x *GetBalloonStatsResponse) GetSwapOut() int64 {
+	if x != nil {
+		return x.SwapOut
+	}
+	return 0
+}
+
+func (x *GetBalloonStatsResponse) GetTargetMib() int64 {
+	if x != nil {
+		return x.TargetMib
+	}
+	return 0
+}
+
+func (x *GetBalloonStatsResponse) GetTargetPages() int64 {
+	if x != nil {
+		return x.TargetPages
+	}
+	return 0
+}
+
+func (x *GetBalloonStatsResponse) GetTotalMemory() int64 {
+	if x != nil {
+		return x.TotalMemory
+	}
+	return 0
+}
+
+type UpdateBalloonStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VMID                  string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+	StatsPollingIntervals int64  `protobuf:"varint,2,opt,name=StatsPollingIntervals,proto3" json:"StatsPollingIntervals,omitempty"`
+}
+
+func (x *UpdateBalloonStatsRequest) Reset() {
+	*x = UpdateBalloonStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateBalloonStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBalloonStatsRequest) ProtoMessage() {}
+
+func (x *UpdateBalloonStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBalloonStatsRequest.ProtoReflect.Descriptor instead.
+func (*UpdateBalloonStatsRequest) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *UpdateBalloonStatsRequest) GetVMID() string {
+	if x != nil {
+		return x.VMID
+	}
+	return ""
+}
+
+func (x *UpdateBalloonStatsRequest) GetStatsPollingIntervals() int64 {
+	if x != nil {
+		return x.StatsPollingIntervals
+	}
+	return 0
+}
+
+type GetConsoleLogRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VMID string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+}
+
+func (x *GetConsoleLogRequest) Reset() {
+	*x = GetConsoleLogRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetConsoleLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConsoleLogRequest) ProtoMessage() {}
+
+func (x *GetConsoleLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConsoleLogRequest.ProtoReflect.Descriptor instead.
+func (*GetConsoleLogRequest) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GetConsoleLogRequest) GetVMID() string {
+	if x != nil {
+		return x.VMID
+	}
+	return ""
+}
+
+type GetConsoleLogResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The latest output of the serial console, bounded by the size of the buffer the shim keeps
+	Output []byte `protobuf:"bytes,1,opt,name=Output,proto3" json:"Output,omitempty"`
+}
+
+func (x *GetConsoleLogResponse) Reset() {
+	*x = GetConsoleLogResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetConsoleLogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConsoleLogResponse) ProtoMessage() {}
+
+func (x *GetConsoleLogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConsoleLogResponse.ProtoReflect.Descriptor instead.
+func (*GetConsoleLogResponse) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GetConsoleLogResponse) GetOutput() []byte {
+	if x != nil {
+		return x.Output
+	}
+	return nil
+}
+
+// AttachConsoleRequest connects the serial console of a VM to FIFOs created by the client, the
+// same way the stdio of tasks is. The console stays attached until either FIFO is closed.
+type AttachConsoleRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VMID string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+	// Path to a FIFO the output of the serial console is written to
+	StdoutPath string `protobuf:"bytes,2,opt,name=StdoutPath,proto3" json:"StdoutPath,omitempty"`
+	// Path to a FIFO the input of the serial console is read from. Only one attachment at a
+	// time can provide input. Optional.
+	StdinPath string `protobuf:"bytes,3,opt,name=StdinPath,proto3" json:"StdinPath,omitempty"`
+}
+
+func (x *AttachConsoleRequest) Reset() {
+	*x = AttachConsoleRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AttachConsoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachConsoleRequest) ProtoMessage() {}
+
+func (x *AttachConsoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachConsoleRequest.ProtoReflect.Descriptor instead.
+func (*AttachConsoleRequest) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *AttachConsoleRequest) GetVMID() string {
+	if x != nil {
+		return x.VMID
+	}
+	return ""
+}
+
+func (x *AttachConsoleRequest) GetStdoutPath() string {
+	if x != nil {
+		return x.StdoutPath
+	}
+	return ""
+}
+
+func (x *AttachConsoleRequest) GetStdinPath() string {
+	if x != nil {
+		return x.StdinPath
+	}
+	return ""
+}
+
+// ExecInVMRequest runs a process in the root namespaces of a VM, which is meant for debugging
+// the VM itself. The stdio of the process is connected to FIFOs created by the client, the same
+// way the stdio of tasks is.
+type ExecInVMRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VMID string   `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+	Args []string `protobuf:"bytes,2,rep,name=Args,proto3" json:"Args,omitempty"`
+	Env  []string `protobuf:"bytes,3,rep,name=Env,proto3" json:"Env,omitempty"`
+	// The working directory of the process. Defaults to the root directory of the VM.
+	Cwd string `protobuf:"bytes,4,opt,name=Cwd,proto3" json:"Cwd,omitempty"`
+	// Terminal runs the process in a pseudo-terminal of the given size. Its stdout and stderr
+	// are both written to StdoutPath then.
+	Terminal bool   `protobuf:"varint,5,opt,name=Terminal,proto3" json:"Terminal,omitempty"`
+	Width    uint32 `protobuf:"varint,6,opt,name=Width,proto3" json:"Width,omitempty"`
+	Height   uint32 `protobuf:"varint,7,opt,name=Height,proto3" json:"Height,omitempty"`
+	// Paths to the stdio FIFOs of the process. Each is optional.
+	StdinPath  string `protobuf:"bytes,8,opt,name=StdinPath,proto3" json:"StdinPath,omitempty"`
+	StdoutPath string `protobuf:"bytes,9,opt,name=StdoutPath,proto3" json:"StdoutPath,omitempty"`
+	StderrPath string `protobuf:"bytes,10,opt,name=StderrPath,proto3" json:"StderrPath,omitempty"`
+}
+
+func (x *ExecInVMRequest) Reset() {
+	*x = ExecInVMRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExecInVMRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecInVMRequest) ProtoMessage() {}
+
+func (x *ExecInVMRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecInVMRequest.ProtoReflect.Descriptor instead.
+func (*ExecInVMRequest) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ExecInVMRequest) GetVMID() string {
+	if x != nil {
+		return x.VMID
+	}
+	return ""
+}
+
+func (x *ExecInVMRequest) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *ExecInVMRequest) GetEnv() []string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+func (x *ExecInVMRequest) GetCwd() string {
+	if x != nil {
+		return x.Cwd
+	}
+	return ""
+}
+
+func (x *ExecInVMRequest) GetTerminal() bool {
+	if x != nil {
+		return x.Terminal
+	}
+	return false
+}
+
+func (x *ExecInVMRequest) GetWidth() uint32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *ExecInVMRequest) GetHeight() uint32 {
+	if x != nil {
+		return x.Height
 	}
 	return 0
 }
 
-func (x *GetBalloonStatsResponse) GetActualPages() int64 {
+func (x *ExecInVMRequest) GetStdinPath() string {
+	if x != nil {
+		return x.StdinPath
+	}
+	return ""
+}
+
+func (x *ExecInVMRequest) GetStdoutPath() string {
+	if x != nil {
+		return x.StdoutPath
+	}
+	return ""
+}
+
+func (x *ExecInVMRequest) GetStderrPath() string {
+	if x != nil {
+		return x.StderrPath
+	}
+	return ""
+}
+
+type ExecInVMResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ExitStatus uint32 `protobuf:"varint,1,opt,name=ExitStatus,proto3" json:"ExitStatus,omitempty"`
+}
+
+func (x *ExecInVMResponse) Reset() {
+	*x = ExecInVMResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExecInVMResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecInVMResponse) ProtoMessage() {}
+
+func (x *ExecInVMResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecInVMResponse.ProtoReflect.Descriptor instead.
+func (*ExecInVMResponse) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ExecInVMResponse) GetExitStatus() uint32 {
 	if x != nil {
-		return x.ActualPages
+		return x.ExitStatus
 	}
 	return 0
 }
 
-func (x *GetBalloonStatsResponse) GetAvailableMemory() int64 {
-	if x != nil {
-		return x.AvailableMemory
+// CopyToVMRequest extracts a tar archive into a VM. The ownership and mode of the archived files
+// are kept.
+type CopyToVMRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VMID string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+	// Path to the archive on the host, either a file or a FIFO the client writes the archive to
+	ArchivePath string `protobuf:"bytes,2,opt,name=ArchivePath,proto3" json:"ArchivePath,omitempty"`
+	// The directory of the VM the archive is extracted into, which is created if needed
+	VMPath string `protobuf:"bytes,3,opt,name=VMPath,proto3" json:"VMPath,omitempty"`
+}
+
+func (x *CopyToVMRequest) Reset() {
+	*x = CopyToVMRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return 0
 }
 
-func (x *GetBalloonStatsResponse) GetDiskCaches() int64 {
-	if x != nil {
-		return x.DiskCaches
+func (x *CopyToVMRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CopyToVMRequest) ProtoMessage() {}
+
+func (x *CopyToVMRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *GetBalloonStatsResponse) GetFreeMemory() int64 {
+// Deprecated: Use CopyToVMRequest.ProtoReflect.Descriptor instead.
+func (*CopyToVMRequest) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *CopyToVMRequest) GetVMID() string {
 	if x != nil {
-		return x.FreeMemory
+		return x.VMID
 	}
-	return 0
+	return ""
 }
 
-func (x *GetBalloonStatsResponse) GetHugetlbAllocations() int64 {
+func (x *CopyToVMRequest) GetArchivePath() string {
 	if x != nil {
-		return x.HugetlbAllocations
+		return x.ArchivePath
 	}
-	return 0
+	return ""
 }
 
-func (x *GetBalloonStatsResponse) GetHugetlbFailures() int64 {
+func (x *CopyToVMRequest) GetVMPath() string {
 	if x != nil {
-		return x.HugetlbFailures
+		return x.VMPath
 	}
-	return 0
+	return ""
 }
 
-func (x *GetBalloonStatsResponse) GetMajorFaults() int64 {
+// CopyFromVMRequest archives a file or directory of a VM, along with its ownership and mode.
+type CopyFromVMRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VMID string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
+	// The file or directory of the VM to archive. Its entries are named after its base name.
+	VMPath string `protobuf:"bytes,2,opt,name=VMPath,proto3" json:"VMPath,omitempty"`
+	// Path to write the archive to on the host, either a file which must not exist yet or a
+	// FIFO the client reads the archive from
+	ArchivePath string `protobuf:"bytes,3,opt,name=ArchivePath,proto3" json:"ArchivePath,omitempty"`
+}
+
+func (x *CopyFromVMRequest) Reset() {
+	*x = CopyFromVMRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CopyFromVMRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CopyFromVMRequest) ProtoMessage() {}
+
+func (x *CopyFromVMRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CopyFromVMRequest.ProtoReflect.Descriptor instead.
+func (*CopyFromVMRequest) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *CopyFromVMRequest) GetVMID() string {
 	if x != nil {
-		return x.MajorFaults
+		return x.VMID
 	}
-	return 0
+	return ""
 }
 
-func (x *GetBalloonStatsResponse) GetMinorFaults() int64 {
+func (x *CopyFromVMRequest) GetVMPath() string {
 	if x != nil {
-		return x.MinorFaults
+		return x.VMPath
 	}
-	return 0
+	return ""
 }
 
-func (x *GetBalloonStatsResponse) GetSwapIn() int64 {
+func (x *CopyFromVMRequest) GetArchivePath() string {
 	if x != nil {
-		return x.SwapIn
+		return x.ArchivePath
 	}
-	return 0
+	return ""
 }
 
-func (x *GetBalloonStatsResponse) GetSwapOut() int64 {
+type MMDSConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version MMDSVersion `protobuf:"varint,1,opt,name=Version,proto3,enum=MMDSVersion" json:"Version,omitempty"`
+	// The link-local IPv4 address the MMDS is reachable at. Defaults to 169.254.169.254.
+	IPv4Address string `protobuf:"bytes,2,opt,name=IPv4Address,proto3" json:"IPv4Address,omitempty"`
+	// The network interfaces the MMDS is reachable through. Firecracker identifies the network
+	// interfaces of a VM as "1", "2" and so on, in the order of NetworkInterfaces. Defaults to
+	// the interfaces with AllowMMDS set.
+	NetworkInterfaceIDs []string `protobuf:"bytes,3,rep,name=NetworkInterfaceIDs,proto3" json:"NetworkInterfaceIDs,omitempty"`
+	// JSON metadata the MMDS serves from the moment the VM boots. Optional.
+	Metadata string `protobuf:"bytes,4,opt,name=Metadata,proto3" json:"Metadata,omitempty"`
+}
+
+func (x *MMDSConfig) Reset() {
+	*x = MMDSConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_firecracker_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MMDSConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MMDSConfig) ProtoMessage() {}
+
+func (x *MMDSConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MMDSConfig.ProtoReflect.Descriptor instead.
+func (*MMDSConfig) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *MMDSConfig) GetVersion() MMDSVersion {
 	if x != nil {
-		return x.SwapOut
+		return x.Version
 	}
-	return 0
+	return MMDSVersion_V1
 }
 
-func (x *GetBalloonStatsResponse) GetTargetMib() int64 {
+func (x *MMDSConfig) GetIPv4Address() string {
 	if x != nil {
-		return x.TargetMib
+		return x.IPv4Address
 	}
-	return 0
+	return ""
 }
 
-func (x *GetBalloonStatsResponse) GetTargetPages() int64 {
+func (x *MMDSConfig) GetNetworkInterfaceIDs() []string {
 	if x != nil {
-		return x.TargetPages
+		return x.NetworkInterfaceIDs
 	}
-	return 0
+	return nil
 }
 
-func (x *GetBalloonStatsResponse) GetTotalMemory() int64 {
+func (x *MMDSConfig) GetMetadata() string {
 	if x != nil {
-		return x.TotalMemory
+		return x.Metadata
 	}
-	return 0
+	return ""
 }
 
-type UpdateBalloonStatsRequest struct {
+// VMImage is an image of the containerd image store holding a single file, which is either its
+// only layer or the only regular file of that layer if it is a container image layer. The file is
+// extracted into a cache on the host the first time the image is used.
+type VMImage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	VMID                  string `protobuf:"bytes,1,opt,name=VMID,proto3" json:"VMID,omitempty"`
-	StatsPollingIntervals int64  `protobuf:"varint,2,opt,name=StatsPollingIntervals,proto3" json:"StatsPollingIntervals,omitempty"`
+	// The name of the image, which must have been pulled into the namespace of the request
+	Ref string `protobuf:"bytes,1,opt,name=Ref,proto3" json:"Ref,omitempty"`
+	// The digest the image must resolve to, which pins it regardless of where its name points
+	// to. Optional.
+	Digest string `protobuf:"bytes,2,opt,name=Digest,proto3" json:"Digest,omitempty"`
 }
 
-func (x *UpdateBalloonStatsRequest) Reset() {
-	*x = UpdateBalloonStatsRequest{}
+func (x *VMImage) Reset() {
+	*x = VMImage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_firecracker_proto_msgTypes[17]
+		mi := &file_firecracker_proto_msgTypes[47]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *UpdateBalloonStatsRequest) String() string {
+func (x *VMImage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateBalloonStatsRequest) ProtoMessage() {}
+func (*VMImage) ProtoMessage() {}
 
-func (x *UpdateBalloonStatsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_firecracker_proto_msgTypes[17]
+func (x *VMImage) ProtoReflect() protoreflect.Message {
+	mi := &file_firecracker_proto_msgTypes[47]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1313,197 +3792,556 @@ func (x *UpdateBalloonStatsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateBalloonStatsRequest.ProtoReflect.Descriptor instead.
-func (*UpdateBalloonStatsRequest) Descriptor() ([]byte, []int) {
-	return file_firecracker_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use VMImage.ProtoReflect.Descriptor instead.
+func (*VMImage) Descriptor() ([]byte, []int) {
+	return file_firecracker_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *UpdateBalloonStatsRequest) GetVMID() string {
+func (x *VMImage) GetRef() string {
 	if x != nil {
-		return x.VMID
+		return x.Ref
 	}
 	return ""
 }
 
-func (x *UpdateBalloonStatsRequest) GetStatsPollingIntervals() int64 {
+func (x *VMImage) GetDigest() string {
 	if x != nil {
-		return x.StatsPollingIntervals
+		return x.Digest
 	}
-	return 0
+	return ""
 }
 
 var File_firecracker_proto protoreflect.FileDescriptor
 
 var file_firecracker_proto_rawDesc = []byte{
 	0x0a, 0x11, 0x66, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x1a, 0x0b, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x22, 0xb8, 0x05, 0x0a, 0x0f, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x4d, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x40, 0x0a, 0x0a, 0x4d, 0x61, 0x63, 0x68,
-	0x69, 0x6e, 0x65, 0x43, 0x66, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x46,
-	0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e,
-	0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a,
-	0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x43, 0x66, 0x67, 0x12, 0x28, 0x0a, 0x0f, 0x4b, 0x65,
-	0x72, 0x6e, 0x65, 0x6c, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x50, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0f, 0x4b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x49, 0x6d, 0x61, 0x67, 0x65,
-	0x50, 0x61, 0x74, 0x68, 0x12, 0x1e, 0x0a, 0x0a, 0x4b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x41, 0x72,
-	0x67, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x4b, 0x65, 0x72, 0x6e, 0x65, 0x6c,
-	0x41, 0x72, 0x67, 0x73, 0x12, 0x33, 0x0a, 0x09, 0x52, 0x6f, 0x6f, 0x74, 0x44, 0x72, 0x69, 0x76,
-	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72,
-	0x61, 0x63, 0x6b, 0x65, 0x72, 0x52, 0x6f, 0x6f, 0x74, 0x44, 0x72, 0x69, 0x76, 0x65, 0x52, 0x09,
-	0x52, 0x6f, 0x6f, 0x74, 0x44, 0x72, 0x69, 0x76, 0x65, 0x12, 0x38, 0x0a, 0x0b, 0x44, 0x72, 0x69,
-	0x76, 0x65, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16,
-	0x2e, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x44, 0x72, 0x69, 0x76,
-	0x65, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x0b, 0x44, 0x72, 0x69, 0x76, 0x65, 0x4d, 0x6f, 0x75,
-	0x6e, 0x74, 0x73, 0x12, 0x4a, 0x0a, 0x11, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x6e,
-	0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c,
-	0x2e, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x4e, 0x65, 0x74, 0x77,
-	0x6f, 0x72, 0x6b, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x52, 0x11, 0x4e, 0x65,
-	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x12,
-	0x26, 0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x43, 0x6f, 0x75, 0x6e,
-	0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
-	0x65, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x3a, 0x0a, 0x18, 0x45, 0x78, 0x69, 0x74, 0x41,
-	0x66, 0x74, 0x65, 0x72, 0x41, 0x6c, 0x6c, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x44, 0x65, 0x6c, 0x65,
-	0x74, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x18, 0x45, 0x78, 0x69, 0x74, 0x41,
-	0x66, 0x74, 0x65, 0x72, 0x41, 0x6c, 0x6c, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x44, 0x65, 0x6c, 0x65,
-	0x74, 0x65, 0x64, 0x12, 0x31, 0x0a, 0x0c, 0x4a, 0x61, 0x69, 0x6c, 0x65, 0x72, 0x43, 0x6f, 0x6e,
-	0x66, 0x69, 0x67, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x4a, 0x61, 0x69, 0x6c,
-	0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0c, 0x4a, 0x61, 0x69, 0x6c, 0x65, 0x72,
-	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x26, 0x0a, 0x0e, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75,
-	0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e,
-	0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x20,
-	0x0a, 0x0b, 0x4c, 0x6f, 0x67, 0x46, 0x69, 0x66, 0x6f, 0x50, 0x61, 0x74, 0x68, 0x18, 0x0c, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0b, 0x4c, 0x6f, 0x67, 0x46, 0x69, 0x66, 0x6f, 0x50, 0x61, 0x74, 0x68,
-	0x12, 0x28, 0x0a, 0x0f, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x46, 0x69, 0x66, 0x6f, 0x50,
-	0x61, 0x74, 0x68, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x4d, 0x65, 0x74, 0x72, 0x69,
-	0x63, 0x73, 0x46, 0x69, 0x66, 0x6f, 0x50, 0x61, 0x74, 0x68, 0x12, 0x3f, 0x0a, 0x0d, 0x42, 0x61,
-	0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x19, 0x2e, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x42,
-	0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x0d, 0x42, 0x61,
-	0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x22, 0xb2, 0x01, 0x0a, 0x10,
-	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x4d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x56, 0x4d, 0x49, 0x44, 0x12, 0x1e, 0x0a, 0x0a, 0x53, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x50, 0x61,
-	0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x53, 0x6f, 0x63, 0x6b, 0x65, 0x74,
-	0x50, 0x61, 0x74, 0x68, 0x12, 0x20, 0x0a, 0x0b, 0x4c, 0x6f, 0x67, 0x46, 0x69, 0x66, 0x6f, 0x50,
-	0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x4c, 0x6f, 0x67, 0x46, 0x69,
-	0x66, 0x6f, 0x50, 0x61, 0x74, 0x68, 0x12, 0x28, 0x0a, 0x0f, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63,
-	0x73, 0x46, 0x69, 0x66, 0x6f, 0x50, 0x61, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0f, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x46, 0x69, 0x66, 0x6f, 0x50, 0x61, 0x74, 0x68,
-	0x12, 0x1e, 0x0a, 0x0a, 0x43, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x50, 0x61, 0x74, 0x68, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x43, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x50, 0x61, 0x74, 0x68,
-	0x22, 0x24, 0x0a, 0x0e, 0x50, 0x61, 0x75, 0x73, 0x65, 0x56, 0x4d, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x22, 0x25, 0x0a, 0x0f, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65,
-	0x56, 0x4d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49,
-	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x22, 0x4b, 0x0a,
-	0x0d, 0x53, 0x74, 0x6f, 0x70, 0x56, 0x4d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12,
-	0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d,
-	0x49, 0x44, 0x12, 0x26, 0x0a, 0x0e, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x63,
-	0x6f, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x54, 0x69, 0x6d, 0x65,
-	0x6f, 0x75, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22, 0x26, 0x0a, 0x10, 0x47, 0x65,
-	0x74, 0x56, 0x4d, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12,
-	0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d,
-	0x49, 0x44, 0x22, 0xd1, 0x01, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x56, 0x4d, 0x49, 0x6e, 0x66, 0x6f,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x1e, 0x0a, 0x0a,
-	0x53, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x50, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0a, 0x53, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x50, 0x61, 0x74, 0x68, 0x12, 0x20, 0x0a, 0x0b,
-	0x4c, 0x6f, 0x67, 0x46, 0x69, 0x66, 0x6f, 0x50, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0b, 0x4c, 0x6f, 0x67, 0x46, 0x69, 0x66, 0x6f, 0x50, 0x61, 0x74, 0x68, 0x12, 0x28,
-	0x0a, 0x0f, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x46, 0x69, 0x66, 0x6f, 0x50, 0x61, 0x74,
-	0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73,
-	0x46, 0x69, 0x66, 0x6f, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1e, 0x0a, 0x0a, 0x43, 0x67, 0x72, 0x6f,
-	0x75, 0x70, 0x50, 0x61, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x43, 0x67,
-	0x72, 0x6f, 0x75, 0x70, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x56, 0x53, 0x6f, 0x63,
-	0x6b, 0x50, 0x61, 0x74, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x56, 0x53, 0x6f,
-	0x63, 0x6b, 0x50, 0x61, 0x74, 0x68, 0x22, 0x46, 0x0a, 0x14, 0x53, 0x65, 0x74, 0x56, 0x4d, 0x4d,
-	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12,
+	0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0b, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x22, 0x8a, 0x08, 0x0a, 0x0f, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x4d, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x40, 0x0a, 0x0a, 0x4d, 0x61, 0x63,
+	0x68, 0x69, 0x6e, 0x65, 0x43, 0x66, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e,
+	0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x4d, 0x61, 0x63, 0x68, 0x69,
+	0x6e, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x0a, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x43, 0x66, 0x67, 0x12, 0x28, 0x0a, 0x0f, 0x4b,
+	0x65, 0x72, 0x6e, 0x65, 0x6c, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x50, 0x61, 0x74, 0x68, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x4b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x49, 0x6d, 0x61, 0x67,
+	0x65, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1e, 0x0a, 0x0a, 0x4b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x41,
+	0x72, 0x67, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x4b, 0x65, 0x72, 0x6e, 0x65,
+	0x6c, 0x41, 0x72, 0x67, 0x73, 0x12, 0x33, 0x0a, 0x09, 0x52, 0x6f, 0x6f, 0x74, 0x44, 0x72, 0x69,
+	0x76, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x46, 0x69, 0x72, 0x65, 0x63,
+	0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x52, 0x6f, 0x6f, 0x74, 0x44, 0x72, 0x69, 0x76, 0x65, 0x52,
+	0x09, 0x52, 0x6f, 0x6f, 0x74, 0x44, 0x72, 0x69, 0x76, 0x65, 0x12, 0x38, 0x0a, 0x0b, 0x44, 0x72,
+	0x69, 0x76, 0x65, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x16, 0x2e, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x44, 0x72, 0x69,
+	0x76, 0x65, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x0b, 0x44, 0x72, 0x69, 0x76, 0x65, 0x4d, 0x6f,
+	0x75, 0x6e, 0x74, 0x73, 0x12, 0x4a, 0x0a, 0x11, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49,
+	0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x4e, 0x65, 0x74,
+	0x77, 0x6f, 0x72, 0x6b, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x52, 0x11, 0x4e,
+	0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73,
+	0x12, 0x26, 0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69,
+	0x6e, 0x65, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x3a, 0x0a, 0x18, 0x45, 0x78, 0x69, 0x74,
+	0x41, 0x66, 0x74, 0x65, 0x72, 0x41, 0x6c, 0x6c, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x18, 0x45, 0x78, 0x69, 0x74,
+	0x41, 0x66, 0x74, 0x65, 0x72, 0x41, 0x6c, 0x6c, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x64, 0x12, 0x31, 0x0a, 0x0c, 0x4a, 0x61, 0x69, 0x6c, 0x65, 0x72, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x4a, 0x61, 0x69,
+	0x6c, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0c, 0x4a, 0x61, 0x69, 0x6c, 0x65,
+	0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x26, 0x0a, 0x0e, 0x54, 0x69, 0x6d, 0x65, 0x6f,
+	0x75, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0e, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12,
+	0x20, 0x0a, 0x0b, 0x4c, 0x6f, 0x67, 0x46, 0x69, 0x66, 0x6f, 0x50, 0x61, 0x74, 0x68, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x4c, 0x6f, 0x67, 0x46, 0x69, 0x66, 0x6f, 0x50, 0x61, 0x74,
+	0x68, 0x12, 0x28, 0x0a, 0x0f, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x46, 0x69, 0x66, 0x6f,
+	0x50, 0x61, 0x74, 0x68, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x4d, 0x65, 0x74, 0x72,
+	0x69, 0x63, 0x73, 0x46, 0x69, 0x66, 0x6f, 0x50, 0x61, 0x74, 0x68, 0x12, 0x3f, 0x0a, 0x0d, 0x42,
+	0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x18, 0x0e, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72,
+	0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x0d, 0x42,
+	0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x37, 0x0a, 0x0c,
+	0x4c, 0x6f, 0x61, 0x64, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x0f, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0c, 0x4c, 0x6f, 0x61, 0x64, 0x53, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x28, 0x0a, 0x0f, 0x53, 0x70, 0x61, 0x72, 0x65, 0x44, 0x72,
+	0x69, 0x76, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x10, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f,
+	0x53, 0x70, 0x61, 0x72, 0x65, 0x44, 0x72, 0x69, 0x76, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x2b, 0x0a, 0x0a, 0x4d, 0x4d, 0x44, 0x53, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x11, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x4d, 0x4d, 0x44, 0x53, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x52, 0x0a, 0x4d, 0x4d, 0x44, 0x53, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x2a, 0x0a, 0x0b,
+	0x4b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x08, 0x2e, 0x56, 0x4d, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x0b, 0x4b, 0x65, 0x72,
+	0x6e, 0x65, 0x6c, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x30, 0x0a, 0x0e, 0x52, 0x6f, 0x6f, 0x74,
+	0x44, 0x72, 0x69, 0x76, 0x65, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x08, 0x2e, 0x56, 0x4d, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x0e, 0x52, 0x6f, 0x6f, 0x74,
+	0x44, 0x72, 0x69, 0x76, 0x65, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x2c, 0x0a, 0x11, 0x46, 0x69,
+	0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x18,
+	0x14, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b,
+	0x65, 0x72, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x12, 0x34, 0x0a, 0x0d, 0x52, 0x65, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0e, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52,
+	0x0d, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x22, 0xc4,
+	0x02, 0x0a, 0x10, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x4d, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x1e, 0x0a, 0x0a, 0x53, 0x6f, 0x63, 0x6b, 0x65,
+	0x74, 0x50, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x53, 0x6f, 0x63,
+	0x6b, 0x65, 0x74, 0x50, 0x61, 0x74, 0x68, 0x12, 0x20, 0x0a, 0x0b, 0x4c, 0x6f, 0x67, 0x46, 0x69,
+	0x66, 0x6f, 0x50, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x4c, 0x6f,
+	0x67, 0x46, 0x69, 0x66, 0x6f, 0x50, 0x61, 0x74, 0x68, 0x12, 0x28, 0x0a, 0x0f, 0x4d, 0x65, 0x74,
+	0x72, 0x69, 0x63, 0x73, 0x46, 0x69, 0x66, 0x6f, 0x50, 0x61, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0f, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x46, 0x69, 0x66, 0x6f, 0x50,
+	0x61, 0x74, 0x68, 0x12, 0x1e, 0x0a, 0x0a, 0x43, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x50, 0x61, 0x74,
+	0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x43, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x50,
+	0x61, 0x74, 0x68, 0x12, 0x2c, 0x0a, 0x11, 0x4b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x49, 0x6d, 0x61,
+	0x67, 0x65, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11,
+	0x4b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x44, 0x69, 0x67, 0x65, 0x73,
+	0x74, 0x12, 0x32, 0x0a, 0x14, 0x52, 0x6f, 0x6f, 0x74, 0x44, 0x72, 0x69, 0x76, 0x65, 0x49, 0x6d,
+	0x61, 0x67, 0x65, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x14, 0x52, 0x6f, 0x6f, 0x74, 0x44, 0x72, 0x69, 0x76, 0x65, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x44,
+	0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x2e, 0x0a, 0x12, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61,
+	0x63, 0x6b, 0x65, 0x72, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x12, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x24, 0x0a, 0x0e, 0x50, 0x61, 0x75, 0x73, 0x65, 0x56, 0x4d,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x22, 0x25, 0x0a, 0x0f, 0x52,
+	0x65, 0x73, 0x75, 0x6d, 0x65, 0x56, 0x4d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12,
 	0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d,
-	0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x49,
-	0x0a, 0x17, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x56, 0x4d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
-	0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49,
-	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x1a, 0x0a,
-	0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x2a, 0x0a, 0x14, 0x47, 0x65, 0x74,
-	0x56, 0x4d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x56, 0x4d, 0x49, 0x44, 0x22, 0x33, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x56, 0x4d, 0x4d, 0x65,
-	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a,
-	0x0a, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0xd2, 0x01, 0x0a, 0x0c, 0x4a,
-	0x61, 0x69, 0x6c, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x4e,
-	0x65, 0x74, 0x4e, 0x53, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x4e, 0x65, 0x74, 0x4e,
-	0x53, 0x12, 0x12, 0x0a, 0x04, 0x43, 0x50, 0x55, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x43, 0x50, 0x55, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x4d, 0x65, 0x6d, 0x73, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x4d, 0x65, 0x6d, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x55, 0x49, 0x44,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x55, 0x49, 0x44, 0x12, 0x10, 0x0a, 0x03, 0x47,
-	0x49, 0x44, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x47, 0x49, 0x44, 0x12, 0x1e, 0x0a,
-	0x0a, 0x43, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x50, 0x61, 0x74, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0a, 0x43, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x50, 0x61, 0x74, 0x68, 0x12, 0x40, 0x0a,
-	0x11, 0x44, 0x72, 0x69, 0x76, 0x65, 0x45, 0x78, 0x70, 0x6f, 0x73, 0x65, 0x50, 0x6f, 0x6c, 0x69,
-	0x63, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x44, 0x72, 0x69, 0x76, 0x65,
-	0x45, 0x78, 0x70, 0x6f, 0x73, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x11, 0x44, 0x72,
-	0x69, 0x76, 0x65, 0x45, 0x78, 0x70, 0x6f, 0x73, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x22,
-	0x48, 0x0a, 0x14, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e,
+	0x49, 0x44, 0x22, 0x7b, 0x0a, 0x0d, 0x53, 0x74, 0x6f, 0x70, 0x56, 0x4d, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x26, 0x0a, 0x0e, 0x54, 0x69, 0x6d, 0x65, 0x6f,
+	0x75, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0e, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12,
+	0x2e, 0x0a, 0x12, 0x47, 0x72, 0x61, 0x63, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x53, 0x65,
+	0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12, 0x47, 0x72, 0x61,
+	0x63, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22,
+	0xa4, 0x01, 0x0a, 0x15, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49,
+	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x20, 0x0a,
+	0x0b, 0x4d, 0x65, 0x6d, 0x46, 0x69, 0x6c, 0x65, 0x50, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x4d, 0x65, 0x6d, 0x46, 0x69, 0x6c, 0x65, 0x50, 0x61, 0x74, 0x68, 0x12,
+	0x22, 0x0a, 0x0c, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x50, 0x61, 0x74, 0x68, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x50,
+	0x61, 0x74, 0x68, 0x12, 0x31, 0x0a, 0x0c, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x54,
+	0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x53, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0c, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x54, 0x79, 0x70, 0x65, 0x22, 0x8c, 0x01, 0x0a, 0x12, 0x4c, 0x6f, 0x61, 0x64, 0x53,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x20, 0x0a,
+	0x0b, 0x4d, 0x65, 0x6d, 0x46, 0x69, 0x6c, 0x65, 0x50, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x4d, 0x65, 0x6d, 0x46, 0x69, 0x6c, 0x65, 0x50, 0x61, 0x74, 0x68, 0x12,
+	0x22, 0x0a, 0x0c, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x50, 0x61, 0x74, 0x68, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x50,
+	0x61, 0x74, 0x68, 0x12, 0x30, 0x0a, 0x13, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x44, 0x69, 0x66,
+	0x66, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x13, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x44, 0x69, 0x66, 0x66, 0x53, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x73, 0x22, 0x65, 0x0a, 0x17, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x44,
+	0x72, 0x69, 0x76, 0x65, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x56, 0x4d, 0x49, 0x44, 0x12, 0x36, 0x0a, 0x0a, 0x44, 0x72, 0x69, 0x76, 0x65, 0x4d, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x46, 0x69, 0x72, 0x65, 0x63,
+	0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x44, 0x72, 0x69, 0x76, 0x65, 0x4d, 0x6f, 0x75, 0x6e, 0x74,
+	0x52, 0x0a, 0x44, 0x72, 0x69, 0x76, 0x65, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x45, 0x0a, 0x17,
+	0x44, 0x65, 0x74, 0x61, 0x63, 0x68, 0x44, 0x72, 0x69, 0x76, 0x65, 0x4d, 0x6f, 0x75, 0x6e, 0x74,
 	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x1c, 0x0a, 0x09, 0x41,
-	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x4d, 0x69, 0x62, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09,
-	0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x4d, 0x69, 0x62, 0x22, 0x2d, 0x0a, 0x17, 0x47, 0x65, 0x74,
-	0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x56,
+	0x4d, 0x50, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x56, 0x4d, 0x50,
+	0x61, 0x74, 0x68, 0x22, 0x95, 0x01, 0x0a, 0x12, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x72,
+	0x69, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d,
+	0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x16,
+	0x0a, 0x06, 0x56, 0x4d, 0x50, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x56, 0x4d, 0x50, 0x61, 0x74, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x44, 0x72, 0x69, 0x76, 0x65, 0x49,
+	0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x44, 0x72, 0x69, 0x76, 0x65, 0x49, 0x44,
+	0x12, 0x39, 0x0a, 0x0b, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63,
+	0x6b, 0x65, 0x72, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x52, 0x0b,
+	0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x22, 0x27, 0x0a, 0x11, 0x4c,
+	0x69, 0x73, 0x74, 0x44, 0x72, 0x69, 0x76, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x56, 0x4d, 0x49, 0x44, 0x22, 0xe3, 0x01, 0x0a, 0x14, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61,
+	0x63, 0x6b, 0x65, 0x72, 0x44, 0x72, 0x69, 0x76, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x18, 0x0a,
+	0x07, 0x44, 0x72, 0x69, 0x76, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x44, 0x72, 0x69, 0x76, 0x65, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x48, 0x6f, 0x73, 0x74, 0x50,
+	0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x48, 0x6f, 0x73, 0x74, 0x50,
+	0x61, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x56, 0x4d, 0x50, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x56, 0x4d, 0x50, 0x61, 0x74, 0x68, 0x12, 0x39, 0x0a, 0x0b, 0x52,
+	0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x52, 0x61,
+	0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x52, 0x0b, 0x52, 0x61, 0x74, 0x65, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x12, 0x22, 0x0a, 0x0c, 0x49, 0x73, 0x52, 0x6f, 0x6f, 0x74,
+	0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x49, 0x73,
+	0x52, 0x6f, 0x6f, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x49, 0x73,
+	0x57, 0x72, 0x69, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a,
+	0x49, 0x73, 0x57, 0x72, 0x69, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x22, 0x43, 0x0a, 0x12, 0x4c, 0x69,
+	0x73, 0x74, 0x44, 0x72, 0x69, 0x76, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x2d, 0x0a, 0x06, 0x44, 0x72, 0x69, 0x76, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x15, 0x2e, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x44, 0x72,
+	0x69, 0x76, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x06, 0x44, 0x72, 0x69, 0x76, 0x65, 0x73, 0x22,
+	0x26, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x56, 0x4d, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x22, 0xc5, 0x02, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x56,
+	0x4d, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49,
+	0x44, 0x12, 0x1e, 0x0a, 0x0a, 0x53, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x50, 0x61, 0x74, 0x68, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x53, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x50, 0x61, 0x74,
+	0x68, 0x12, 0x20, 0x0a, 0x0b, 0x4c, 0x6f, 0x67, 0x46, 0x69, 0x66, 0x6f, 0x50, 0x61, 0x74, 0x68,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x4c, 0x6f, 0x67, 0x46, 0x69, 0x66, 0x6f, 0x50,
+	0x61, 0x74, 0x68, 0x12, 0x28, 0x0a, 0x0f, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x46, 0x69,
+	0x66, 0x6f, 0x50, 0x61, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x4d, 0x65,
+	0x74, 0x72, 0x69, 0x63, 0x73, 0x46, 0x69, 0x66, 0x6f, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1e, 0x0a,
+	0x0a, 0x43, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x50, 0x61, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x43, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1c, 0x0a,
+	0x09, 0x56, 0x53, 0x6f, 0x63, 0x6b, 0x50, 0x61, 0x74, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x56, 0x53, 0x6f, 0x63, 0x6b, 0x50, 0x61, 0x74, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x53,
+	0x68, 0x69, 0x6d, 0x50, 0x49, 0x44, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x53, 0x68,
+	0x69, 0x6d, 0x50, 0x49, 0x44, 0x12, 0x1e, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x65, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x08, 0x2e, 0x56, 0x4d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x38, 0x0a, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x41, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22,
+	0x10, 0x0a, 0x0e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x4d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x22, 0x37, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x4d, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x24, 0x0a, 0x03, 0x56, 0x4d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x12, 0x2e, 0x47, 0x65, 0x74, 0x56, 0x4d, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x03, 0x56, 0x4d, 0x73, 0x22, 0x29, 0x0a, 0x13, 0x47, 0x65,
+	0x74, 0x56, 0x4d, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x56, 0x4d, 0x49, 0x44, 0x22, 0x90, 0x06, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x56, 0x4d, 0x4d,
+	0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38,
+	0x0a, 0x09, 0x46, 0x6c, 0x75, 0x73, 0x68, 0x65, 0x64, 0x41, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x46,
+	0x6c, 0x75, 0x73, 0x68, 0x65, 0x64, 0x41, 0x74, 0x12, 0x2b, 0x0a, 0x04, 0x56, 0x63, 0x70, 0x75,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61,
+	0x63, 0x6b, 0x65, 0x72, 0x56, 0x63, 0x70, 0x75, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52,
+	0x04, 0x56, 0x63, 0x70, 0x75, 0x12, 0x2e, 0x0a, 0x05, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b,
+	0x65, 0x72, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x05,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x39, 0x0a, 0x06, 0x44, 0x72, 0x69, 0x76, 0x65, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x47, 0x65, 0x74, 0x56, 0x4d, 0x4d, 0x65, 0x74,
+	0x72, 0x69, 0x63, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x44, 0x72, 0x69,
+	0x76, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x44, 0x72, 0x69, 0x76, 0x65, 0x73,
+	0x12, 0x28, 0x0a, 0x03, 0x4e, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x4e, 0x65, 0x74, 0x4d, 0x65,
+	0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x03, 0x4e, 0x65, 0x74, 0x12, 0x5a, 0x0a, 0x11, 0x4e, 0x65,
+	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x18,
+	0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x47, 0x65, 0x74, 0x56, 0x4d, 0x4d, 0x65, 0x74,
+	0x72, 0x69, 0x63, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4e, 0x65, 0x74,
+	0x77, 0x6f, 0x72, 0x6b, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x11, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x6e, 0x74, 0x65,
+	0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x12, 0x2e, 0x0a, 0x05, 0x56, 0x73, 0x6f, 0x63, 0x6b, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63,
+	0x6b, 0x65, 0x72, 0x56, 0x73, 0x6f, 0x63, 0x6b, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52,
+	0x05, 0x56, 0x73, 0x6f, 0x63, 0x6b, 0x12, 0x2b, 0x0a, 0x04, 0x4d, 0x6d, 0x64, 0x73, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b,
+	0x65, 0x72, 0x4d, 0x6d, 0x64, 0x73, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x04, 0x4d,
+	0x6d, 0x64, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x53, 0x65, 0x63, 0x63, 0x6f, 0x6d, 0x70, 0x46, 0x61,
+	0x75, 0x6c, 0x74, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x53, 0x65, 0x63, 0x63,
+	0x6f, 0x6d, 0x70, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x38, 0x0a, 0x09, 0x4c, 0x61, 0x74,
+	0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x46,
+	0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63,
+	0x79, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x09, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63,
+	0x69, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x13, 0x53, 0x74, 0x75, 0x62, 0x44, 0x72, 0x69, 0x76, 0x65,
+	0x73, 0x45, 0x78, 0x68, 0x61, 0x75, 0x73, 0x74, 0x65, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x13, 0x53, 0x74, 0x75, 0x62, 0x44, 0x72, 0x69, 0x76, 0x65, 0x73, 0x45, 0x78, 0x68, 0x61,
+	0x75, 0x73, 0x74, 0x65, 0x64, 0x1a, 0x53, 0x0a, 0x0b, 0x44, 0x72, 0x69, 0x76, 0x65, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2e, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63,
+	0x6b, 0x65, 0x72, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x5c, 0x0a, 0x16, 0x4e, 0x65,
+	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63,
+	0x6b, 0x65, 0x72, 0x4e, 0x65, 0x74, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xb8, 0x01, 0x0a, 0x16, 0x46, 0x69, 0x72,
+	0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x56, 0x63, 0x70, 0x75, 0x4d, 0x65, 0x74, 0x72,
+	0x69, 0x63, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x45, 0x78, 0x69, 0x74, 0x49, 0x4f, 0x49, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x45, 0x78, 0x69, 0x74, 0x49, 0x4f, 0x49, 0x6e, 0x12,
+	0x1c, 0x0a, 0x09, 0x45, 0x78, 0x69, 0x74, 0x49, 0x4f, 0x4f, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x09, 0x45, 0x78, 0x69, 0x74, 0x49, 0x4f, 0x4f, 0x75, 0x74, 0x12, 0x22, 0x0a,
+	0x0c, 0x45, 0x78, 0x69, 0x74, 0x4d, 0x4d, 0x49, 0x4f, 0x52, 0x65, 0x61, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0c, 0x45, 0x78, 0x69, 0x74, 0x4d, 0x4d, 0x49, 0x4f, 0x52, 0x65, 0x61,
+	0x64, 0x12, 0x24, 0x0a, 0x0d, 0x45, 0x78, 0x69, 0x74, 0x4d, 0x4d, 0x49, 0x4f, 0x57, 0x72, 0x69,
+	0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x45, 0x78, 0x69, 0x74, 0x4d, 0x4d,
+	0x49, 0x4f, 0x57, 0x72, 0x69, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x46, 0x61, 0x69, 0x6c, 0x75,
+	0x72, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x46, 0x61, 0x69, 0x6c, 0x75,
+	0x72, 0x65, 0x73, 0x22, 0xc3, 0x02, 0x0a, 0x17, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63,
+	0x6b, 0x65, 0x72, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x12,
+	0x1c, 0x0a, 0x09, 0x52, 0x65, 0x61, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x09, 0x52, 0x65, 0x61, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1e, 0x0a,
+	0x0a, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0a, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1c, 0x0a,
+	0x09, 0x52, 0x65, 0x61, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x09, 0x52, 0x65, 0x61, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x57,
+	0x72, 0x69, 0x74, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0a, 0x57, 0x72, 0x69, 0x74, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x46,
+	0x6c, 0x75, 0x73, 0x68, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0a, 0x46, 0x6c, 0x75, 0x73, 0x68, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x3e, 0x0a, 0x1a, 0x52,
+	0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x54, 0x68, 0x72, 0x6f, 0x74, 0x74,
+	0x6c, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x1a, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x54, 0x68, 0x72, 0x6f,
+	0x74, 0x74, 0x6c, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x45,
+	0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x46, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0c, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x46, 0x61, 0x69, 0x6c, 0x73, 0x12,
+	0x28, 0x0a, 0x0f, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69,
+	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x22, 0xab, 0x02, 0x0a, 0x15, 0x46, 0x69,
+	0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x4e, 0x65, 0x74, 0x4d, 0x65, 0x74, 0x72,
+	0x69, 0x63, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x52, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x52, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1c, 0x0a,
+	0x09, 0x52, 0x78, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x09, 0x52, 0x78, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x52,
+	0x78, 0x46, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x52, 0x78,
+	0x46, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x36, 0x0a, 0x16, 0x52, 0x78, 0x52, 0x61, 0x74, 0x65, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x54, 0x68, 0x72, 0x6f, 0x74, 0x74, 0x6c, 0x65, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x16, 0x52, 0x78, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x65, 0x72, 0x54, 0x68, 0x72, 0x6f, 0x74, 0x74, 0x6c, 0x65, 0x64, 0x12, 0x18, 0x0a,
+	0x07, 0x54, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07,
+	0x54, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x54, 0x78, 0x50, 0x61, 0x63,
+	0x6b, 0x65, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x54, 0x78, 0x50, 0x61,
+	0x63, 0x6b, 0x65, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x54, 0x78, 0x46, 0x61, 0x69, 0x6c, 0x73,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x54, 0x78, 0x46, 0x61, 0x69, 0x6c, 0x73, 0x12,
+	0x36, 0x0a, 0x16, 0x54, 0x78, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72,
+	0x54, 0x68, 0x72, 0x6f, 0x74, 0x74, 0x6c, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x16, 0x54, 0x78, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x54, 0x68,
+	0x72, 0x6f, 0x74, 0x74, 0x6c, 0x65, 0x64, 0x22, 0xef, 0x01, 0x0a, 0x17, 0x46, 0x69, 0x72, 0x65,
+	0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x56, 0x73, 0x6f, 0x63, 0x6b, 0x4d, 0x65, 0x74, 0x72,
+	0x69, 0x63, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x52, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x52, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1c, 0x0a,
+	0x09, 0x52, 0x78, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x09, 0x52, 0x78, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x54,
+	0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x54, 0x78,
+	0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x54, 0x78, 0x50, 0x61, 0x63, 0x6b, 0x65,
+	0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x54, 0x78, 0x50, 0x61, 0x63, 0x6b,
+	0x65, 0x74, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x43, 0x6f, 0x6e, 0x6e, 0x73, 0x41, 0x64, 0x64, 0x65,
+	0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x43, 0x6f, 0x6e, 0x6e, 0x73, 0x41, 0x64,
+	0x64, 0x65, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x43, 0x6f, 0x6e, 0x6e, 0x73, 0x4b, 0x69, 0x6c, 0x6c,
+	0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x43, 0x6f, 0x6e, 0x6e, 0x73, 0x4b,
+	0x69, 0x6c, 0x6c, 0x65, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x43, 0x6f, 0x6e, 0x6e, 0x73, 0x52, 0x65,
+	0x6d, 0x6f, 0x76, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x43, 0x6f, 0x6e,
+	0x6e, 0x73, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x22, 0xc8, 0x01, 0x0a, 0x16, 0x46, 0x69,
+	0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x4d, 0x6d, 0x64, 0x73, 0x4d, 0x65, 0x74,
+	0x72, 0x69, 0x63, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x52, 0x78, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x52, 0x78, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1e,
+	0x0a, 0x0a, 0x52, 0x78, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0a, 0x52, 0x78, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x12, 0x24,
+	0x0a, 0x0d, 0x52, 0x78, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x45, 0x72, 0x72, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x52, 0x78, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65,
+	0x64, 0x45, 0x72, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x54, 0x78, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x54, 0x78, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x18,
+	0x0a, 0x07, 0x54, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x07, 0x54, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x54, 0x78, 0x45, 0x72,
+	0x72, 0x6f, 0x72, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x54, 0x78, 0x45, 0x72,
+	0x72, 0x6f, 0x72, 0x73, 0x22, 0xd5, 0x01, 0x0a, 0x19, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61,
+	0x63, 0x6b, 0x65, 0x72, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x65, 0x74, 0x72, 0x69,
+	0x63, 0x73, 0x12, 0x2e, 0x0a, 0x12, 0x46, 0x75, 0x6c, 0x6c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x12,
+	0x46, 0x75, 0x6c, 0x6c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x12, 0x2e, 0x0a, 0x12, 0x44, 0x69, 0x66, 0x66, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x12,
+	0x44, 0x69, 0x66, 0x66, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x4c, 0x6f, 0x61, 0x64, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x4c, 0x6f, 0x61, 0x64, 0x53, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x50, 0x61, 0x75, 0x73, 0x65, 0x56,
+	0x4d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x50, 0x61, 0x75, 0x73, 0x65, 0x56, 0x4d,
+	0x12, 0x1a, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x56, 0x4d, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x08, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x56, 0x4d, 0x22, 0x46, 0x0a, 0x14,
+	0x53, 0x65, 0x74, 0x56, 0x4d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71,
 	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x22, 0x5b, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x42,
-	0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x0d, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x43,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x46, 0x69,
-	0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e,
-	0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x0d, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x43,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0x2c, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x6c,
-	0x6f, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x22, 0x49, 0x0a, 0x17, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x56, 0x4d,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
 	0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56,
-	0x4d, 0x49, 0x44, 0x22, 0xf5, 0x03, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x6c, 0x6f,
-	0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x1c, 0x0a, 0x09, 0x41, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x4d, 0x69, 0x62, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x09, 0x41, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x4d, 0x69, 0x62, 0x12, 0x20, 0x0a,
-	0x0b, 0x41, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x50, 0x61, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x0b, 0x41, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x50, 0x61, 0x67, 0x65, 0x73, 0x12,
-	0x28, 0x0a, 0x0f, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x4d, 0x65, 0x6d, 0x6f,
-	0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61,
-	0x62, 0x6c, 0x65, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x44, 0x69, 0x73,
-	0x6b, 0x43, 0x61, 0x63, 0x68, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x44,
-	0x69, 0x73, 0x6b, 0x43, 0x61, 0x63, 0x68, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x46, 0x72, 0x65,
-	0x65, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x46,
-	0x72, 0x65, 0x65, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x2e, 0x0a, 0x12, 0x48, 0x75, 0x67,
-	0x65, 0x74, 0x6c, 0x62, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
-	0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x12, 0x48, 0x75, 0x67, 0x65, 0x74, 0x6c, 0x62, 0x41, 0x6c,
-	0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x48, 0x75, 0x67,
-	0x65, 0x74, 0x6c, 0x62, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x0f, 0x48, 0x75, 0x67, 0x65, 0x74, 0x6c, 0x62, 0x46, 0x61, 0x69, 0x6c, 0x75,
-	0x72, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x4d, 0x61, 0x6a, 0x6f, 0x72, 0x46, 0x61, 0x75, 0x6c,
-	0x74, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x4d, 0x61, 0x6a, 0x6f, 0x72, 0x46,
-	0x61, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x4d, 0x69, 0x6e, 0x6f, 0x72, 0x46, 0x61,
-	0x75, 0x6c, 0x74, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x4d, 0x69, 0x6e, 0x6f,
-	0x72, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x77, 0x61, 0x70, 0x49,
-	0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x53, 0x77, 0x61, 0x70, 0x49, 0x6e, 0x12,
-	0x18, 0x0a, 0x07, 0x53, 0x77, 0x61, 0x70, 0x4f, 0x75, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x07, 0x53, 0x77, 0x61, 0x70, 0x4f, 0x75, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x54, 0x61, 0x72,
-	0x67, 0x65, 0x74, 0x4d, 0x69, 0x62, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x54, 0x61,
-	0x72, 0x67, 0x65, 0x74, 0x4d, 0x69, 0x62, 0x12, 0x20, 0x0a, 0x0b, 0x54, 0x61, 0x72, 0x67, 0x65,
-	0x74, 0x50, 0x61, 0x67, 0x65, 0x73, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x54, 0x61,
-	0x72, 0x67, 0x65, 0x74, 0x50, 0x61, 0x67, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x54, 0x6f, 0x74,
-	0x61, 0x6c, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b,
-	0x54, 0x6f, 0x74, 0x61, 0x6c, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x22, 0x65, 0x0a, 0x19, 0x55,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x34, 0x0a, 0x15,
-	0x53, 0x74, 0x61, 0x74, 0x73, 0x50, 0x6f, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x74, 0x65,
-	0x72, 0x76, 0x61, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x15, 0x53, 0x74, 0x61,
+	0x4d, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22,
+	0x2a, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x56, 0x4d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x22, 0x33, 0x0a, 0x15, 0x47,
+	0x65, 0x74, 0x56, 0x4d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x22, 0xed, 0x02, 0x0a, 0x0c, 0x4a, 0x61, 0x69, 0x6c, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x12, 0x14, 0x0a, 0x05, 0x4e, 0x65, 0x74, 0x4e, 0x53, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x4e, 0x65, 0x74, 0x4e, 0x53, 0x12, 0x12, 0x0a, 0x04, 0x43, 0x50, 0x55, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x43, 0x50, 0x55, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x4d,
+	0x65, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4d, 0x65, 0x6d, 0x73, 0x12,
+	0x10, 0x0a, 0x03, 0x55, 0x49, 0x44, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x55, 0x49,
+	0x44, 0x12, 0x10, 0x0a, 0x03, 0x47, 0x49, 0x44, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03,
+	0x47, 0x49, 0x44, 0x12, 0x1e, 0x0a, 0x0a, 0x43, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x50, 0x61, 0x74,
+	0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x43, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x50,
+	0x61, 0x74, 0x68, 0x12, 0x40, 0x0a, 0x11, 0x44, 0x72, 0x69, 0x76, 0x65, 0x45, 0x78, 0x70, 0x6f,
+	0x73, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12,
+	0x2e, 0x44, 0x72, 0x69, 0x76, 0x65, 0x45, 0x78, 0x70, 0x6f, 0x73, 0x65, 0x50, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x52, 0x11, 0x44, 0x72, 0x69, 0x76, 0x65, 0x45, 0x78, 0x70, 0x6f, 0x73, 0x65, 0x50,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x4d, 0x65,
+	0x6d, 0x6f, 0x72, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x4c, 0x69, 0x6d, 0x69,
+	0x74, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x2c, 0x0a, 0x11, 0x4d, 0x65, 0x6d, 0x6f, 0x72,
+	0x79, 0x4f, 0x76, 0x65, 0x72, 0x68, 0x65, 0x61, 0x64, 0x4d, 0x69, 0x62, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x11, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4f, 0x76, 0x65, 0x72, 0x68, 0x65,
+	0x61, 0x64, 0x4d, 0x69, 0x62, 0x12, 0x2b, 0x0a, 0x08, 0x49, 0x4f, 0x4c, 0x69, 0x6d, 0x69, 0x74,
+	0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x4a, 0x61, 0x69, 0x6c, 0x65, 0x72,
+	0x49, 0x4f, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x08, 0x49, 0x4f, 0x4c, 0x69, 0x6d, 0x69,
+	0x74, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x50, 0x69, 0x64, 0x73, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x50, 0x69, 0x64, 0x73, 0x4c, 0x69, 0x6d, 0x69, 0x74,
+	0x22, 0x58, 0x0a, 0x0e, 0x4a, 0x61, 0x69, 0x6c, 0x65, 0x72, 0x49, 0x4f, 0x4c, 0x69, 0x6d, 0x69,
+	0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x06, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x2e, 0x0a, 0x07, 0x44, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x4a, 0x61,
+	0x69, 0x6c, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x4f, 0x4c, 0x69, 0x6d, 0x69,
+	0x74, 0x52, 0x07, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x22, 0xb1, 0x01, 0x0a, 0x13, 0x4a,
+	0x61, 0x69, 0x6c, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x4f, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x50, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x50, 0x61, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x18,
+	0x0a, 0x07, 0x52, 0x65, 0x61, 0x64, 0x42, 0x70, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x07, 0x52, 0x65, 0x61, 0x64, 0x42, 0x70, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x57, 0x72, 0x69, 0x74,
+	0x65, 0x42, 0x70, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x57, 0x72, 0x69, 0x74,
+	0x65, 0x42, 0x70, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x52, 0x65, 0x61, 0x64, 0x49, 0x6f, 0x70, 0x73,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x52, 0x65, 0x61, 0x64, 0x49, 0x6f, 0x70, 0x73,
+	0x12, 0x1c, 0x0a, 0x09, 0x57, 0x72, 0x69, 0x74, 0x65, 0x49, 0x6f, 0x70, 0x73, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x09, 0x57, 0x72, 0x69, 0x74, 0x65, 0x49, 0x6f, 0x70, 0x73, 0x22, 0x48,
+	0x0a, 0x14, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x1c, 0x0a, 0x09, 0x41, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x4d, 0x69, 0x62, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x41,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x4d, 0x69, 0x62, 0x22, 0xdb, 0x01, 0x0a, 0x1d, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66,
+	0x61, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d,
+	0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x26,
+	0x0a, 0x0e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63,
+	0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x3d, 0x0a, 0x0d, 0x49, 0x6e, 0x52, 0x61, 0x74, 0x65,
+	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x52, 0x61, 0x74, 0x65, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x52, 0x0d, 0x49, 0x6e, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69,
+	0x6d, 0x69, 0x74, 0x65, 0x72, 0x12, 0x3f, 0x0a, 0x0e, 0x4f, 0x75, 0x74, 0x52, 0x61, 0x74, 0x65,
+	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x52, 0x61, 0x74, 0x65, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x52, 0x0e, 0x4f, 0x75, 0x74, 0x52, 0x61, 0x74, 0x65, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x22, 0x2d, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6c,
+	0x6c, 0x6f, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x56, 0x4d, 0x49, 0x44, 0x22, 0x5b, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x6c,
+	0x6f, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x3f, 0x0a, 0x0d, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x46, 0x69, 0x72, 0x65, 0x63,
+	0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x44, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x52, 0x0d, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x22, 0x2c, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44,
+	0x22, 0xf5, 0x03, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x09,
+	0x41, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x4d, 0x69, 0x62, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x09, 0x41, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x4d, 0x69, 0x62, 0x12, 0x20, 0x0a, 0x0b, 0x41, 0x63,
+	0x74, 0x75, 0x61, 0x6c, 0x50, 0x61, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0b, 0x41, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x50, 0x61, 0x67, 0x65, 0x73, 0x12, 0x28, 0x0a, 0x0f,
+	0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65,
+	0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x44, 0x69, 0x73, 0x6b, 0x43, 0x61,
+	0x63, 0x68, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x44, 0x69, 0x73, 0x6b,
+	0x43, 0x61, 0x63, 0x68, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x46, 0x72, 0x65, 0x65, 0x4d, 0x65,
+	0x6d, 0x6f, 0x72, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x46, 0x72, 0x65, 0x65,
+	0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x2e, 0x0a, 0x12, 0x48, 0x75, 0x67, 0x65, 0x74, 0x6c,
+	0x62, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x12, 0x48, 0x75, 0x67, 0x65, 0x74, 0x6c, 0x62, 0x41, 0x6c, 0x6c, 0x6f, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x48, 0x75, 0x67, 0x65, 0x74, 0x6c,
+	0x62, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0f, 0x48, 0x75, 0x67, 0x65, 0x74, 0x6c, 0x62, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73,
+	0x12, 0x20, 0x0a, 0x0b, 0x4d, 0x61, 0x6a, 0x6f, 0x72, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x4d, 0x61, 0x6a, 0x6f, 0x72, 0x46, 0x61, 0x75, 0x6c,
+	0x74, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x4d, 0x69, 0x6e, 0x6f, 0x72, 0x46, 0x61, 0x75, 0x6c, 0x74,
+	0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x4d, 0x69, 0x6e, 0x6f, 0x72, 0x46, 0x61,
+	0x75, 0x6c, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x77, 0x61, 0x70, 0x49, 0x6e, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x53, 0x77, 0x61, 0x70, 0x49, 0x6e, 0x12, 0x18, 0x0a, 0x07,
+	0x53, 0x77, 0x61, 0x70, 0x4f, 0x75, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x53,
+	0x77, 0x61, 0x70, 0x4f, 0x75, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x4d, 0x69, 0x62, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x54, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x4d, 0x69, 0x62, 0x12, 0x20, 0x0a, 0x0b, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x50, 0x61,
+	0x67, 0x65, 0x73, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x54, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x50, 0x61, 0x67, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x4d,
+	0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x54, 0x6f, 0x74,
+	0x61, 0x6c, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x22, 0x65, 0x0a, 0x19, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x34, 0x0a, 0x15, 0x53, 0x74, 0x61,
 	0x74, 0x73, 0x50, 0x6f, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61,
-	0x6c, 0x73, 0x2a, 0x27, 0x0a, 0x11, 0x44, 0x72, 0x69, 0x76, 0x65, 0x45, 0x78, 0x70, 0x6f, 0x73,
-	0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x08, 0x0a, 0x04, 0x43, 0x4f, 0x50, 0x59, 0x10,
-	0x00, 0x12, 0x08, 0x0a, 0x04, 0x42, 0x49, 0x4e, 0x44, 0x10, 0x01, 0x42, 0x09, 0x5a, 0x07, 0x2e,
-	0x3b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6c, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x15, 0x53, 0x74, 0x61, 0x74, 0x73, 0x50,
+	0x6f, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x73, 0x22,
+	0x2a, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x73, 0x6f, 0x6c, 0x65, 0x4c, 0x6f, 0x67,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x22, 0x2f, 0x0a, 0x15, 0x47,
+	0x65, 0x74, 0x43, 0x6f, 0x6e, 0x73, 0x6f, 0x6c, 0x65, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x22, 0x68, 0x0a, 0x14,
+	0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x43, 0x6f, 0x6e, 0x73, 0x6f, 0x6c, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x1e, 0x0a, 0x0a, 0x53, 0x74, 0x64, 0x6f,
+	0x75, 0x74, 0x50, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x53, 0x74,
+	0x64, 0x6f, 0x75, 0x74, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x53, 0x74, 0x64, 0x69,
+	0x6e, 0x50, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x53, 0x74, 0x64,
+	0x69, 0x6e, 0x50, 0x61, 0x74, 0x68, 0x22, 0x85, 0x02, 0x0a, 0x0f, 0x45, 0x78, 0x65, 0x63, 0x49,
+	0x6e, 0x56, 0x4d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d,
+	0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x12,
+	0x0a, 0x04, 0x41, 0x72, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x41, 0x72,
+	0x67, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x45, 0x6e, 0x76, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x03, 0x45, 0x6e, 0x76, 0x12, 0x10, 0x0a, 0x03, 0x43, 0x77, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x43, 0x77, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x54, 0x65, 0x72, 0x6d, 0x69, 0x6e,
+	0x61, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x54, 0x65, 0x72, 0x6d, 0x69, 0x6e,
+	0x61, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x57, 0x69, 0x64, 0x74, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x05, 0x57, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x48, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x12, 0x1c, 0x0a, 0x09, 0x53, 0x74, 0x64, 0x69, 0x6e, 0x50, 0x61, 0x74, 0x68, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x53, 0x74, 0x64, 0x69, 0x6e, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1e,
+	0x0a, 0x0a, 0x53, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x50, 0x61, 0x74, 0x68, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x53, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1e,
+	0x0a, 0x0a, 0x53, 0x74, 0x64, 0x65, 0x72, 0x72, 0x50, 0x61, 0x74, 0x68, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x53, 0x74, 0x64, 0x65, 0x72, 0x72, 0x50, 0x61, 0x74, 0x68, 0x22, 0x32,
+	0x0a, 0x10, 0x45, 0x78, 0x65, 0x63, 0x49, 0x6e, 0x56, 0x4d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x45, 0x78, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x45, 0x78, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x22, 0x5f, 0x0a, 0x0f, 0x43, 0x6f, 0x70, 0x79, 0x54, 0x6f, 0x56, 0x4d, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x20, 0x0a, 0x0b, 0x41, 0x72, 0x63,
+	0x68, 0x69, 0x76, 0x65, 0x50, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x50, 0x61, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x56,
+	0x4d, 0x50, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x56, 0x4d, 0x50,
+	0x61, 0x74, 0x68, 0x22, 0x61, 0x0a, 0x11, 0x43, 0x6f, 0x70, 0x79, 0x46, 0x72, 0x6f, 0x6d, 0x56,
+	0x4d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x4d, 0x49, 0x44,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x56, 0x4d, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06,
+	0x56, 0x4d, 0x50, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x56, 0x4d,
+	0x50, 0x61, 0x74, 0x68, 0x12, 0x20, 0x0a, 0x0b, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x50,
+	0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x41, 0x72, 0x63, 0x68, 0x69,
+	0x76, 0x65, 0x50, 0x61, 0x74, 0x68, 0x22, 0xa4, 0x01, 0x0a, 0x0a, 0x4d, 0x4d, 0x44, 0x53, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x26, 0x0a, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x4d, 0x4d, 0x44, 0x53, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a,
+	0x0b, 0x49, 0x50, 0x76, 0x34, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x49, 0x50, 0x76, 0x34, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12,
+	0x30, 0x0a, 0x13, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66,
+	0x61, 0x63, 0x65, 0x49, 0x44, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x13, 0x4e, 0x65,
+	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x49, 0x44,
+	0x73, 0x12, 0x1a, 0x0a, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x33, 0x0a,
+	0x07, 0x56, 0x4d, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x52, 0x65, 0x66, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x52, 0x65, 0x66, 0x12, 0x16, 0x0a, 0x06, 0x44, 0x69,
+	0x67, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x44, 0x69, 0x67, 0x65,
+	0x73, 0x74, 0x2a, 0x22, 0x0a, 0x0c, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x08, 0x0a, 0x04, 0x46, 0x55, 0x4c, 0x4c, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04,
+	0x44, 0x49, 0x46, 0x46, 0x10, 0x01, 0x2a, 0x3d, 0x0a, 0x07, 0x56, 0x4d, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0b,
+	0x0a, 0x07, 0x52, 0x55, 0x4e, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x50,
+	0x41, 0x55, 0x53, 0x45, 0x44, 0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x54, 0x4f, 0x50, 0x50,
+	0x49, 0x4e, 0x47, 0x10, 0x03, 0x2a, 0x27, 0x0a, 0x11, 0x44, 0x72, 0x69, 0x76, 0x65, 0x45, 0x78,
+	0x70, 0x6f, 0x73, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x08, 0x0a, 0x04, 0x43, 0x4f,
+	0x50, 0x59, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x42, 0x49, 0x4e, 0x44, 0x10, 0x01, 0x2a, 0x1d,
+	0x0a, 0x0b, 0x4d, 0x4d, 0x44, 0x53, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x06, 0x0a,
+	0x02, 0x56, 0x31, 0x10, 0x00, 0x12, 0x06, 0x0a, 0x02, 0x56, 0x32, 0x10, 0x01, 0x42, 0x09, 0x5a,
+	0x07, 0x2e, 0x3b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1518,48 +4356,115 @@ func file_firecracker_proto_rawDescGZIP() []byte {
 	return file_firecracker_proto_rawDescData
 }
 
-var file_firecracker_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_firecracker_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_firecracker_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_firecracker_proto_msgTypes = make([]protoimpl.MessageInfo, 50)
 var file_firecracker_proto_goTypes = []interface{}{
-	(DriveExposePolicy)(0),                  // 0: DriveExposePolicy
-	(*CreateVMRequest)(nil),                 // 1: CreateVMRequest
-	(*CreateVMResponse)(nil),                // 2: CreateVMResponse
-	(*PauseVMRequest)(nil),                  // 3: PauseVMRequest
-	(*ResumeVMRequest)(nil),                 // 4: ResumeVMRequest
-	(*StopVMRequest)(nil),                   // 5: StopVMRequest
-	(*GetVMInfoRequest)(nil),                // 6: GetVMInfoRequest
-	(*GetVMInfoResponse)(nil),               // 7: GetVMInfoResponse
-	(*SetVMMetadataRequest)(nil),            // 8: SetVMMetadataRequest
-	(*UpdateVMMetadataRequest)(nil),         // 9: UpdateVMMetadataRequest
-	(*GetVMMetadataRequest)(nil),            // 10: GetVMMetadataRequest
-	(*GetVMMetadataResponse)(nil),           // 11: GetVMMetadataResponse
-	(*JailerConfig)(nil),                    // 12: JailerConfig
-	(*UpdateBalloonRequest)(nil),            // 13: UpdateBalloonRequest
-	(*GetBalloonConfigRequest)(nil),         // 14: GetBalloonConfigRequest
-	(*GetBalloonConfigResponse)(nil),        // 15: GetBalloonConfigResponse
-	(*GetBalloonStatsRequest)(nil),          // 16: GetBalloonStatsRequest
-	(*GetBalloonStatsResponse)(nil),         // 17: GetBalloonStatsResponse
-	(*UpdateBalloonStatsRequest)(nil),       // 18: UpdateBalloonStatsRequest
-	(*FirecrackerMachineConfiguration)(nil), // 19: FirecrackerMachineConfiguration
-	(*FirecrackerRootDrive)(nil),            // 20: FirecrackerRootDrive
-	(*FirecrackerDriveMount)(nil),           // 21: FirecrackerDriveMount
-	(*FirecrackerNetworkInterface)(nil),     // 22: FirecrackerNetworkInterface
-	(*FirecrackerBalloonDevice)(nil),        // 23: FirecrackerBalloonDevice
+	(SnapshotType)(0),                       // 0: SnapshotType
+	(VMState)(0),                            // 1: VMState
+	(DriveExposePolicy)(0),                  // 2: DriveExposePolicy
+	(MMDSVersion)(0),                        // 3: MMDSVersion
+	(*CreateVMRequest)(nil),                 // 4: CreateVMRequest
+	(*CreateVMResponse)(nil),                // 5: CreateVMResponse
+	(*PauseVMRequest)(nil),                  // 6: PauseVMRequest
+	(*ResumeVMRequest)(nil),                 // 7: ResumeVMRequest
+	(*StopVMRequest)(nil),                   // 8: StopVMRequest
+	(*CreateSnapshotRequest)(nil),           // 9: CreateSnapshotRequest
+	(*LoadSnapshotConfig)(nil),              // 10: LoadSnapshotConfig
+	(*AttachDriveMountRequest)(nil),         // 11: AttachDriveMountRequest
+	(*DetachDriveMountRequest)(nil),         // 12: DetachDriveMountRequest
+	(*UpdateDriveRequest)(nil),              // 13: UpdateDriveRequest
+	(*ListDrivesRequest)(nil),               // 14: ListDrivesRequest
+	(*FirecrackerDriveInfo)(nil),            // 15: FirecrackerDriveInfo
+	(*ListDrivesResponse)(nil),              // 16: ListDrivesResponse
+	(*GetVMInfoRequest)(nil),                // 17: GetVMInfoRequest
+	(*GetVMInfoResponse)(nil),               // 18: GetVMInfoResponse
+	(*ListVMsRequest)(nil),                  // 19: ListVMsRequest
+	(*ListVMsResponse)(nil),                 // 20: ListVMsResponse
+	(*GetVMMetricsRequest)(nil),             // 21: GetVMMetricsRequest
+	(*GetVMMetricsResponse)(nil),            // 22: GetVMMetricsResponse
+	(*FirecrackerVcpuMetrics)(nil),          // 23: FirecrackerVcpuMetrics
+	(*FirecrackerBlockMetrics)(nil),         // 24: FirecrackerBlockMetrics
+	(*FirecrackerNetMetrics)(nil),           // 25: FirecrackerNetMetrics
+	(*FirecrackerVsockMetrics)(nil),         // 26: FirecrackerVsockMetrics
+	(*FirecrackerMmdsMetrics)(nil),          // 27: FirecrackerMmdsMetrics
+	(*FirecrackerLatencyMetrics)(nil),       // 28: FirecrackerLatencyMetrics
+	(*SetVMMetadataRequest)(nil),            // 29: SetVMMetadataRequest
+	(*UpdateVMMetadataRequest)(nil),         // 30: UpdateVMMetadataRequest
+	(*GetVMMetadataRequest)(nil),            // 31: GetVMMetadataRequest
+	(*GetVMMetadataResponse)(nil),           // 32: GetVMMetadataResponse
+	(*JailerConfig)(nil),                    // 33: JailerConfig
+	(*JailerIOLimits)(nil),                  // 34: JailerIOLimits
+	(*JailerDeviceIOLimit)(nil),             // 35: JailerDeviceIOLimit
+	(*UpdateBalloonRequest)(nil),            // 36: UpdateBalloonRequest
+	(*UpdateNetworkInterfaceRequest)(nil),   // 37: UpdateNetworkInterfaceRequest
+	(*GetBalloonConfigRequest)(nil),         // 38: GetBalloonConfigRequest
+	(*GetBalloonConfigResponse)(nil),        // 39: GetBalloonConfigResponse
+	(*GetBalloonStatsRequest)(nil),          // 40: GetBalloonStatsRequest
+	(*GetBalloonStatsResponse)(nil),         // 41: GetBalloonStatsResponse
+	(*UpdateBalloonStatsRequest)(nil),       // 42: UpdateBalloonStatsRequest
+	(*GetConsoleLogRequest)(nil),            // 43: GetConsoleLogRequest
+	(*GetConsoleLogResponse)(nil),           // 44: GetConsoleLogResponse
+	(*AttachConsoleRequest)(nil),            // 45: AttachConsoleRequest
+	(*ExecInVMRequest)(nil),                 // 46: ExecInVMRequest
+	(*ExecInVMResponse)(nil),                // 47: ExecInVMResponse
+	(*CopyToVMRequest)(nil),                 // 48: CopyToVMRequest
+	(*CopyFromVMRequest)(nil),               // 49: CopyFromVMRequest
+	(*MMDSConfig)(nil),                      // 50: MMDSConfig
+	(*VMImage)(nil),                         // 51: VMImage
+	nil,                                     // 52: GetVMMetricsResponse.DrivesEntry
+	nil,                                     // 53: GetVMMetricsResponse.NetworkInterfacesEntry
+	(*FirecrackerMachineConfiguration)(nil), // 54: FirecrackerMachineConfiguration
+	(*FirecrackerRootDrive)(nil),            // 55: FirecrackerRootDrive
+	(*FirecrackerDriveMount)(nil),           // 56: FirecrackerDriveMount
+	(*FirecrackerNetworkInterface)(nil),     // 57: FirecrackerNetworkInterface
+	(*FirecrackerBalloonDevice)(nil),        // 58: FirecrackerBalloonDevice
+	(*RestartPolicy)(nil),                   // 59: RestartPolicy
+	(*FirecrackerRateLimiter)(nil),          // 60: FirecrackerRateLimiter
+	(*timestamp.Timestamp)(nil),             // 61: google.protobuf.Timestamp
 }
 var file_firecracker_proto_depIdxs = []int32{
-	19, // 0: CreateVMRequest.MachineCfg:type_name -> FirecrackerMachineConfiguration
-	20, // 1: CreateVMRequest.RootDrive:type_name -> FirecrackerRootDrive
-	21, // 2: CreateVMRequest.DriveMounts:type_name -> FirecrackerDriveMount
-	22, // 3: CreateVMRequest.NetworkInterfaces:type_name -> FirecrackerNetworkInterface
-	12, // 4: CreateVMRequest.JailerConfig:type_name -> JailerConfig
-	23, // 5: CreateVMRequest.BalloonDevice:type_name -> FirecrackerBalloonDevice
-	0,  // 6: JailerConfig.DriveExposePolicy:type_name -> DriveExposePolicy
-	23, // 7: GetBalloonConfigResponse.BalloonConfig:type_name -> FirecrackerBalloonDevice
-	8,  // [8:8] is the sub-list for method output_type
-	8,  // [8:8] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	54, // 0: CreateVMRequest.MachineCfg:type_name -> FirecrackerMachineConfiguration
+	55, // 1: CreateVMRequest.RootDrive:type_name -> FirecrackerRootDrive
+	56, // 2: CreateVMRequest.DriveMounts:type_name -> FirecrackerDriveMount
+	57, // 3: CreateVMRequest.NetworkInterfaces:type_name -> FirecrackerNetworkInterface
+	33, // 4: CreateVMRequest.JailerConfig:type_name -> JailerConfig
+	58, // 5: CreateVMRequest.BalloonDevice:type_name -> FirecrackerBalloonDevice
+	10, // 6: CreateVMRequest.LoadSnapshot:type_name -> LoadSnapshotConfig
+	50, // 7: CreateVMRequest.MMDSConfig:type_name -> MMDSConfig
+	51, // 8: CreateVMRequest.KernelImage:type_name -> VMImage
+	51, // 9: CreateVMRequest.RootDriveImage:type_name -> VMImage
+	59, // 10: CreateVMRequest.RestartPolicy:type_name -> RestartPolicy
+	0,  // 11: CreateSnapshotRequest.SnapshotType:type_name -> SnapshotType
+	56, // 12: AttachDriveMountRequest.DriveMount:type_name -> FirecrackerDriveMount
+	60, // 13: UpdateDriveRequest.RateLimiter:type_name -> FirecrackerRateLimiter
+	60, // 14: FirecrackerDriveInfo.RateLimiter:type_name -> FirecrackerRateLimiter
+	15, // 15: ListDrivesResponse.Drives:type_name -> FirecrackerDriveInfo
+	1,  // 16: GetVMInfoResponse.State:type_name -> VMState
+	61, // 17: GetVMInfoResponse.CreatedAt:type_name -> google.protobuf.Timestamp
+	18, // 18: ListVMsResponse.VMs:type_name -> GetVMInfoResponse
+	61, // 19: GetVMMetricsResponse.FlushedAt:type_name -> google.protobuf.Timestamp
+	23, // 20: GetVMMetricsResponse.Vcpu:type_name -> FirecrackerVcpuMetrics
+	24, // 21: GetVMMetricsResponse.Block:type_name -> FirecrackerBlockMetrics
+	52, // 22: GetVMMetricsResponse.Drives:type_name -> GetVMMetricsResponse.DrivesEntry
+	25, // 23: GetVMMetricsResponse.Net:type_name -> FirecrackerNetMetrics
+	53, // 24: GetVMMetricsResponse.NetworkInterfaces:type_name -> GetVMMetricsResponse.NetworkInterfacesEntry
+	26, // 25: GetVMMetricsResponse.Vsock:type_name -> FirecrackerVsockMetrics
+	27, // 26: GetVMMetricsResponse.Mmds:type_name -> FirecrackerMmdsMetrics
+	28, // 27: GetVMMetricsResponse.Latencies:type_name -> FirecrackerLatencyMetrics
+	2,  // 28: JailerConfig.DriveExposePolicy:type_name -> DriveExposePolicy
+	34, // 29: JailerConfig.IOLimits:type_name -> JailerIOLimits
+	35, // 30: JailerIOLimits.Devices:type_name -> JailerDeviceIOLimit
+	60, // 31: UpdateNetworkInterfaceRequest.InRateLimiter:type_name -> FirecrackerRateLimiter
+	60, // 32: UpdateNetworkInterfaceRequest.OutRateLimiter:type_name -> FirecrackerRateLimiter
+	58, // 33: GetBalloonConfigResponse.BalloonConfig:type_name -> FirecrackerBalloonDevice
+	3,  // 34: MMDSConfig.Version:type_name -> MMDSVersion
+	24, // 35: GetVMMetricsResponse.DrivesEntry.value:type_name -> FirecrackerBlockMetrics
+	25, // 36: GetVMMetricsResponse.NetworkInterfacesEntry.value:type_name -> FirecrackerNetMetrics
+	37, // [37:37] is the sub-list for method output_type
+	37, // [37:37] is the sub-list for method input_type
+	37, // [37:37] is the sub-list for extension type_name
+	37, // [37:37] is the sub-list for extension extendee
+	0,  // [0:37] is the sub-list for field type_name
 }
 
 func init() { file_firecracker_proto_init() }
@@ -1581,8 +4486,104 @@ func file_firecracker_proto_init() {
 				return nil
 			}
 		}
-		file_firecracker_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateVMResponse); i {
+		file_firecracker_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateVMResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PauseVMRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResumeVMRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StopVMRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateSnapshotRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LoadSnapshotConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AttachDriveMountRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DetachDriveMountRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateDriveRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1593,8 +4594,8 @@ func file_firecracker_proto_init() {
 				return nil
 			}
 		}
-		file_firecracker_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PauseVMRequest); i {
+		file_firecracker_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListDrivesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1605,8 +4606,8 @@ func file_firecracker_proto_init() {
 				return nil
 			}
 		}
-		file_firecracker_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResumeVMRequest); i {
+		file_firecracker_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FirecrackerDriveInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1617,8 +4618,8 @@ func file_firecracker_proto_init() {
 				return nil
 			}
 		}
-		file_firecracker_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StopVMRequest); i {
+		file_firecracker_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListDrivesResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1629,7 +4630,7 @@ func file_firecracker_proto_init() {
 				return nil
 			}
 		}
-		file_firecracker_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+		file_firecracker_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetVMInfoRequest); i {
 			case 0:
 				return &v.state
@@ -1641,7 +4642,7 @@ func file_firecracker_proto_init() {
 				return nil
 			}
 		}
-		file_firecracker_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+		file_firecracker_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetVMInfoResponse); i {
 			case 0:
 				return &v.state
@@ -1653,7 +4654,127 @@ func file_firecracker_proto_init() {
 				return nil
 			}
 		}
-		file_firecracker_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+		file_firecracker_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListVMsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListVMsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetVMMetricsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetVMMetricsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FirecrackerVcpuMetrics); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FirecrackerBlockMetrics); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FirecrackerNetMetrics); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FirecrackerVsockMetrics); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FirecrackerMmdsMetrics); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FirecrackerLatencyMetrics); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SetVMMetadataRequest); i {
 			case 0:
 				return &v.state
@@ -1665,7 +4786,7 @@ func file_firecracker_proto_init() {
 				return nil
 			}
 		}
-		file_firecracker_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+		file_firecracker_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*UpdateVMMetadataRequest); i {
 			case 0:
 				return &v.state
@@ -1677,7 +4798,7 @@ func file_firecracker_proto_init() {
 				return nil
 			}
 		}
-		file_firecracker_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+		file_firecracker_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetVMMetadataRequest); i {
 			case 0:
 				return &v.state
@@ -1689,7 +4810,7 @@ func file_firecracker_proto_init() {
 				return nil
 			}
 		}
-		file_firecracker_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+		file_firecracker_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetVMMetadataResponse); i {
 			case 0:
 				return &v.state
@@ -1701,7 +4822,7 @@ func file_firecracker_proto_init() {
 				return nil
 			}
 		}
-		file_firecracker_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+		file_firecracker_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*JailerConfig); i {
 			case 0:
 				return &v.state
@@ -1713,7 +4834,31 @@ func file_firecracker_proto_init() {
 				return nil
 			}
 		}
-		file_firecracker_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+		file_firecracker_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JailerIOLimits); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JailerDeviceIOLimit); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*UpdateBalloonRequest); i {
 			case 0:
 				return &v.state
@@ -1725,7 +4870,19 @@ func file_firecracker_proto_init() {
 				return nil
 			}
 		}
-		file_firecracker_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+		file_firecracker_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateNetworkInterfaceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetBalloonConfigRequest); i {
 			case 0:
 				return &v.state
@@ -1737,7 +4894,7 @@ func file_firecracker_proto_init() {
 				return nil
 			}
 		}
-		file_firecracker_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+		file_firecracker_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetBalloonConfigResponse); i {
 			case 0:
 				return &v.state
@@ -1749,7 +4906,7 @@ func file_firecracker_proto_init() {
 				return nil
 			}
 		}
-		file_firecracker_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+		file_firecracker_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetBalloonStatsRequest); i {
 			case 0:
 				return &v.state
@@ -1761,7 +4918,7 @@ func file_firecracker_proto_init() {
 				return nil
 			}
 		}
-		file_firecracker_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+		file_firecracker_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetBalloonStatsResponse); i {
 			case 0:
 				return &v.state
@@ -1773,7 +4930,7 @@ func file_firecracker_proto_init() {
 				return nil
 			}
 		}
-		file_firecracker_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+		file_firecracker_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*UpdateBalloonStatsRequest); i {
 			case 0:
 				return &v.state
@@ -1785,14 +4942,122 @@ func file_firecracker_proto_init() {
 				return nil
 			}
 		}
+		file_firecracker_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetConsoleLogRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetConsoleLogResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AttachConsoleRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExecInVMRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExecInVMResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CopyToVMRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CopyFromVMRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MMDSConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_firecracker_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VMImage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_firecracker_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   18,
+			NumEnums:      4,
+			NumMessages:   50,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,44 @@
+// Code generated by protoc-gen-go-ttrpc. DO NOT EDIT.
+// source: agenthealth.proto
+package agenthealth
+
+import (
+	context "context"
+	ttrpc "github.com/containerd/ttrpc"
+)
+
+type AgentHealthService interface {
+	Ping(context.Context, *PingRequest) (*PingResponse, error)
+}
+
+func RegisterAgentHealthService(srv *ttrpc.Server, svc AgentHealthService) {
+	srv.RegisterService("AgentHealth", &ttrpc.ServiceDesc{
+		Methods: map[string]ttrpc.Method{
+			"Ping": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+				var req PingRequest
+				if err := unmarshal(&req); err != nil {
+					return nil, err
+				}
+				return svc.Ping(ctx, &req)
+			},
+		},
+	})
+}
+
+type agenthealthClient struct {
+	client *ttrpc.Client
+}
+
+func NewAgentHealthClient(client *ttrpc.Client) AgentHealthService {
+	return &agenthealthClient{
+		client: client,
+	}
+}
+
+func (c *agenthealthClient) Ping(ctx context.Context, req *PingRequest) (*PingResponse, error) {
+	var resp PingResponse
+	if err := c.client.Call(ctx, "AgentHealth", "Ping", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -28,7 +28,7 @@ var file_fccontrol_proto_rawDesc = []byte{
 	0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
 	0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x11,
 	0x66, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x32, 0xed, 0x05, 0x0a, 0x0b, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65,
+	0x6f, 0x32, 0x8c, 0x0c, 0x0a, 0x0b, 0x46, 0x69, 0x72, 0x65, 0x63, 0x72, 0x61, 0x63, 0x6b, 0x65,
 	0x72, 0x12, 0x2f, 0x0a, 0x08, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x4d, 0x12, 0x10, 0x2e,
 	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x4d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
 	0x11, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x4d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
@@ -38,94 +38,188 @@ var file_fccontrol_proto_rawDesc = []byte{
 	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x34, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65,
 	0x56, 0x4d, 0x12, 0x10, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x56, 0x4d, 0x52, 0x65, 0x71,
 	0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x30, 0x0a, 0x06,
-	0x53, 0x74, 0x6f, 0x70, 0x56, 0x4d, 0x12, 0x0e, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x56, 0x4d, 0x52,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x40, 0x0a, 0x0e,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x16,
+	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52,
 	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x32,
-	0x0a, 0x09, 0x47, 0x65, 0x74, 0x56, 0x4d, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x11, 0x2e, 0x47, 0x65,
-	0x74, 0x56, 0x4d, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12,
-	0x2e, 0x47, 0x65, 0x74, 0x56, 0x4d, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x3e, 0x0a, 0x0d, 0x53, 0x65, 0x74, 0x56, 0x4d, 0x4d, 0x65, 0x74, 0x61, 0x64,
-	0x61, 0x74, 0x61, 0x12, 0x15, 0x2e, 0x53, 0x65, 0x74, 0x56, 0x4d, 0x4d, 0x65, 0x74, 0x61, 0x64,
-	0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70,
-	0x74, 0x79, 0x12, 0x44, 0x0a, 0x10, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x56, 0x4d, 0x4d, 0x65,
-	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x18, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x56,
-	0x4d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x3e, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x56,
-	0x4d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x15, 0x2e, 0x47, 0x65, 0x74, 0x56,
-	0x4d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x16, 0x2e, 0x47, 0x65, 0x74, 0x56, 0x4d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x42,
-	0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x18, 0x2e, 0x47,
-	0x65, 0x74, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x6c,
-	0x6f, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x3e, 0x0a, 0x0d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x61, 0x6c, 0x6c, 0x6f,
-	0x6f, 0x6e, 0x12, 0x15, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x61, 0x6c, 0x6c, 0x6f,
-	0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
-	0x79, 0x12, 0x44, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x53,
-	0x74, 0x61, 0x74, 0x73, 0x12, 0x17, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f,
-	0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e,
-	0x47, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x12, 0x55, 0x70, 0x64, 0x61, 0x74,
-	0x65, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x1a, 0x2e,
-	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x53, 0x74, 0x61,
-	0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
-	0x79, 0x42, 0x0d, 0x5a, 0x0b, 0x2e, 0x3b, 0x66, 0x63, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x44,
+	0x0a, 0x10, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x44, 0x72, 0x69, 0x76, 0x65, 0x4d, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x18, 0x2e, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x44, 0x72, 0x69, 0x76, 0x65,
+	0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x12, 0x44, 0x0a, 0x10, 0x44, 0x65, 0x74, 0x61, 0x63, 0x68, 0x44, 0x72,
+	0x69, 0x76, 0x65, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x18, 0x2e, 0x44, 0x65, 0x74, 0x61, 0x63,
+	0x68, 0x44, 0x72, 0x69, 0x76, 0x65, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x3a, 0x0a, 0x0b, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x44, 0x72, 0x69, 0x76, 0x65, 0x12, 0x13, 0x2e, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x44, 0x72, 0x69, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x35, 0x0a, 0x0a, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x72,
+	0x69, 0x76, 0x65, 0x73, 0x12, 0x12, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x72, 0x69, 0x76, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44,
+	0x72, 0x69, 0x76, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a,
+	0x06, 0x53, 0x74, 0x6f, 0x70, 0x56, 0x4d, 0x12, 0x0e, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x56, 0x4d,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12,
+	0x32, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x56, 0x4d, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x11, 0x2e, 0x47,
+	0x65, 0x74, 0x56, 0x4d, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x12, 0x2e, 0x47, 0x65, 0x74, 0x56, 0x4d, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x07, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x4d, 0x73, 0x12, 0x0f,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x4d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x10, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x4d, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x3b, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x56, 0x4d, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63,
+	0x73, 0x12, 0x14, 0x2e, 0x47, 0x65, 0x74, 0x56, 0x4d, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x47, 0x65, 0x74, 0x56, 0x4d, 0x4d,
+	0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e,
+	0x0a, 0x0d, 0x53, 0x65, 0x74, 0x56, 0x4d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12,
+	0x15, 0x2e, 0x53, 0x65, 0x74, 0x56, 0x4d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x44,
+	0x0a, 0x10, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x56, 0x4d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x12, 0x18, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x56, 0x4d, 0x4d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x12, 0x3e, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x56, 0x4d, 0x4d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x15, 0x2e, 0x47, 0x65, 0x74, 0x56, 0x4d, 0x4d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x47,
+	0x65, 0x74, 0x56, 0x4d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x50, 0x0a, 0x16, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4e, 0x65,
+	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x12, 0x1e,
+	0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x6e,
+	0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x47, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6c,
+	0x6c, 0x6f, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x18, 0x2e, 0x47, 0x65, 0x74,
+	0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f,
+	0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x3e, 0x0a, 0x0d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e,
+	0x12, 0x15, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12,
+	0x44, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x12, 0x17, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x47, 0x65,
+	0x74, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x12, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42,
+	0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x1a, 0x2e, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12,
+	0x3e, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x73, 0x6f, 0x6c, 0x65, 0x4c, 0x6f, 0x67,
+	0x12, 0x15, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x73, 0x6f, 0x6c, 0x65, 0x4c, 0x6f, 0x67,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e,
+	0x73, 0x6f, 0x6c, 0x65, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x3e, 0x0a, 0x0d, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x43, 0x6f, 0x6e, 0x73, 0x6f, 0x6c, 0x65,
+	0x12, 0x15, 0x2e, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x43, 0x6f, 0x6e, 0x73, 0x6f, 0x6c, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12,
+	0x2f, 0x0a, 0x08, 0x45, 0x78, 0x65, 0x63, 0x49, 0x6e, 0x56, 0x4d, 0x12, 0x10, 0x2e, 0x45, 0x78,
+	0x65, 0x63, 0x49, 0x6e, 0x56, 0x4d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e,
+	0x45, 0x78, 0x65, 0x63, 0x49, 0x6e, 0x56, 0x4d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x34, 0x0a, 0x08, 0x43, 0x6f, 0x70, 0x79, 0x54, 0x6f, 0x56, 0x4d, 0x12, 0x10, 0x2e, 0x43,
+	0x6f, 0x70, 0x79, 0x54, 0x6f, 0x56, 0x4d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x38, 0x0a, 0x0a, 0x43, 0x6f, 0x70, 0x79, 0x46, 0x72,
+	0x6f, 0x6d, 0x56, 0x4d, 0x12, 0x12, 0x2e, 0x43, 0x6f, 0x70, 0x79, 0x46, 0x72, 0x6f, 0x6d, 0x56,
+	0x4d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x42, 0x0d, 0x5a, 0x0b, 0x2e, 0x3b, 0x66, 0x63, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var file_fccontrol_proto_goTypes = []interface{}{
-	(*proto.CreateVMRequest)(nil),           // 0: CreateVMRequest
-	(*proto.PauseVMRequest)(nil),            // 1: PauseVMRequest
-	(*proto.ResumeVMRequest)(nil),           // 2: ResumeVMRequest
-	(*proto.StopVMRequest)(nil),             // 3: StopVMRequest
-	(*proto.GetVMInfoRequest)(nil),          // 4: GetVMInfoRequest
-	(*proto.SetVMMetadataRequest)(nil),      // 5: SetVMMetadataRequest
-	(*proto.UpdateVMMetadataRequest)(nil),   // 6: UpdateVMMetadataRequest
-	(*proto.GetVMMetadataRequest)(nil),      // 7: GetVMMetadataRequest
-	(*proto.GetBalloonConfigRequest)(nil),   // 8: GetBalloonConfigRequest
-	(*proto.UpdateBalloonRequest)(nil),      // 9: UpdateBalloonRequest
-	(*proto.GetBalloonStatsRequest)(nil),    // 10: GetBalloonStatsRequest
-	(*proto.UpdateBalloonStatsRequest)(nil), // 11: UpdateBalloonStatsRequest
-	(*proto.CreateVMResponse)(nil),          // 12: CreateVMResponse
-	(*empty.Empty)(nil),                     // 13: google.protobuf.Empty
-	(*proto.GetVMInfoResponse)(nil),         // 14: GetVMInfoResponse
-	(*proto.GetVMMetadataResponse)(nil),     // 15: GetVMMetadataResponse
-	(*proto.GetBalloonConfigResponse)(nil),  // 16: GetBalloonConfigResponse
-	(*proto.GetBalloonStatsResponse)(nil),   // 17: GetBalloonStatsResponse
+	(*proto.CreateVMRequest)(nil),               // 0: CreateVMRequest
+	(*proto.PauseVMRequest)(nil),                // 1: PauseVMRequest
+	(*proto.ResumeVMRequest)(nil),               // 2: ResumeVMRequest
+	(*proto.CreateSnapshotRequest)(nil),         // 3: CreateSnapshotRequest
+	(*proto.AttachDriveMountRequest)(nil),       // 4: AttachDriveMountRequest
+	(*proto.DetachDriveMountRequest)(nil),       // 5: DetachDriveMountRequest
+	(*proto.UpdateDriveRequest)(nil),            // 6: UpdateDriveRequest
+	(*proto.ListDrivesRequest)(nil),             // 7: ListDrivesRequest
+	(*proto.StopVMRequest)(nil),                 // 8: StopVMRequest
+	(*proto.GetVMInfoRequest)(nil),              // 9: GetVMInfoRequest
+	(*proto.ListVMsRequest)(nil),                // 10: ListVMsRequest
+	(*proto.GetVMMetricsRequest)(nil),           // 11: GetVMMetricsRequest
+	(*proto.SetVMMetadataRequest)(nil),          // 12: SetVMMetadataRequest
+	(*proto.UpdateVMMetadataRequest)(nil),       // 13: UpdateVMMetadataRequest
+	(*proto.GetVMMetadataRequest)(nil),          // 14: GetVMMetadataRequest
+	(*proto.UpdateNetworkInterfaceRequest)(nil), // 15: UpdateNetworkInterfaceRequest
+	(*proto.GetBalloonConfigRequest)(nil),       // 16: GetBalloonConfigRequest
+	(*proto.UpdateBalloonRequest)(nil),          // 17: UpdateBalloonRequest
+	(*proto.GetBalloonStatsRequest)(nil),        // 18: GetBalloonStatsRequest
+	(*proto.UpdateBalloonStatsRequest)(nil),     // 19: UpdateBalloonStatsRequest
+	(*proto.GetConsoleLogRequest)(nil),          // 20: GetConsoleLogRequest
+	(*proto.AttachConsoleRequest)(nil),          // 21: AttachConsoleRequest
+	(*proto.ExecInVMRequest)(nil),               // 22: ExecInVMRequest
+	(*proto.CopyToVMRequest)(nil),               // 23: CopyToVMRequest
+	(*proto.CopyFromVMRequest)(nil),             // 24: CopyFromVMRequest
+	(*proto.CreateVMResponse)(nil),              // 25: CreateVMResponse
+	(*empty.Empty)(nil),                         // 26: google.protobuf.Empty
+	(*proto.ListDrivesResponse)(nil),            // 27: ListDrivesResponse
+	(*proto.GetVMInfoResponse)(nil),             // 28: GetVMInfoResponse
+	(*proto.ListVMsResponse)(nil),               // 29: ListVMsResponse
+	(*proto.GetVMMetricsResponse)(nil),          // 30: GetVMMetricsResponse
+	(*proto.GetVMMetadataResponse)(nil),         // 31: GetVMMetadataResponse
+	(*proto.GetBalloonConfigResponse)(nil),      // 32: GetBalloonConfigResponse
+	(*proto.GetBalloonStatsResponse)(nil),       // 33: GetBalloonStatsResponse
+	(*proto.GetConsoleLogResponse)(nil),         // 34: GetConsoleLogResponse
+	(*proto.ExecInVMResponse)(nil),              // 35: ExecInVMResponse
 }
 var file_fccontrol_proto_depIdxs = []int32{
 	0,  // 0: Firecracker.CreateVM:input_type -> CreateVMRequest
 	1,  // 1: Firecracker.PauseVM:input_type -> PauseVMRequest
 	2,  // 2: Firecracker.ResumeVM:input_type -> ResumeVMRequest
-	3,  // 3: Firecracker.StopVM:input_type -> StopVMRequest
-	4,  // 4: Firecracker.GetVMInfo:input_type -> GetVMInfoRequest
-	5,  // 5: Firecracker.SetVMMetadata:input_type -> SetVMMetadataRequest
-	6,  // 6: Firecracker.UpdateVMMetadata:input_type -> UpdateVMMetadataRequest
-	7,  // 7: Firecracker.GetVMMetadata:input_type -> GetVMMetadataRequest
-	8,  // 8: Firecracker.GetBalloonConfig:input_type -> GetBalloonConfigRequest
-	9,  // 9: Firecracker.UpdateBalloon:input_type -> UpdateBalloonRequest
-	10, // 10: Firecracker.GetBalloonStats:input_type -> GetBalloonStatsRequest
-	11, // 11: Firecracker.UpdateBalloonStats:input_type -> UpdateBalloonStatsRequest
-	12, // 12: Firecracker.CreateVM:output_type -> CreateVMResponse
-	13, // 13: Firecracker.PauseVM:output_type -> google.protobuf.Empty
-	13, // 14: Firecracker.ResumeVM:output_type -> google.protobuf.Empty
-	13, // 15: Firecracker.StopVM:output_type -> google.protobuf.Empty
-	14, // 16: Firecracker.GetVMInfo:output_type -> GetVMInfoResponse
-	13, // 17: Firecracker.SetVMMetadata:output_type -> google.protobuf.Empty
-	13, // 18: Firecracker.UpdateVMMetadata:output_type -> google.protobuf.Empty
-	15, // 19: Firecracker.GetVMMetadata:output_type -> GetVMMetadataResponse
-	16, // 20: Firecracker.GetBalloonConfig:output_type -> GetBalloonConfigResponse
-	13, // 21: Firecracker.UpdateBalloon:output_type -> google.protobuf.Empty
-	17, // 22: Firecracker.GetBalloonStats:output_type -> GetBalloonStatsResponse
-	13, // 23: Firecracker.UpdateBalloonStats:output_type -> google.protobuf.Empty
-	12, // [12:24] is the sub-list for method output_type
-	0,  // [0:12] is the sub-list for method input_type
+	3,  // 3: Firecracker.CreateSnapshot:input_type -> CreateSnapshotRequest
+	4,  // 4: Firecracker.AttachDriveMount:input_type -> AttachDriveMountRequest
+	5,  // 5: Firecracker.DetachDriveMount:input_type -> DetachDriveMountRequest
+	6,  // 6: Firecracker.UpdateDrive:input_type -> UpdateDriveRequest
+	7,  // 7: Firecracker.ListDrives:input_type -> ListDrivesRequest
+	8,  // 8: Firecracker.StopVM:input_type -> StopVMRequest
+	9,  // 9: Firecracker.GetVMInfo:input_type -> GetVMInfoRequest
+	10, // 10: Firecracker.ListVMs:input_type -> ListVMsRequest
+	11, // 11: Firecracker.GetVMMetrics:input_type -> GetVMMetricsRequest
+	12, // 12: Firecracker.SetVMMetadata:input_type -> SetVMMetadataRequest
+	13, // 13: Firecracker.UpdateVMMetadata:input_type -> UpdateVMMetadataRequest
+	14, // 14: Firecracker.GetVMMetadata:input_type -> GetVMMetadataRequest
+	15, // 15: Firecracker.UpdateNetworkInterface:input_type -> UpdateNetworkInterfaceRequest
+	16, // 16: Firecracker.GetBalloonConfig:input_type -> GetBalloonConfigRequest
+	17, // 17: Firecracker.UpdateBalloon:input_type -> UpdateBalloonRequest
+	18, // 18: Firecracker.GetBalloonStats:input_type -> GetBalloonStatsRequest
+	19, // 19: Firecracker.UpdateBalloonStats:input_type -> UpdateBalloonStatsRequest
+	20, // 20: Firecracker.GetConsoleLog:input_type -> GetConsoleLogRequest
+	21, // 21: Firecracker.AttachConsole:input_type -> AttachConsoleRequest
+	22, // 22: Firecracker.ExecInVM:input_type -> ExecInVMRequest
+	23, // 23: Firecracker.CopyToVM:input_type -> CopyToVMRequest
+	24, // 24: Firecracker.CopyFromVM:input_type -> CopyFromVMRequest
+	25, // 25: Firecracker.CreateVM:output_type -> CreateVMResponse
+	26, // 26: Firecracker.PauseVM:output_type -> google.protobuf.Empty
+	26, // 27: Firecracker.ResumeVM:output_type -> google.protobuf.Empty
+	26, // 28: Firecracker.CreateSnapshot:output_type -> google.protobuf.Empty
+	26, // 29: Firecracker.AttachDriveMount:output_type -> google.protobuf.Empty
+	26, // 30: Firecracker.DetachDriveMount:output_type -> google.protobuf.Empty
+	26, // 31: Firecracker.UpdateDrive:output_type -> google.protobuf.Empty
+	27, // 32: Firecracker.ListDrives:output_type -> ListDrivesResponse
+	26, // 33: Firecracker.StopVM:output_type -> google.protobuf.Empty
+	28, // 34: Firecracker.GetVMInfo:output_type -> GetVMInfoResponse
+	29, // 35: Firecracker.ListVMs:output_type -> ListVMsResponse
+	30, // 36: Firecracker.GetVMMetrics:output_type -> GetVMMetricsResponse
+	26, // 37: Firecracker.SetVMMetadata:output_type -> google.protobuf.Empty
+	26, // 38: Firecracker.UpdateVMMetadata:output_type -> google.protobuf.Empty
+	31, // 39: Firecracker.GetVMMetadata:output_type -> GetVMMetadataResponse
+	26, // 40: Firecracker.UpdateNetworkInterface:output_type -> google.protobuf.Empty
+	32, // 41: Firecracker.GetBalloonConfig:output_type -> GetBalloonConfigResponse
+	26, // 42: Firecracker.UpdateBalloon:output_type -> google.protobuf.Empty
+	33, // 43: Firecracker.GetBalloonStats:output_type -> GetBalloonStatsResponse
+	26, // 44: Firecracker.UpdateBalloonStats:output_type -> google.protobuf.Empty
+	34, // 45: Firecracker.GetConsoleLog:output_type -> GetConsoleLogResponse
+	26, // 46: Firecracker.AttachConsole:output_type -> google.protobuf.Empty
+	35, // 47: Firecracker.ExecInVM:output_type -> ExecInVMResponse
+	26, // 48: Firecracker.CopyToVM:output_type -> google.protobuf.Empty
+	26, // 49: Firecracker.CopyFromVM:output_type -> google.protobuf.Empty
+	25, // [25:50] is the sub-list for method output_type
+	0,  // [0:25] is the sub-list for method input_type
 	0,  // [0:0] is the sub-list for extension type_name
 	0,  // [0:0] is the sub-list for extension extendee
 	0,  // [0:0] is the sub-list for field type_name
@@ -13,15 +13,28 @@ type FirecrackerService interface {
 	CreateVM(context.Context, *proto.CreateVMRequest) (*proto.CreateVMResponse, error)
 	PauseVM(context.Context, *proto.PauseVMRequest) (*empty.Empty, error)
 	ResumeVM(context.Context, *proto.ResumeVMRequest) (*empty.Empty, error)
+	CreateSnapshot(context.Context, *proto.CreateSnapshotRequest) (*empty.Empty, error)
+	AttachDriveMount(context.Context, *proto.AttachDriveMountRequest) (*empty.Empty, error)
+	DetachDriveMount(context.Context, *proto.DetachDriveMountRequest) (*empty.Empty, error)
+	UpdateDrive(context.Context, *proto.UpdateDriveRequest) (*empty.Empty, error)
+	ListDrives(context.Context, *proto.ListDrivesRequest) (*proto.ListDrivesResponse, error)
 	StopVM(context.Context, *proto.StopVMRequest) (*empty.Empty, error)
 	GetVMInfo(context.Context, *proto.GetVMInfoRequest) (*proto.GetVMInfoResponse, error)
+	ListVMs(context.Context, *proto.ListVMsRequest) (*proto.ListVMsResponse, error)
+	GetVMMetrics(context.Context, *proto.GetVMMetricsRequest) (*proto.GetVMMetricsResponse, error)
 	SetVMMetadata(context.Context, *proto.SetVMMetadataRequest) (*empty.Empty, error)
 	UpdateVMMetadata(context.Context, *proto.UpdateVMMetadataRequest) (*empty.Empty, error)
 	GetVMMetadata(context.Context, *proto.GetVMMetadataRequest) (*proto.GetVMMetadataResponse, error)
+	UpdateNetworkInterface(context.Context, *proto.UpdateNetworkInterfaceRequest) (*empty.Empty, error)
 	GetBalloonConfig(context.Context, *proto.GetBalloonConfigRequest) (*proto.GetBalloonConfigResponse, error)
 	UpdateBalloon(context.Context, *proto.UpdateBalloonRequest) (*empty.Empty, error)
 	GetBalloonStats(context.Context, *proto.GetBalloonStatsRequest) (*proto.GetBalloonStatsResponse, error)
 	UpdateBalloonStats(context.Context, *proto.UpdateBalloonStatsRequest) (*empty.Empty, error)
+	GetConsoleLog(context.Context, *proto.GetConsoleLogRequest) (*proto.GetConsoleLogResponse, error)
+	AttachConsole(context.Context, *proto.AttachConsoleRequest) (*empty.Empty, error)
+	ExecInVM(context.Context, *proto.ExecInVMRequest) (*proto.ExecInVMResponse, error)
+	CopyToVM(context.Context, *proto.CopyToVMRequest) (*empty.Empty, error)
+	CopyFromVM(context.Context, *proto.CopyFromVMRequest) (*empty.Empty, error)
 }
 
 func RegisterFirecrackerService(srv *ttrpc.Server, svc FirecrackerService) {
@@ -48,6 +61,41 @@ func RegisterFirecrackerService(srv *ttrpc.Server, svc FirecrackerService) {
 				}
 				return svc.ResumeVM(ctx, &req)
 			},
+			"CreateSnapshot": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+				var req proto.CreateSnapshotRequest
+				if err := unmarshal(&req); err != nil {
+					return nil, err
+				}
+				return svc.CreateSnapshot(ctx, &req)
+			},
+			"AttachDriveMount": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+				var req proto.AttachDriveMountRequest
+				if err := unmarshal(&req); err != nil {
+					return nil, err
+				}
+				return svc.AttachDriveMount(ctx, &req)
+			},
+			"DetachDriveMount": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+				var req proto.DetachDriveMountRequest
+				if err := unmarshal(&req); err != nil {
+					return nil, err
+				}
+				return svc.DetachDriveMount(ctx, &req)
+			},
+			"UpdateDrive": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+				var req proto.UpdateDriveRequest
+				if err := unmarshal(&req); err != nil {
+					return nil, err
+				}
+				return svc.UpdateDrive(ctx, &req)
+			},
+			"ListDrives": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+				var req proto.ListDrivesRequest
+				if err := unmarshal(&req); err != nil {
+					return nil, err
+				}
+				return svc.ListDrives(ctx, &req)
+			},
 			"StopVM": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
 				var req proto.StopVMRequest
 				if err := unmarshal(&req); err != nil {
@@ -62,6 +110,20 @@ func RegisterFirecrackerService(srv *ttrpc.Server, svc FirecrackerService) {
 				}
 				return svc.GetVMInfo(ctx, &req)
 			},
+			"ListVMs": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+				var req proto.ListVMsRequest
+				if err := unmarshal(&req); err != nil {
+					return nil, err
+				}
+				return svc.ListVMs(ctx, &req)
+			},
+			"GetVMMetrics": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+				var req proto.GetVMMetricsRequest
+				if err := unmarshal(&req); err != nil {
+					return nil, err
+				}
+				return svc.GetVMMetrics(ctx, &req)
+			},
 			"SetVMMetadata": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
 				var req proto.SetVMMetadataRequest
 				if err := unmarshal(&req); err != nil {
@@ -83,6 +145,13 @@ func RegisterFirecrackerService(srv *ttrpc.Server, svc FirecrackerService) {
 				}
 				return svc.GetVMMetadata(ctx, &req)
 			},
+			"UpdateNetworkInterface": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+				var req proto.UpdateNetworkInterfaceRequest
+				if err := unmarshal(&req); err != nil {
+					return nil, err
+				}
+				return svc.UpdateNetworkInterface(ctx, &req)
+			},
 			"GetBalloonConfig": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
 				var req proto.GetBalloonConfigRequest
 				if err := unmarshal(&req); err != nil {
@@ -111,6 +180,41 @@ func RegisterFirecrackerService(srv *ttrpc.Server, svc FirecrackerService) {
 				}
 				return svc.UpdateBalloonStats(ctx, &req)
 			},
+			"GetConsoleLog": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+				var req proto.GetConsoleLogRequest
+				if err := unmarshal(&req); err != nil {
+					return nil, err
+				}
+				return svc.GetConsoleLog(ctx, &req)
+			},
+			"AttachConsole": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+				var req proto.AttachConsoleRequest
+				if err := unmarshal(&req); err != nil {
+					return nil, err
+				}
+				return svc.AttachConsole(ctx, &req)
+			},
+			"ExecInVM": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+				var req proto.ExecInVMRequest
+				if err := unmarshal(&req); err != nil {
+					return nil, err
+				}
+				return svc.ExecInVM(ctx, &req)
+			},
+			"CopyToVM": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+				var req proto.CopyToVMRequest
+				if err := unmarshal(&req); err != nil {
+					return nil, err
+				}
+				return svc.CopyToVM(ctx, &req)
+			},
+			"CopyFromVM": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+				var req proto.CopyFromVMRequest
+				if err := unmarshal(&req); err != nil {
+					return nil, err
+				}
+				return svc.CopyFromVM(ctx, &req)
+			},
 		},
 	})
 }
@@ -149,6 +253,46 @@ func (c *firecrackerClient) ResumeVM(ctx context.Context, req *proto.ResumeVMReq
 	return &resp, nil
 }
 
+func (c *firecrackerClient) CreateSnapshot(ctx context.Context, req *proto.CreateSnapshotRequest) (*empty.Empty, error) {
+	var resp empty.Empty
+	if err := c.client.Call(ctx, "Firecracker", "CreateSnapshot", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *firecrackerClient) AttachDriveMount(ctx context.Context, req *proto.AttachDriveMountRequest) (*empty.Empty, error) {
+	var resp empty.Empty
+	if err := c.client.Call(ctx, "Firecracker", "AttachDriveMount", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *firecrackerClient) DetachDriveMount(ctx context.Context, req *proto.DetachDriveMountRequest) (*empty.Empty, error) {
+	var resp empty.Empty
+	if err := c.client.Call(ctx, "Firecracker", "DetachDriveMount", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *firecrackerClient) UpdateDrive(ctx context.Context, req *proto.UpdateDriveRequest) (*empty.Empty, error) {
+	var resp empty.Empty
+	if err := c.client.Call(ctx, "Firecracker", "UpdateDrive", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *firecrackerClient) ListDrives(ctx context.Context, req *proto.ListDrivesRequest) (*proto.ListDrivesResponse, error) {
+	var resp proto.ListDrivesResponse
+	if err := c.client.Call(ctx, "Firecracker", "ListDrives", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 func (c *firecrackerClient) StopVM(ctx context.Context, req *proto.StopVMRequest) (*empty.Empty, error) {
 	var resp empty.Empty
 	if err := c.client.Call(ctx, "Firecracker", "StopVM", req, &resp); err != nil {
@@ -165,6 +309,22 @@ func (c *firecrackerClient) GetVMInfo(ctx context.Context, req *proto.GetVMInfoR
 	return &resp, nil
 }
 
+func (c *firecrackerClient) ListVMs(ctx context.Context, req *proto.ListVMsRequest) (*proto.ListVMsResponse, error) {
+	var resp proto.ListVMsResponse
+	if err := c.client.Call(ctx, "Firecracker", "ListVMs", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *firecrackerClient) GetVMMetrics(ctx context.Context, req *proto.GetVMMetricsRequest) (*proto.GetVMMetricsResponse, error) {
+	var resp proto.GetVMMetricsResponse
+	if err := c.client.Call(ctx, "Firecracker", "GetVMMetrics", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 func (c *firecrackerClient) SetVMMetadata(ctx context.Context, req *proto.SetVMMetadataRequest) (*empty.Empty, error) {
 	var resp empty.Empty
 	if err := c.client.Call(ctx, "Firecracker", "SetVMMetadata", req, &resp); err != nil {
@@ -189,6 +349,14 @@ func (c *firecrackerClient) GetVMMetadata(ctx context.Context, req *proto.GetVMM
 	return &resp, nil
 }
 
+func (c *firecrackerClient) UpdateNetworkInterface(ctx context.Context, req *proto.UpdateNetworkInterfaceRequest) (*empty.Empty, error) {
+	var resp empty.Empty
+	if err := c.client.Call(ctx, "Firecracker", "UpdateNetworkInterface", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 func (c *firecrackerClient) GetBalloonConfig(ctx context.Context, req *proto.GetBalloonConfigRequest) (*proto.GetBalloonConfigResponse, error) {
 	var resp proto.GetBalloonConfigResponse
 	if err := c.client.Call(ctx, "Firecracker", "GetBalloonConfig", req, &resp); err != nil {
@@ -220,3 +388,43 @@ func (c *firecrackerClient) UpdateBalloonStats(ctx context.Context, req *proto.U
 	}
 	return &resp, nil
 }
+
+func (c *firecrackerClient) GetConsoleLog(ctx context.Context, req *proto.GetConsoleLogRequest) (*proto.GetConsoleLogResponse, error) {
+	var resp proto.GetConsoleLogResponse
+	if err := c.client.Call(ctx, "Firecracker", "GetConsoleLog", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *firecrackerClient) AttachConsole(ctx context.Context, req *proto.AttachConsoleRequest) (*empty.Empty, error) {
+	var resp empty.Empty
+	if err := c.client.Call(ctx, "Firecracker", "AttachConsole", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *firecrackerClient) ExecInVM(ctx context.Context, req *proto.ExecInVMRequest) (*proto.ExecInVMResponse, error) {
+	var resp proto.ExecInVMResponse
+	if err := c.client.Call(ctx, "Firecracker", "ExecInVM", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *firecrackerClient) CopyToVM(ctx context.Context, req *proto.CopyToVMRequest) (*empty.Empty, error) {
+	var resp empty.Empty
+	if err := c.client.Call(ctx, "Firecracker", "CopyToVM", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *firecrackerClient) CopyFromVM(ctx context.Context, req *proto.CopyFromVMRequest) (*empty.Empty, error) {
+	var resp empty.Empty
+	if err := c.client.Call(ctx, "Firecracker", "CopyFromVM", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
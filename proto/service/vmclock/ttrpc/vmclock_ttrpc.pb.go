@@ -0,0 +1,44 @@
+// Code generated by protoc-gen-go-ttrpc. DO NOT EDIT.
+// source: vmclock.proto
+package vmclock
+
+import (
+	context "context"
+	ttrpc "github.com/containerd/ttrpc"
+)
+
+type VMClockService interface {
+	SyncClock(context.Context, *SyncClockRequest) (*SyncClockResponse, error)
+}
+
+func RegisterVMClockService(srv *ttrpc.Server, svc VMClockService) {
+	srv.RegisterService("VMClock", &ttrpc.ServiceDesc{
+		Methods: map[string]ttrpc.Method{
+			"SyncClock": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+				var req SyncClockRequest
+				if err := unmarshal(&req); err != nil {
+					return nil, err
+				}
+				return svc.SyncClock(ctx, &req)
+			},
+		},
+	})
+}
+
+type vmclockClient struct {
+	client *ttrpc.Client
+}
+
+func NewVMClockClient(client *ttrpc.Client) VMClockService {
+	return &vmclockClient{
+		client: client,
+	}
+}
+
+func (c *vmclockClient) SyncClock(ctx context.Context, req *SyncClockRequest) (*SyncClockResponse, error) {
+	var resp SyncClockResponse
+	if err := c.client.Call(ctx, "VMClock", "SyncClock", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -0,0 +1,61 @@
+// Code generated by protoc-gen-go-ttrpc. DO NOT EDIT.
+// source: vmcopy.proto
+package vmcopy
+
+import (
+	context "context"
+	ttrpc "github.com/containerd/ttrpc"
+	empty "github.com/golang/protobuf/ptypes/empty"
+)
+
+type VMCopyService interface {
+	Extract(context.Context, *ExtractRequest) (*empty.Empty, error)
+	Archive(context.Context, *ArchiveRequest) (*empty.Empty, error)
+}
+
+func RegisterVMCopyService(srv *ttrpc.Server, svc VMCopyService) {
+	srv.RegisterService("VMCopy", &ttrpc.ServiceDesc{
+		Methods: map[string]ttrpc.Method{
+			"Extract": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+				var req ExtractRequest
+				if err := unmarshal(&req); err != nil {
+					return nil, err
+				}
+				return svc.Extract(ctx, &req)
+			},
+			"Archive": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+				var req ArchiveRequest
+				if err := unmarshal(&req); err != nil {
+					return nil, err
+				}
+				return svc.Archive(ctx, &req)
+			},
+		},
+	})
+}
+
+type vmcopyClient struct {
+	client *ttrpc.Client
+}
+
+func NewVMCopyClient(client *ttrpc.Client) VMCopyService {
+	return &vmcopyClient{
+		client: client,
+	}
+}
+
+func (c *vmcopyClient) Extract(ctx context.Context, req *ExtractRequest) (*empty.Empty, error) {
+	var resp empty.Empty
+	if err := c.client.Call(ctx, "VMCopy", "Extract", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *vmcopyClient) Archive(ctx context.Context, req *ArchiveRequest) (*empty.Empty, error) {
+	var resp empty.Empty
+	if err := c.client.Call(ctx, "VMCopy", "Archive", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
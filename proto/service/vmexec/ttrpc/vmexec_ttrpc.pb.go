@@ -0,0 +1,44 @@
+// Code generated by protoc-gen-go-ttrpc. DO NOT EDIT.
+// source: vmexec.proto
+package vmexec
+
+import (
+	context "context"
+	ttrpc "github.com/containerd/ttrpc"
+)
+
+type VMExecService interface {
+	Exec(context.Context, *ExecRequest) (*ExecResponse, error)
+}
+
+func RegisterVMExecService(srv *ttrpc.Server, svc VMExecService) {
+	srv.RegisterService("VMExec", &ttrpc.ServiceDesc{
+		Methods: map[string]ttrpc.Method{
+			"Exec": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+				var req ExecRequest
+				if err := unmarshal(&req); err != nil {
+					return nil, err
+				}
+				return svc.Exec(ctx, &req)
+			},
+		},
+	})
+}
+
+type vmexecClient struct {
+	client *ttrpc.Client
+}
+
+func NewVMExecClient(client *ttrpc.Client) VMExecService {
+	return &vmexecClient{
+		client: client,
+	}
+}
+
+func (c *vmexecClient) Exec(ctx context.Context, req *ExecRequest) (*ExecResponse, error) {
+	var resp ExecResponse
+	if err := c.client.Call(ctx, "VMExec", "Exec", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -0,0 +1,121 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+	agenthealth "github.com/firecracker-microvm/firecracker-containerd/proto/service/agenthealth/ttrpc"
+)
+
+const defaultAgentMaxMissedPings = 3
+
+// agentWatchdog pings the agent periodically, which detects a guest that stopped serving requests
+// while its vsock connection is still open, e.g. because its kernel hung.
+type agentWatchdog struct {
+	interval       time.Duration
+	timeout        time.Duration
+	maxMissedPings uint32
+
+	ping func(ctx context.Context) error
+	// ignoreMiss reports whether a missed ping is expected, e.g. because the VM is paused
+	ignoreMiss func(ctx context.Context) bool
+	// unhealthy is called once the agent missed maxMissedPings consecutive pings. It is called
+	// again only after the agent answered a ping in between.
+	unhealthy func(missedPings uint32, err error)
+}
+
+// run pings the agent until ctx is canceled.
+func (w *agentWatchdog) run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	var missed uint32
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		pingCtx, cancel := context.WithTimeout(ctx, w.timeout)
+		err := w.ping(pingCtx)
+		cancel()
+		if err == nil {
+			missed = 0
+			continue
+		}
+		if ctx.Err() != nil || w.ignoreMiss(ctx) {
+			continue
+		}
+
+		missed++
+		if missed == w.maxMissedPings {
+			w.unhealthy(missed, err)
+		}
+	}
+}
+
+// watchAgent runs the agent watchdog of the VM, if it is enabled, until the shim exits.
+func (s *service) watchAgent() {
+	cfg := s.config.AgentWatchdog
+	if cfg.IntervalSeconds <= 0 {
+		return
+	}
+
+	w := &agentWatchdog{
+		interval:       time.Duration(cfg.IntervalSeconds) * time.Second,
+		timeout:        time.Duration(cfg.TimeoutSeconds) * time.Second,
+		maxMissedPings: uint32(cfg.MaxMissedPings),
+		ping: func(ctx context.Context) error {
+			_, err := s.vm().agentHealthClient.Ping(ctx, &agenthealth.PingRequest{})
+			return err
+		},
+		ignoreMiss: func(ctx context.Context) bool {
+			// nothing answers the pings while the VM is stopping or being relaunched
+			if s.stopping.Load() || s.restarting.Load() {
+				return true
+			}
+			// the agent of a paused VM cannot answer, and Firecracker failing to report the
+			// state of the VM doesn't make the agent any healthier
+			paused, err := s.isPaused(ctx)
+			return err == nil && paused
+		},
+		unhealthy: func(missedPings uint32, err error) {
+			reason := fmt.Sprintf("agent missed %d pings: %v", missedPings, err)
+			s.logger.WithError(err).Errorf("agent is unhealthy after missing %d pings", missedPings)
+			s.publishEvent(AgentUnhealthyEventName, &proto.VMAgentUnhealthy{
+				VMID:        s.boundVMID(),
+				Reason:      reason,
+				MissedPings: missedPings,
+			})
+
+			if cfg.ForceTerminate {
+				_ = s.forceTerminate(s.shimCtx, "agent is unhealthy")
+			}
+		},
+	}
+	if w.timeout <= 0 {
+		w.timeout = w.interval
+	}
+	if w.maxMissedPings == 0 {
+		w.maxMissedPings = defaultAgentMaxMissedPings
+	}
+
+	s.logger.WithField("interval", w.interval).Debug("watching the agent")
+	w.run(s.shimCtx)
+}
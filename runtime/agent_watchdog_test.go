@@ -0,0 +1,80 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAgentWatchdog(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		pings       atomic.Uint32
+		answering   atomic.Bool
+		paused      atomic.Bool
+		unhealthyCh = make(chan uint32, 10)
+	)
+	w := &agentWatchdog{
+		interval:       time.Millisecond,
+		timeout:        time.Millisecond,
+		maxMissedPings: 3,
+		ping: func(context.Context) error {
+			pings.Add(1)
+			if answering.Load() {
+				return nil
+			}
+			return errors.New("timed out")
+		},
+		ignoreMiss: func(context.Context) bool { return paused.Load() },
+		unhealthy: func(missedPings uint32, err error) {
+			unhealthyCh <- missedPings
+		},
+	}
+
+	paused.Store(true)
+	go w.run(ctx)
+
+	assert.Eventually(t, func() bool { return pings.Load() > 10 }, time.Second, time.Millisecond)
+	assert.Empty(t, unhealthyCh, "pings missed while the VM is paused must not count")
+
+	paused.Store(false)
+	select {
+	case missed := <-unhealthyCh:
+		assert.Equal(t, uint32(3), missed)
+	case <-time.After(time.Second):
+		t.Fatal("the agent was not reported unhealthy")
+	}
+
+	before := pings.Load()
+	assert.Eventually(t, func() bool { return pings.Load() > before+10 }, time.Second, time.Millisecond)
+	assert.Empty(t, unhealthyCh, "the agent must be reported unhealthy only once while it keeps missing pings")
+
+	answering.Store(true)
+	before = pings.Load()
+	assert.Eventually(t, func() bool { return pings.Load() > before+2 }, time.Second, time.Millisecond)
+	answering.Store(false)
+	select {
+	case <-unhealthyCh:
+	case <-time.After(time.Second):
+		t.Fatal("the agent was not reported unhealthy again after answering a ping")
+	}
+}
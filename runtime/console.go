@@ -0,0 +1,247 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"syscall"
+
+	"github.com/containerd/containerd/protobuf/types"
+	"github.com/containerd/fifo"
+	"github.com/hashicorp/go-multierror"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+const (
+	// consoleBufferSize is the amount of the latest console output GetConsoleLog returns
+	consoleBufferSize  = 256 * 1024
+	consoleFileMaxSize = 8 * 1024 * 1024
+	// consoleAttachmentBacklog is the number of writes buffered for an attached console before
+	// output is dropped, so that a slow reader never blocks the serial console of the VM
+	consoleAttachmentBacklog = 256
+)
+
+// vmConsole captures the serial console of a VM, which Firecracker connects to its stdio. The
+// output is kept in a bounded buffer, written to a file and copied to the attached clients.
+type vmConsole struct {
+	mu       sync.Mutex
+	buffer   []byte
+	file     *rotatingFile
+	attached map[*consoleAttachment]struct{}
+	// inputAttached is set while an attachment is providing the console input
+	inputAttached bool
+
+	stdinReader *os.File
+	stdinWriter *os.File
+}
+
+type consoleAttachment struct {
+	output    chan []byte
+	closeOnce sync.Once
+}
+
+func newVMConsole(path string) (*vmConsole, error) {
+	file, err := newRotatingFile(path, consoleFileMaxSize, 1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create console log file: %w", err)
+	}
+
+	stdinReader, stdinWriter, err := os.Pipe()
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to create console input pipe: %w", err)
+	}
+
+	return &vmConsole{
+		file:        file,
+		attached:    make(map[*consoleAttachment]struct{}),
+		stdinReader: stdinReader,
+		stdinWriter: stdinWriter,
+	}, nil
+}
+
+// Write captures output of the console. It never fails, so that Firecracker is never blocked
+// on the console.
+func (c *vmConsole) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.buffer = append(c.buffer, p...)
+	if len(c.buffer) > consoleBufferSize {
+		n := copy(c.buffer, c.buffer[len(c.buffer)-consoleBufferSize:])
+		c.buffer = c.buffer[:n]
+	}
+
+	// the console keeps capturing the output even if the file cannot be written
+	_, _ = c.file.Write(p)
+
+	for attachment := range c.attached {
+		select {
+		case attachment.output <- append([]byte(nil), p...):
+		default:
+		}
+	}
+
+	return len(p), nil
+}
+
+// stdin returns the file Firecracker reads the input of the console from.
+func (c *vmConsole) stdin() *os.File {
+	return c.stdinReader
+}
+
+// log returns the latest output of the console.
+func (c *vmConsole) log() []byte {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]byte(nil), c.buffer...)
+}
+
+// attach copies the output of the console to stdout, and stdin to the input of the console if
+// it isn't nil, until either is closed.
+func (c *vmConsole) attach(stdout io.WriteCloser, stdin io.ReadCloser, logger *logrus.Entry) error {
+	attachment := &consoleAttachment{output: make(chan []byte, consoleAttachmentBacklog)}
+
+	c.mu.Lock()
+	if stdin != nil {
+		if c.inputAttached {
+			c.mu.Unlock()
+			return status.Error(codes.FailedPrecondition, "console input is already attached")
+		}
+		c.inputAttached = true
+	}
+	c.attached[attachment] = struct{}{}
+	c.mu.Unlock()
+
+	detach := func() {
+		attachment.closeOnce.Do(func() {
+			c.mu.Lock()
+			delete(c.attached, attachment)
+			if stdin != nil {
+				c.inputAttached = false
+			}
+			c.mu.Unlock()
+
+			close(attachment.output)
+			stdout.Close()
+			if stdin != nil {
+				stdin.Close()
+			}
+			logger.Debug("console detached")
+		})
+	}
+
+	go func() {
+		for p := range attachment.output {
+			if _, err := stdout.Write(p); err != nil {
+				detach()
+			}
+		}
+	}()
+
+	if stdin != nil {
+		go func() {
+			defer detach()
+			if _, err := io.Copy(c.stdinWriter, stdin); err != nil {
+				logger.WithError(err).Debug("failed to copy console input")
+			}
+		}()
+	}
+
+	return nil
+}
+
+func (c *vmConsole) Close() error {
+	var result *multierror.Error
+	if err := c.stdinWriter.Close(); err != nil {
+		result = multierror.Append(result, err)
+	}
+	if err := c.stdinReader.Close(); err != nil {
+		result = multierror.Append(result, err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.file.Close(); err != nil {
+		result = multierror.Append(result, err)
+	}
+	return result.ErrorOrNil()
+}
+
+// GetConsoleLog returns the latest output of the serial console of the VM.
+func (s *service) GetConsoleLog(requestCtx context.Context, request *proto.GetConsoleLogRequest) (*proto.GetConsoleLogResponse, error) {
+	defer logPanicAndDie(s.logger)
+
+	err := s.waitVMReady()
+	if err != nil {
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
+
+	return &proto.GetConsoleLogResponse{Output: s.console.log()}, nil
+}
+
+// AttachConsole connects the FIFOs of the request to the serial console of the VM. It returns
+// once they are connected, and the console stays attached until either is closed.
+func (s *service) AttachConsole(requestCtx context.Context, request *proto.AttachConsoleRequest) (*types.Empty, error) {
+	defer logPanicAndDie(s.logger)
+
+	err := s.waitVMReady()
+	if err != nil {
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
+
+	if request.StdoutPath == "" {
+		return nil, status.Error(codes.InvalidArgument, "no stdout path was specified")
+	}
+
+	stdout, err := fifo.OpenFifo(s.shimCtx, request.StdoutPath, syscall.O_WRONLY|syscall.O_NONBLOCK, 0)
+	if err != nil {
+		err = fmt.Errorf("failed to open console stdout fifo: %w", err)
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
+
+	var stdin io.ReadCloser
+	if request.StdinPath != "" {
+		stdin, err = fifo.OpenFifo(s.shimCtx, request.StdinPath, syscall.O_RDONLY|syscall.O_NONBLOCK, 0)
+		if err != nil {
+			stdout.Close()
+			err = fmt.Errorf("failed to open console stdin fifo: %w", err)
+			s.logger.WithError(err).Error()
+			return nil, err
+		}
+	}
+
+	err = s.console.attach(stdout, stdin, s.logger.WithField("stdout", request.StdoutPath))
+	if err != nil {
+		stdout.Close()
+		if stdin != nil {
+			stdin.Close()
+		}
+		return nil, err
+	}
+
+	s.logger.Debug("console attached")
+	return &types.Empty{}, nil
+}
@@ -0,0 +1,86 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestVMConsoleLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "console.log")
+	console, err := newVMConsole(path)
+	require.NoError(t, err)
+	defer console.Close()
+
+	_, err = console.Write([]byte("boot\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("boot\n"), console.log())
+
+	output := bytes.Repeat([]byte("x"), consoleBufferSize)
+	_, err = console.Write(output)
+	require.NoError(t, err)
+	assert.Equal(t, output, console.log(), "only the latest output must be kept")
+
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, append([]byte("boot\n"), output...), contents)
+}
+
+func TestVMConsoleAttach(t *testing.T) {
+	console, err := newVMConsole(filepath.Join(t.TempDir(), "console.log"))
+	require.NoError(t, err)
+	defer console.Close()
+
+	logger := logrus.NewEntry(logrus.New())
+
+	stdoutReader, stdoutWriter, err := os.Pipe()
+	require.NoError(t, err)
+	defer stdoutReader.Close()
+	stdinReader, stdinWriter, err := os.Pipe()
+	require.NoError(t, err)
+	defer stdinWriter.Close()
+
+	require.NoError(t, console.attach(stdoutWriter, stdinReader, logger))
+
+	otherReader, otherStdout, err := os.Pipe()
+	require.NoError(t, err)
+	defer otherReader.Close()
+	defer otherStdout.Close()
+	err = console.attach(otherStdout, stdinReader, logger)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err), "only one attachment can provide input")
+
+	_, err = console.Write([]byte("login: "))
+	require.NoError(t, err)
+	output := make([]byte, len("login: "))
+	_, err = io.ReadFull(stdoutReader, output)
+	require.NoError(t, err)
+	assert.Equal(t, "login: ", string(output))
+
+	_, err = stdinWriter.Write([]byte("root\n"))
+	require.NoError(t, err)
+	input := make([]byte, len("root\n"))
+	_, err = io.ReadFull(console.stdin(), input)
+	require.NoError(t, err)
+	assert.Equal(t, "root\n", string(input))
+}
@@ -0,0 +1,118 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+
+	"github.com/firecracker-microvm/firecracker-go-sdk"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/firecracker-microvm/firecracker-containerd/internal"
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+const customCPUTemplateHandlerName = "firecracker-containerd-custom-cpu-template"
+
+// customCPUTemplate returns the custom CPU template of the VM, from the request or else from the
+// runtime config, after checking it against the CPU of the host. It returns nil if the VM has no
+// custom CPU template.
+func (s *service) customCPUTemplate(request *proto.CreateVMRequest) (*internal.CustomCPUTemplate, error) {
+	template := request.MachineCfg.GetCustomCPUTemplate()
+	if template != "" {
+		if request.MachineCfg.CPUTemplate != "" {
+			return nil, status.Error(codes.InvalidArgument, "CustomCPUTemplate cannot be set along with CPUTemplate")
+		}
+		if request.LoadSnapshot != nil {
+			return nil, status.Error(codes.InvalidArgument, "CustomCPUTemplate cannot be set when loading a snapshot")
+		}
+	} else if request.MachineCfg.GetCPUTemplate() == "" && request.LoadSnapshot == nil {
+		// the CPU configuration of a snapshotted VM is part of its snapshot
+		template = s.config.CustomCPUTemplate
+	}
+	if template == "" {
+		return nil, nil
+	}
+
+	if !s.firecracker.CustomCPUTemplates {
+		return nil, status.Errorf(codes.FailedPrecondition, "Firecracker %s does not support custom CPU templates", s.firecracker.Version)
+	}
+
+	t, err := internal.LoadCustomCPUTemplate(template)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	cpu, err := internal.DetectHostCPU()
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect the CPU of the host: %w", err)
+	}
+	if err := t.Validate(cpu); err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return t, nil
+}
+
+// newCustomCPUTemplateHandler returns a handler that sets the custom CPU template of the VM once
+// its machine is configured. The SDK does not know the endpoint, so it is called directly.
+func newCustomCPUTemplateHandler(t *internal.CustomCPUTemplate) firecracker.Handler {
+	return firecracker.Handler{
+		Name: customCPUTemplateHandlerName,
+		Fn: func(ctx context.Context, m *firecracker.Machine) error {
+			return putCPUConfig(ctx, m.Cfg.SocketPath, t)
+		},
+	}
+}
+
+// putCPUConfig puts the given custom CPU template to the Firecracker API listening at socketPath.
+func putCPUConfig(ctx context.Context, socketPath string, t *internal.CustomCPUTemplate) error {
+	body, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", socketPath)
+			},
+		},
+	}
+	defer client.CloseIdleConnections()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, "http://localhost/cpu-config", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to put custom CPU template: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("failed to put custom CPU template: %s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+	return nil
+}
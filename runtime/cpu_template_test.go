@@ -0,0 +1,116 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/firecracker-microvm/firecracker-containerd/config"
+	"github.com/firecracker-microvm/firecracker-containerd/internal"
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+func TestCustomCPUTemplateSelection(t *testing.T) {
+	s := &service{
+		config:      &config.Config{CustomCPUTemplate: "{}"},
+		firecracker: internal.CapabilitiesOf(internal.FirecrackerVersion{Major: 1, Minor: 3}),
+	}
+
+	for _, tc := range []struct {
+		name     string
+		request  *proto.CreateVMRequest
+		expected codes.Code
+	}{
+		{
+			name:     "static template",
+			request:  &proto.CreateVMRequest{MachineCfg: &proto.FirecrackerMachineConfiguration{CPUTemplate: "T2"}},
+			expected: codes.OK,
+		},
+		{
+			name:     "snapshot",
+			request:  &proto.CreateVMRequest{LoadSnapshot: &proto.LoadSnapshotConfig{}},
+			expected: codes.OK,
+		},
+		{
+			name: "both templates",
+			request: &proto.CreateVMRequest{MachineCfg: &proto.FirecrackerMachineConfiguration{
+				CPUTemplate:       "T2",
+				CustomCPUTemplate: "{}",
+			}},
+			expected: codes.InvalidArgument,
+		},
+		{
+			name: "custom template with snapshot",
+			request: &proto.CreateVMRequest{
+				MachineCfg:   &proto.FirecrackerMachineConfiguration{CustomCPUTemplate: "{}"},
+				LoadSnapshot: &proto.LoadSnapshotConfig{},
+			},
+			expected: codes.InvalidArgument,
+		},
+		{
+			name:     "default template of an old Firecracker",
+			request:  &proto.CreateVMRequest{},
+			expected: codes.FailedPrecondition,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			template, err := s.customCPUTemplate(tc.request)
+			assert.Equal(t, tc.expected, status.Code(err))
+			assert.Nil(t, template)
+		})
+	}
+}
+
+func TestPutCPUConfig(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "firecracker.sock")
+	listener, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+
+	var received internal.CustomCPUTemplate
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/cpu-config" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		received = internal.CustomCPUTemplate{}
+		if err := json.Unmarshal(body, &received); err != nil || len(received.MSRModifiers) == 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			io.WriteString(w, `{"fault_message": "invalid template"}`)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})}
+	go server.Serve(listener)
+	defer server.Close()
+
+	template := &internal.CustomCPUTemplate{MSRModifiers: []internal.RegisterModifier{{Addr: "0x10a", Bitmap: "0b0"}}}
+	require.NoError(t, putCPUConfig(context.Background(), socketPath, template))
+	assert.Equal(t, *template, received)
+
+	err = putCPUConfig(context.Background(), socketPath, &internal.CustomCPUTemplate{})
+	assert.EqualError(t, err, `failed to put custom CPU template: 400 Bad Request: {"fault_message": "invalid template"}`)
+}
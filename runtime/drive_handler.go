@@ -19,12 +19,14 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"sync"
 
 	"github.com/sirupsen/logrus"
 
 	firecracker "github.com/firecracker-microvm/firecracker-go-sdk"
 	"github.com/firecracker-microvm/firecracker-go-sdk/client/models"
+	ops "github.com/firecracker-microvm/firecracker-go-sdk/client/operations"
 
 	"github.com/firecracker-microvm/firecracker-containerd/internal"
 	"github.com/firecracker-microvm/firecracker-containerd/proto"
@@ -50,19 +52,49 @@ func CreateContainerStubs(
 	jail jailer,
 	containerCount int,
 	logger *logrus.Entry,
+) (*StubDriveHandler, error) {
+	handler, err := createStubDriveHandler(machineCfg, jail, containerCount, "ctrstub%d", logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create container stub drive: %w", err)
+	}
+	return handler, nil
+}
+
+// CreateSpareStubs will create a StubDriveHandler for managing spare stub drives, which
+// are not used at boot and can be attached to drive mounts while the VM is running. Like
+// container stub drives, the Firecracker drives are read-write.
+func CreateSpareStubs(
+	machineCfg *firecracker.Config,
+	jail jailer,
+	spareCount int,
+	logger *logrus.Entry,
+) (*StubDriveHandler, error) {
+	handler, err := createStubDriveHandler(machineCfg, jail, spareCount, "sparestub%d", logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create spare stub drive: %w", err)
+	}
+	return handler, nil
+}
+
+func createStubDriveHandler(
+	machineCfg *firecracker.Config,
+	jail jailer,
+	count int,
+	stubFileNameFormat string,
+	logger *logrus.Entry,
 ) (*StubDriveHandler, error) {
 	var containerStubs []*stubDrive
-	for i := 0; i < containerCount; i++ {
+	for i := 0; i < count; i++ {
 		isWritable := true
 		var rateLimiter *proto.FirecrackerRateLimiter
-		stubFileName := fmt.Sprintf("ctrstub%d", i)
+		stubFileName := fmt.Sprintf(stubFileNameFormat, i)
 
 		stubDrive, err := newStubDrive(
 			filepath.Join(jail.JailPath().RootPath(), stubFileName),
 			jail, isWritable, rateLimiter, logger)
 
 		if err != nil {
-			return nil, fmt.Errorf("failed to create container stub drive: %w", err)
+			return nil, err
 		}
 
 		machineCfg.Drives = append(machineCfg.Drives, models.Drive{
@@ -139,6 +171,109 @@ func (h *StubDriveHandler) Reserve(
 	return nil
 }
 
+// ReserveDriveMount pops an unused stub drive, patches it with the provided drive mount and
+// mounts it inside the VM. The rate limiter of the drive mount replaces any rate limiter left
+// on the stub drive by a previous reservation.
+func (h *StubDriveHandler) ReserveDriveMount(
+	requestCtx context.Context,
+	id string,
+	driveMount *proto.FirecrackerDriveMount,
+	driveMounter drivemount.DriveMounterService,
+	machine firecracker.MachineIface,
+) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.freeDrives) == 0 {
+		return ErrDrivesExhausted
+	}
+	if _, ok := h.usedDrives[id]; ok {
+		return fmt.Errorf("drive with ID %s already in use", id)
+	}
+
+	options, err := setReadWriteOptions(driveMount.Options, driveMount.IsWritable)
+	if err != nil {
+		return err
+	}
+
+	freeDrive := h.freeDrives[0]
+	stubDrive := freeDrive.withMountConfig(
+		driveMount.HostPath,
+		driveMount.VMPath,
+		driveMount.FilesystemType,
+		options,
+	)
+	stubDrive.driveMount.IsWritable = driveMount.IsWritable
+	stubDrive.driveMount.RateLimiter = driveMount.RateLimiter
+
+	err = stubDrive.PatchAndMount(requestCtx, machine, driveMounter,
+		func(params *ops.PatchGuestDriveByIDParams) {
+			params.Body.RateLimiter = rateLimiterPatchFromProto(driveMount.RateLimiter)
+		})
+	if err != nil {
+		return fmt.Errorf("failed to mount drive inside vm: %w", err)
+	}
+
+	h.freeDrives = h.freeDrives[1:]
+	h.usedDrives[id] = &stubDrive
+	return nil
+}
+
+// driveMount returns the mount configuration of the drive reserved for the given ID,
+// or nil if there is none.
+func (h *StubDriveHandler) driveMount(id string) *proto.FirecrackerDriveMount {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if drive, ok := h.usedDrives[id]; ok {
+		return drive.driveMount
+	}
+	return nil
+}
+
+// UpdateRateLimiter replaces the rate limiter of the stub drive with the given drive ID, or of the
+// reserved one mounted at the given VM path. It returns false if there is no such drive.
+func (h *StubDriveHandler) UpdateRateLimiter(
+	requestCtx context.Context,
+	driveID string,
+	vmPath string,
+	rateLimiter *proto.FirecrackerRateLimiter,
+	machine firecracker.MachineIface,
+) (bool, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, drive := range h.usedDrives {
+		if drive.matches(driveID, vmPath) {
+			return true, drive.patchRateLimiter(requestCtx, machine, rateLimiter)
+		}
+	}
+
+	for _, drive := range h.freeDrives {
+		// free drives are not mounted, so they can only be found by drive ID
+		if driveID != "" && drive.driveID == driveID {
+			return true, drive.patchRateLimiter(requestCtx, machine, rateLimiter)
+		}
+	}
+
+	return false, nil
+}
+
+// drives returns the state of the stub drives managed by the handler, sorted by drive ID.
+func (h *StubDriveHandler) drives() []*proto.FirecrackerDriveInfo {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var drives []*proto.FirecrackerDriveInfo
+	for _, drive := range h.usedDrives {
+		drives = append(drives, drive.driveInfo(true))
+	}
+	for _, drive := range h.freeDrives {
+		drives = append(drives, drive.driveInfo(false))
+	}
+
+	sort.Slice(drives, func(i, j int) bool { return drives[i].DriveID < drives[j].DriveID })
+	return drives
+}
+
 // Release unmounts stub drive of just deleted container
 // and pushes just released drive to freeDrives
 func (h *StubDriveHandler) Release(
@@ -171,9 +306,72 @@ func (h *StubDriveHandler) Release(
 	return nil
 }
 
+// reset moves all the reserved drives back to the free drives without unmounting or patching
+// them, as a relaunched VM boots with the stub files. It returns the drive mounts the drives were
+// reserved with, keyed by the ID they were reserved for.
+func (h *StubDriveHandler) reset() map[string]*proto.FirecrackerDriveMount {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	reserved := make(map[string]*proto.FirecrackerDriveMount, len(h.usedDrives))
+	for id, drive := range h.usedDrives {
+		reserved[id] = drive.driveMount
+		h.freeDrives = append(h.freeDrives, drive)
+	}
+	h.usedDrives = make(map[string]*stubDrive)
+	return reserved
+}
+
+// reservedDrive is the persisted form of a reserved stub drive, used to rebuild a
+// StubDriveHandler when a VM is restored from a snapshot.
+type reservedDrive struct {
+	StubName   string                       `json:"stubName"`
+	DriveMount *proto.FirecrackerDriveMount `json:"driveMount"`
+}
+
+// reservedDrives returns the total number of stub drives managed by the handler and
+// the drives currently reserved, keyed by the ID they were reserved for.
+func (h *StubDriveHandler) reservedDrives() (int, map[string]reservedDrive) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	reserved := make(map[string]reservedDrive, len(h.usedDrives))
+	for id, drive := range h.usedDrives {
+		reserved[id] = reservedDrive{
+			StubName:   filepath.Base(drive.stubPath),
+			DriveMount: drive.driveMount,
+		}
+	}
+	return len(h.freeDrives) + len(h.usedDrives), reserved
+}
+
+// markReserved moves the stub drive with the given file name from the free drives to the
+// used drives without patching or mounting it. It is meant for drives that the guest
+// already has mounted, such as after restoring a VM from a snapshot.
+func (h *StubDriveHandler) markReserved(id string, drive reservedDrive) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.usedDrives[id]; ok {
+		return fmt.Errorf("drive with ID %s already in use", id)
+	}
+
+	for i, freeDrive := range h.freeDrives {
+		if filepath.Base(freeDrive.stubPath) != drive.StubName {
+			continue
+		}
+
+		usedDrive := *freeDrive
+		usedDrive.driveMount = drive.DriveMount
+		h.freeDrives = append(h.freeDrives[:i], h.freeDrives[i+1:]...)
+		h.usedDrives[id] = &usedDrive
+		return nil
+	}
+	return fmt.Errorf("stub drive %s was not found", drive.StubName)
+}
+
 // CreateDriveMountStubs creates a set of MountableStubDrives from the provided DriveMount configs.
-// The RateLimiter and ReadOnly settings need to be provided up front here as they currently
-// cannot be patched after the Firecracker VM starts.
+// The ReadOnly setting needs to be provided up front here as it cannot be patched after the
+// Firecracker VM starts. The RateLimiter can be changed later on through UpdateDrive.
 func CreateDriveMountStubs(
 	machineCfg *firecracker.Config,
 	jail jailer,
@@ -246,6 +444,7 @@ type MountableStubDrive interface {
 		requestCtx context.Context,
 		machine firecracker.MachineIface,
 		driveMounter drivemount.DriveMounterService,
+		patchOpts ...firecracker.PatchGuestDriveByIDOpt,
 	) error
 }
 
@@ -355,17 +554,72 @@ func (sd stubDrive) withMountConfig(
 	return sd
 }
 
+// matches returns whether the stub drive has the given drive ID, or is mounted at the given VM path.
+func (sd stubDrive) matches(driveID, vmPath string) bool {
+	if driveID != "" {
+		return sd.driveID == driveID
+	}
+	return sd.driveMount.VMPath == vmPath
+}
+
+// driveInfo returns the state of the stub drive. Unless it is mounted, the drive is backed by the
+// stub file.
+func (sd stubDrive) driveInfo(mounted bool) *proto.FirecrackerDriveInfo {
+	info := &proto.FirecrackerDriveInfo{
+		DriveID:     sd.driveID,
+		HostPath:    sd.stubPath,
+		RateLimiter: sd.driveMount.RateLimiter,
+		IsWritable:  sd.driveMount.IsWritable,
+	}
+	if mounted {
+		info.HostPath = sd.driveMount.HostPath
+		info.VMPath = sd.driveMount.VMPath
+	}
+	return info
+}
+
+func (sd stubDrive) patchRateLimiter(
+	requestCtx context.Context,
+	machine firecracker.MachineIface,
+	rateLimiter *proto.FirecrackerRateLimiter,
+) error {
+	if err := patchDriveRateLimiter(requestCtx, machine, sd.driveID, rateLimiter); err != nil {
+		return err
+	}
+	sd.driveMount.RateLimiter = rateLimiter
+	return nil
+}
+
+// patchDriveRateLimiter replaces the rate limiter of a drive of the running VM, leaving the file
+// backing the drive unchanged.
+func patchDriveRateLimiter(
+	requestCtx context.Context,
+	machine firecracker.MachineIface,
+	driveID string,
+	rateLimiter *proto.FirecrackerRateLimiter,
+) error {
+	err := machine.UpdateGuestDrive(requestCtx, driveID, "",
+		func(params *ops.PatchGuestDriveByIDParams) {
+			params.Body.RateLimiter = rateLimiterPatchFromProto(rateLimiter)
+		})
+	if err != nil {
+		return fmt.Errorf("failed to patch rate limiter of drive %s: %w", driveID, err)
+	}
+	return nil
+}
+
 func (sd stubDrive) PatchAndMount(
 	requestCtx context.Context,
 	machine firecracker.MachineIface,
 	driveMounter drivemount.DriveMounterService,
+	patchOpts ...firecracker.PatchGuestDriveByIDOpt,
 ) error {
 	err := sd.jail.ExposeFileToJail(sd.driveMount.HostPath)
 	if err != nil {
 		return fmt.Errorf("failed to expose patched drive contents to jail: %w", err)
 	}
 
-	err = machine.UpdateGuestDrive(requestCtx, sd.driveID, sd.driveMount.HostPath)
+	err = machine.UpdateGuestDrive(requestCtx, sd.driveID, sd.driveMount.HostPath, patchOpts...)
 	if err != nil {
 		return fmt.Errorf("failed to patch drive: %w", err)
 	}
@@ -281,3 +281,199 @@ func TestStubPathToDriveID(t *testing.T) {
 			"unexpected invalid characters in drive ID")
 	}
 }
+
+func TestContainerStubsMarkReserved(t *testing.T) {
+	ctx := context.Background()
+	logger := log.G(ctx)
+
+	noopJailer := &noopJailer{
+		shimDir: vm.Dir(t.TempDir()),
+		ctx:     ctx,
+		logger:  logger,
+	}
+
+	stubDriveHandler, err := CreateContainerStubs(&firecracker.Config{}, noopJailer, 3, logger)
+	require.NoError(t, err, "failed to create stub drive handler")
+
+	driveMount := &proto.FirecrackerDriveMount{
+		HostPath:       "/path/to/rootfs",
+		VMPath:         "/container/rootfs",
+		FilesystemType: "ext4",
+		IsWritable:     true,
+	}
+	err = stubDriveHandler.markReserved("task", reservedDrive{StubName: "ctrstub1", DriveMount: driveMount})
+	require.NoError(t, err, "failed to mark stub drive as reserved")
+
+	count, reserved := stubDriveHandler.reservedDrives()
+	assert.Equal(t, 3, count)
+	assert.Equal(t, map[string]reservedDrive{
+		"task": {StubName: "ctrstub1", DriveMount: driveMount},
+	}, reserved)
+	assert.Len(t, stubDriveHandler.freeDrives, 2)
+
+	err = stubDriveHandler.markReserved("task", reservedDrive{StubName: "ctrstub2", DriveMount: driveMount})
+	assert.Error(t, err, "the same ID must not be reserved twice")
+
+	err = stubDriveHandler.markReserved("other", reservedDrive{StubName: "ctrstub1", DriveMount: driveMount})
+	assert.Error(t, err, "a used stub drive must not be reserved twice")
+}
+
+func TestStubDriveHandlerReset(t *testing.T) {
+	ctx := context.Background()
+	logger := log.G(ctx)
+
+	noopJailer := &noopJailer{
+		shimDir: vm.Dir(t.TempDir()),
+		ctx:     ctx,
+		logger:  logger,
+	}
+
+	stubDriveHandler, err := CreateContainerStubs(&firecracker.Config{}, noopJailer, 2, logger)
+	require.NoError(t, err, "failed to create stub drive handler")
+
+	driveMount := &proto.FirecrackerDriveMount{
+		HostPath:       "/path/to/rootfs",
+		VMPath:         "/container/rootfs",
+		FilesystemType: "ext4",
+	}
+	err = stubDriveHandler.markReserved("task", reservedDrive{StubName: "ctrstub0", DriveMount: driveMount})
+	require.NoError(t, err, "failed to mark stub drive as reserved")
+
+	assert.Equal(t, map[string]*proto.FirecrackerDriveMount{"task": driveMount}, stubDriveHandler.reset())
+	count, reserved := stubDriveHandler.reservedDrives()
+	assert.Equal(t, 2, count)
+	assert.Empty(t, reserved)
+
+	err = stubDriveHandler.markReserved("task", reservedDrive{StubName: "ctrstub0", DriveMount: driveMount})
+	assert.NoError(t, err, "a reset stub drive must be free again")
+}
+
+func TestSpareStubsReserveDriveMount(t *testing.T) {
+	ctx := context.Background()
+	logger := log.G(ctx)
+
+	stubDir := t.TempDir()
+	noopJailer := &noopJailer{
+		shimDir: vm.Dir(stubDir),
+		ctx:     ctx,
+		logger:  logger,
+	}
+
+	machineCfg := &firecracker.Config{}
+	spareStubHandler, err := CreateSpareStubs(machineCfg, noopJailer, 1, logger)
+	require.NoError(t, err, "failed to create spare stub drive handler")
+	require.Len(t, machineCfg.Drives, 1)
+	assert.False(t, firecracker.BoolValue(machineCfg.Drives[0].IsReadOnly))
+	assert.Equal(t, filepath.Join(stubDir, "sparestub0"), firecracker.StringValue(machineCfg.Drives[0].PathOnHost))
+
+	driveMount := &proto.FirecrackerDriveMount{
+		HostPath:       "/path/to/volume",
+		VMPath:         "/mnt/volume",
+		FilesystemType: "ext4",
+		Options:        []string{"noatime"},
+		RateLimiter: &proto.FirecrackerRateLimiter{
+			Bandwidth: &proto.FirecrackerTokenBucket{Capacity: 100, RefillTime: 1000},
+		},
+	}
+
+	mockMachine, err := firecracker.NewMachine(ctx, firecracker.Config{}, firecracker.WithClient(
+		firecracker.NewClient("/path/to/socket", nil, false, firecracker.WithOpsClient(&fctesting.MockClient{
+			PatchGuestDriveByIDFn: func(params *ops.PatchGuestDriveByIDParams) (*ops.PatchGuestDriveByIDNoContent, error) {
+				assert.Equal(t, driveMount.HostPath, params.Body.PathOnHost)
+				require.NotNil(t, params.Body.RateLimiter)
+				assert.Equal(t, int64(100), firecracker.Int64Value(params.Body.RateLimiter.Bandwidth.Size))
+				return nil, nil
+			},
+		}))))
+	require.NoError(t, err, "failed to create new machine")
+
+	mockDriveMounter := &MockDriveMounter{
+		t:                       t,
+		expectedDestinationPath: driveMount.VMPath,
+		expectedFilesystemType:  driveMount.FilesystemType,
+		expectedOptions:         []string{"noatime", "ro"},
+	}
+
+	err = spareStubHandler.ReserveDriveMount(ctx, driveMount.VMPath, driveMount, mockDriveMounter, mockMachine)
+	require.NoError(t, err, "failed to reserve spare stub drive")
+	assert.Equal(t, driveMount.HostPath, spareStubHandler.driveMount(driveMount.VMPath).HostPath)
+
+	err = spareStubHandler.ReserveDriveMount(ctx, "/mnt/other", driveMount, mockDriveMounter, mockMachine)
+	assert.Equal(t, ErrDrivesExhausted, err)
+}
+
+func TestStubDriveHandlerUpdateRateLimiter(t *testing.T) {
+	ctx := context.Background()
+	logger := log.G(ctx)
+
+	stubDir := t.TempDir()
+	noopJailer := &noopJailer{
+		shimDir: vm.Dir(stubDir),
+		ctx:     ctx,
+		logger:  logger,
+	}
+
+	stubDriveHandler, err := CreateContainerStubs(&firecracker.Config{}, noopJailer, 2, logger)
+	require.NoError(t, err, "failed to create stub drive handler")
+
+	driveMount := &proto.FirecrackerDriveMount{
+		HostPath:       "/path/to/rootfs",
+		VMPath:         "/container/rootfs",
+		FilesystemType: "ext4",
+		IsWritable:     true,
+	}
+	err = stubDriveHandler.markReserved("task", reservedDrive{StubName: "ctrstub0", DriveMount: driveMount})
+	require.NoError(t, err, "failed to mark stub drive as reserved")
+
+	rateLimiter := &proto.FirecrackerRateLimiter{
+		Ops: &proto.FirecrackerTokenBucket{Capacity: 10, RefillTime: 1000},
+	}
+
+	var patchedDriveIDs []string
+	mockMachine, err := firecracker.NewMachine(ctx, firecracker.Config{}, firecracker.WithClient(
+		firecracker.NewClient("/path/to/socket", nil, false, firecracker.WithOpsClient(&fctesting.MockClient{
+			PatchGuestDriveByIDFn: func(params *ops.PatchGuestDriveByIDParams) (*ops.PatchGuestDriveByIDNoContent, error) {
+				assert.Empty(t, params.Body.PathOnHost, "the backing file must be left unchanged")
+				require.NotNil(t, params.Body.RateLimiter)
+				assert.Equal(t, int64(10), firecracker.Int64Value(params.Body.RateLimiter.Ops.Size))
+				assert.Equal(t, int64(0), firecracker.Int64Value(params.Body.RateLimiter.Bandwidth.Size),
+					"unset token buckets must be disabled")
+				patchedDriveIDs = append(patchedDriveIDs, params.DriveID)
+				return nil, nil
+			},
+		}))))
+	require.NoError(t, err, "failed to create new machine")
+
+	usedDriveID := stubPathToDriveID(filepath.Join(stubDir, "ctrstub0"))
+	freeDriveID := stubPathToDriveID(filepath.Join(stubDir, "ctrstub1"))
+
+	found, err := stubDriveHandler.UpdateRateLimiter(ctx, "", driveMount.VMPath, rateLimiter, mockMachine)
+	require.NoError(t, err)
+	assert.True(t, found, "a used drive must be found by its VM path")
+
+	found, err = stubDriveHandler.UpdateRateLimiter(ctx, freeDriveID, "", rateLimiter, mockMachine)
+	require.NoError(t, err)
+	assert.True(t, found, "a free drive must be found by its drive ID")
+
+	found, err = stubDriveHandler.UpdateRateLimiter(ctx, "", "/not/mounted", rateLimiter, mockMachine)
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	assert.Equal(t, []string{usedDriveID, freeDriveID}, patchedDriveIDs)
+
+	drives := stubDriveHandler.drives()
+	require.Len(t, drives, 2)
+	for _, drive := range drives {
+		assert.Equal(t, rateLimiter, drive.RateLimiter)
+		switch drive.DriveID {
+		case usedDriveID:
+			assert.Equal(t, driveMount.HostPath, drive.HostPath)
+			assert.Equal(t, driveMount.VMPath, drive.VMPath)
+		case freeDriveID:
+			assert.Equal(t, filepath.Join(stubDir, "ctrstub1"), drive.HostPath)
+			assert.Empty(t, drive.VMPath)
+		default:
+			t.Errorf("unexpected drive %q", drive.DriveID)
+		}
+	}
+}
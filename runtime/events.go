@@ -0,0 +1,73 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"os/exec"
+	"syscall"
+
+	"github.com/containerd/containerd/events"
+
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+// publishEvent publishes a VM lifecycle event on the given topic. Failures are only
+// logged as lifecycle events are informational and must not fail the operation
+// they describe.
+func (s *service) publishEvent(topic string, event events.Event) {
+	if err := s.eventExchange.Publish(s.shimCtx, topic, event); err != nil {
+		s.logger.WithError(err).WithField("topic", topic).Error("failed to publish event")
+	}
+}
+
+// beginStop marks the VM as stopping and records the reason reported in the stop event.
+// A later call overrides the reason, so the most specific cause of the stop is reported.
+func (s *service) beginStop(reason string) {
+	s.stopping.Store(true)
+	s.stopOnce.Do(func() { close(s.stopCh) })
+
+	s.stopReasonMu.Lock()
+	defer s.stopReasonMu.Unlock()
+	s.stopReason = reason
+}
+
+// enterStopStage records the stage a stop has reached, which is reported in the stop event if
+// the VM stops at it.
+func (s *service) enterStopStage(stage proto.VMStopStage) {
+	s.logger.WithField("stage", stage.String()).Info("stopping VM")
+
+	s.stopReasonMu.Lock()
+	defer s.stopReasonMu.Unlock()
+	s.stopStage = stage
+}
+
+// exitStatusFromError returns the exit status of the Firecracker process from the error
+// returned by Machine.Wait. Like shells, 128+n is returned when the process was killed by
+// signal n. -1 is returned if the error does not carry an exit status.
+func exitStatusFromError(err error) int32 {
+	if err == nil {
+		return 0
+	}
+
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return -1
+	}
+
+	if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+		return 128 + int32(status.Signal())
+	}
+	return int32(exitErr.ExitCode())
+}
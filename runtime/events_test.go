@@ -0,0 +1,255 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/containerd/containerd/events"
+	"github.com/containerd/typeurl/v2"
+	"github.com/firecracker-microvm/firecracker-go-sdk"
+	models "github.com/firecracker-microvm/firecracker-go-sdk/client/models"
+	ops "github.com/firecracker-microvm/firecracker-go-sdk/client/operations"
+	"github.com/firecracker-microvm/firecracker-go-sdk/fctesting"
+	"github.com/hashicorp/go-multierror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/firecracker-microvm/firecracker-containerd/config"
+	"github.com/firecracker-microvm/firecracker-containerd/internal/vm"
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+	agenthealth "github.com/firecracker-microvm/firecracker-containerd/proto/service/agenthealth/ttrpc"
+)
+
+func TestExitStatusFromError(t *testing.T) {
+	exitErr := exec.Command("sh", "-c", "exit 3").Run()
+	killedErr := exec.Command("sh", "-c", "kill -9 $$").Run()
+
+	testcases := []struct {
+		name     string
+		err      error
+		expected int32
+	}{
+		{name: "nil", err: nil, expected: 0},
+		{name: "exit code", err: exitErr, expected: 3},
+		{name: "wrapped exit code", err: multierror.Append(exitErr, errors.New("cleanup")), expected: 3},
+		{name: "signal", err: fmt.Errorf("wait: %w", killedErr), expected: 137},
+		{name: "no status", err: errors.New("something else"), expected: -1},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, exitStatusFromError(tc.err))
+		})
+	}
+}
+
+// eventRecorder records the events published on the exchange of a service.
+type eventRecorder struct {
+	envelopes <-chan *events.Envelope
+}
+
+func recordEvents(t *testing.T, s *service) *eventRecorder {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	envelopes, _ := s.eventExchange.Subscribe(ctx)
+	return &eventRecorder{envelopes: envelopes}
+}
+
+// expect asserts that the next published event has the given topic and payload.
+func (r *eventRecorder) expect(t *testing.T, topic string, event protobuf.Message) {
+	t.Helper()
+	select {
+	case envelope := <-r.envelopes:
+		assert.Equal(t, topic, envelope.Topic)
+		published, err := typeurl.UnmarshalAny(envelope.Event)
+		require.NoError(t, err)
+		assert.True(t, protobuf.Equal(event, published.(protobuf.Message)), "expected %v, published %v", event, published)
+	case <-time.After(5 * time.Second):
+		t.Fatalf("no event was published on %s", topic)
+	}
+}
+
+// expectNone asserts that no other event is published.
+func (r *eventRecorder) expectNone(t *testing.T) {
+	t.Helper()
+	select {
+	case envelope := <-r.envelopes:
+		t.Errorf("unexpected event published on %s", envelope.Topic)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+// failingAgentHealth is the health service of an agent which never answers pings.
+type failingAgentHealth struct{}
+
+func (failingAgentHealth) Ping(context.Context, *agenthealth.PingRequest) (*agenthealth.PingResponse, error) {
+	return nil, errors.New("timed out")
+}
+
+func TestPublishedEvents(t *testing.T) {
+	uut, _ := newClockTestService(t, &fctesting.MockClient{
+		DescribeInstanceFn: func(*ops.DescribeInstanceParams) (*ops.DescribeInstanceOK, error) {
+			return &ops.DescribeInstanceOK{
+				Payload: &models.InstanceInfo{State: firecracker.String(models.InstanceInfoStateRunning)},
+			}, nil
+		},
+	})
+	uut.vmID = "events"
+	ctx, cancel := context.WithCancel(uut.shimCtx)
+	uut.shimCtx, uut.shimCancel = ctx, cancel
+	defer cancel()
+
+	jailer := newNoopJailer(ctx, uut.logger, vm.Dir(t.TempDir()))
+	var err error
+	uut.spareStubHandler, err = CreateSpareStubs(&firecracker.Config{}, jailer, 1, uut.logger)
+	require.NoError(t, err)
+	driveMounter := &recordingDriveMounter{}
+	uut.live.driveMountClient = driveMounter
+	uut.live.agentHealthClient = failingAgentHealth{}
+	uut.config = &config.Config{AgentWatchdog: config.AgentWatchdogConfig{IntervalSeconds: 1, MaxMissedPings: 1}}
+
+	recorder := recordEvents(t, uut)
+
+	_, err = uut.PauseVM(ctx, &proto.PauseVMRequest{})
+	require.NoError(t, err)
+	recorder.expect(t, PauseEventName, &proto.VMPause{VMID: "events", Reason: "requested"})
+
+	_, err = uut.ResumeVM(ctx, &proto.ResumeVMRequest{})
+	require.NoError(t, err)
+	recorder.expect(t, ResumeEventName, &proto.VMResume{VMID: "events", Reason: "requested"})
+
+	_, err = uut.UpdateBalloon(ctx, &proto.UpdateBalloonRequest{AmountMib: 64})
+	require.NoError(t, err)
+	recorder.expect(t, BalloonUpdateEventName, &proto.VMBalloonUpdate{VMID: "events", Reason: "requested", AmountMib: 64})
+
+	driveMount := &proto.FirecrackerDriveMount{HostPath: "/logs.img", VMPath: "/logs", FilesystemType: "ext4"}
+	_, err = uut.AttachDriveMount(ctx, &proto.AttachDriveMountRequest{DriveMount: driveMount})
+	require.NoError(t, err)
+	recorder.expect(t, DriveMountEventName, &proto.VMDriveMount{
+		VMID:     "events",
+		Reason:   "drive mount attached",
+		HostPath: "/logs.img",
+		VMPath:   "/logs",
+	})
+
+	_, err = uut.DetachDriveMount(ctx, &proto.DetachDriveMountRequest{VMPath: "/logs"})
+	require.NoError(t, err)
+	recorder.expect(t, DriveUnmountEventName, &proto.VMDriveUnmount{
+		VMID:     "events",
+		Reason:   "drive mount detached",
+		HostPath: "/logs.img",
+		VMPath:   "/logs",
+	})
+
+	go uut.watchAgent()
+	recorder.expect(t, AgentUnhealthyEventName, &proto.VMAgentUnhealthy{
+		VMID:        "events",
+		Reason:      "agent missed 1 pings: timed out",
+		MissedPings: 1,
+	})
+	cancel()
+	recorder.expectNone(t)
+}
+
+// newExitingVMService returns a service whose VMM is a process which runs until it is killed.
+func newExitingVMService(t *testing.T) *service {
+	socketPath := filepath.Join(t.TempDir(), "firecracker.sock")
+	require.NoError(t, os.WriteFile(socketPath, nil, 0600))
+
+	uut, _ := newClockTestService(t, &fctesting.MockClient{})
+	machine, err := firecracker.NewMachine(uut.shimCtx, firecracker.Config{SocketPath: socketPath},
+		firecracker.WithProcessRunner(exec.Command("sleep", "60")),
+		firecracker.WithClient(firecracker.NewClient(socketPath, nil, false,
+			firecracker.WithOpsClient(&fctesting.MockClient{}))),
+		func(m *firecracker.Machine) {
+			m.Handlers.Validation = firecracker.HandlerList{}
+			m.Handlers.FcInit = firecracker.HandlerList{}.Append(firecracker.StartVMMHandler)
+		})
+	require.NoError(t, err)
+	require.NoError(t, machine.Start(context.Background()))
+	t.Cleanup(func() { machine.StopVMM() })
+
+	uut.vmID = "exiting"
+	uut.live.machine = machine
+	uut.jailer = newNoopJailer(uut.shimCtx, uut.logger, vm.Dir(t.TempDir()))
+	uut.createRequest = &proto.CreateVMRequest{}
+	uut.stopCh = make(chan struct{})
+	uut.shimCtx, uut.shimCancel = context.WithCancel(uut.shimCtx)
+	t.Cleanup(uut.shimCancel)
+	return uut
+}
+
+func TestUnexpectedExitEvents(t *testing.T) {
+	uut := newExitingVMService(t)
+	recorder := recordEvents(t, uut)
+
+	exited := make(chan struct{})
+	go func() {
+		uut.monitorVMExit()
+		close(exited)
+	}()
+	pid, err := uut.vm().machine.PID()
+	require.NoError(t, err)
+	require.NoError(t, syscall.Kill(pid, syscall.SIGKILL))
+	<-exited
+
+	reason := "firecracker exited with status 137"
+	recorder.expect(t, UnexpectedExitEventName, &proto.VMUnexpectedExit{VMID: "exiting", Reason: reason, ExitStatus: 137})
+	recorder.expect(t, StopEventName, &proto.VMStop{VMID: "exiting", Reason: reason})
+	recorder.expectNone(t)
+}
+
+func TestRequestedStopEvents(t *testing.T) {
+	uut := newExitingVMService(t)
+	recorder := recordEvents(t, uut)
+
+	exited := make(chan struct{})
+	go func() {
+		uut.monitorVMExit()
+		close(exited)
+	}()
+
+	// a stop reached through a second path only reports its most specific reason, once
+	uut.beginStop("stop requested")
+	uut.beginStop("forcefully terminated: VM did not stop gracefully")
+	uut.enterStopStage(proto.VMStopStage_VMM_KILLED)
+	select {
+	case <-uut.stopCh:
+	default:
+		t.Fatal("the stop channel must be closed")
+	}
+
+	pid, err := uut.vm().machine.PID()
+	require.NoError(t, err)
+	require.NoError(t, syscall.Kill(pid, syscall.SIGKILL))
+	<-exited
+
+	recorder.expect(t, StopEventName, &proto.VMStop{
+		VMID:   "exiting",
+		Reason: "forcefully terminated: VM did not stop gracefully",
+		Stage:  proto.VMStopStage_VMM_KILLED,
+	})
+	recorder.expectNone(t)
+}
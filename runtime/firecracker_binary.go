@@ -0,0 +1,109 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/firecracker-microvm/firecracker-containerd/config"
+	"github.com/firecracker-microvm/firecracker-containerd/internal"
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+// defaultFirecrackerBinary is the binary the SDK runs if the runtime config has no
+// firecracker_binary_path.
+const defaultFirecrackerBinary = "firecracker"
+
+// firecrackerBinary is a Firecracker binary of the runtime config along with its capabilities.
+type firecrackerBinary struct {
+	// path is empty for the default binary if the runtime config has no firecracker_binary_path
+	path string
+	caps internal.FirecrackerCapabilities
+	// err is why the capabilities of the binary could not be detected
+	err error
+}
+
+// probeFirecrackerBinaries detects the capabilities of each Firecracker binary of the runtime
+// config by name, the default one having an empty name. A binary that cannot be run is only
+// reported once a VM selects it.
+func probeFirecrackerBinaries(ctx context.Context, logger *logrus.Entry, cfg *config.Config) map[string]firecrackerBinary {
+	paths := map[string]string{"": cfg.FirecrackerBinaryPath}
+	for name, path := range cfg.FirecrackerBinaries {
+		paths[name] = path
+	}
+
+	binaries := make(map[string]firecrackerBinary, len(paths))
+	for name, path := range paths {
+		bin := firecrackerBinary{path: path}
+		if path == "" {
+			path = defaultFirecrackerBinary
+		}
+
+		version, err := internal.DetectFirecrackerVersion(ctx, path)
+		if err != nil {
+			bin.err = err
+			logger.WithError(err).WithField("firecracker_binary", name).Warn("failed to detect the version of Firecracker")
+		} else {
+			bin.caps = internal.CapabilitiesOf(version)
+			logger.WithFields(logrus.Fields{
+				"firecracker_binary":  name,
+				"firecracker_version": version,
+			}).Debug("detected Firecracker version")
+		}
+		binaries[name] = bin
+	}
+	return binaries
+}
+
+// selectFirecrackerBinary makes the VM run with the Firecracker binary of the runtime config
+// with the given name, or the default one if name is empty. The binaries are probed on the
+// first call.
+func (s *service) selectFirecrackerBinary(name string) error {
+	s.firecrackerBinariesOnce.Do(func() {
+		s.firecrackerBinaries = probeFirecrackerBinaries(s.shimCtx, s.logger, s.config)
+	})
+
+	bin, ok := s.firecrackerBinaries[name]
+	if !ok {
+		return status.Errorf(codes.InvalidArgument, "unknown Firecracker binary %q", name)
+	}
+	if bin.err != nil {
+		return fmt.Errorf("failed to detect the version of Firecracker: %w", bin.err)
+	}
+
+	s.firecrackerPath = bin.path
+	s.firecracker = bin.caps
+	s.logger.WithField("firecracker_version", bin.caps.Version).Info("selected Firecracker binary")
+	return nil
+}
+
+// checkFirecrackerCapabilities returns an error if the request needs a feature the Firecracker
+// binary of the VM lacks.
+func checkFirecrackerCapabilities(caps internal.FirecrackerCapabilities, request *proto.CreateVMRequest) error {
+	if request.BalloonDevice != nil && !caps.Balloon {
+		return status.Errorf(codes.FailedPrecondition, "Firecracker %s does not support balloon devices", caps.Version)
+	}
+	if request.LoadSnapshot != nil && !caps.Snapshots {
+		return status.Errorf(codes.FailedPrecondition, "Firecracker %s does not support snapshots", caps.Version)
+	}
+	if request.MMDSConfig.GetVersion() == proto.MMDSVersion_V2 && !caps.MMDSV2 {
+		return status.Errorf(codes.FailedPrecondition, "Firecracker %s does not support MMDS version 2", caps.Version)
+	}
+	return nil
+}
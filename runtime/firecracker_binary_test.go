@@ -0,0 +1,96 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/firecracker-microvm/firecracker-containerd/config"
+	"github.com/firecracker-microvm/firecracker-containerd/internal"
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+func TestCheckFirecrackerCapabilities(t *testing.T) {
+	old := internal.CapabilitiesOf(internal.FirecrackerVersion{Major: 0, Minor: 23})
+	current := internal.CapabilitiesOf(internal.FirecrackerVersion{Major: 1, Minor: 1})
+
+	for name, request := range map[string]*proto.CreateVMRequest{
+		"balloon":  {BalloonDevice: &proto.FirecrackerBalloonDevice{}},
+		"snapshot": {LoadSnapshot: &proto.LoadSnapshotConfig{}},
+		"mmds v2":  {MMDSConfig: &proto.MMDSConfig{Version: proto.MMDSVersion_V2}},
+	} {
+		request := request
+		t.Run(name, func(t *testing.T) {
+			err := checkFirecrackerCapabilities(old, request)
+			assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+			assert.NoError(t, checkFirecrackerCapabilities(current, request))
+		})
+	}
+
+	assert.NoError(t, checkFirecrackerCapabilities(old, &proto.CreateVMRequest{}))
+}
+
+func TestProbeFirecrackerBinaries(t *testing.T) {
+	dir := t.TempDir()
+	fakeFirecracker := func(name, version string) string {
+		path := filepath.Join(dir, name)
+		script := fmt.Sprintf("#!/bin/sh\necho 'Firecracker %s'\necho probed >> %s.probes\n", version, path)
+		require.NoError(t, os.WriteFile(path, []byte(script), 0700))
+		return path
+	}
+
+	cfg := &config.Config{
+		FirecrackerBinaryPath: fakeFirecracker("default", "v1.1.0"),
+		FirecrackerBinaries: map[string]string{
+			"old":     fakeFirecracker("old", "v0.23.0"),
+			"missing": filepath.Join(dir, "missing"),
+		},
+	}
+	uut := &service{
+		logger:  logrus.NewEntry(logrus.New()),
+		shimCtx: context.Background(),
+		config:  cfg,
+	}
+	assert.NoFileExists(t, filepath.Join(dir, "default.probes"), "the binaries are only probed by CreateVM")
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, uut.selectFirecrackerBinary("old"))
+		assert.Equal(t, cfg.FirecrackerBinaries["old"], uut.firecrackerPath)
+		assert.False(t, uut.firecracker.Snapshots)
+
+		require.NoError(t, uut.selectFirecrackerBinary(""))
+		assert.Equal(t, cfg.FirecrackerBinaryPath, uut.firecrackerPath)
+		assert.True(t, uut.firecracker.Snapshots)
+	}
+	assert.Equal(t, filepath.Join(dir, "default"), cfg.FirecrackerBinaryPath, "the config is left as is")
+
+	for _, name := range []string{"default", "old"} {
+		probes, err := os.ReadFile(filepath.Join(dir, name+".probes"))
+		require.NoError(t, err)
+		assert.Equal(t, "probed\n", string(probes), "%s must be probed once", name)
+	}
+
+	assert.Error(t, uut.selectFirecrackerBinary("missing"))
+	assert.Equal(t, codes.InvalidArgument, status.Code(uut.selectFirecrackerBinary("unknown")))
+}
@@ -55,6 +55,7 @@ func machineConfigurationFromProto(cfg *config.Config, req *proto.FirecrackerMac
 	}
 
 	config.Smt = firecracker.Bool(req.HtEnabled)
+	config.TrackDirtyPages = req.TrackDirtyPages
 
 	return config
 }
@@ -136,6 +137,27 @@ func rateLimiterFromProto(rl *proto.FirecrackerRateLimiter) *models.RateLimiter
 	return &result
 }
 
+// rateLimiterPatchFromProto creates the firecracker RateLimiter object to PATCH on a device of a
+// running VM so that its rate limiter gets replaced by the one of the protobuf message. Firecracker
+// leaves the token buckets missing from a PATCH unchanged, so they are sent with a zero size,
+// which disables them.
+func rateLimiterPatchFromProto(rl *proto.FirecrackerRateLimiter) *models.RateLimiter {
+	result := rateLimiterFromProto(rl)
+	if result == nil {
+		result = &models.RateLimiter{}
+	}
+
+	if result.Bandwidth == nil {
+		result.Bandwidth = &models.TokenBucket{Size: firecracker.Int64(0), RefillTime: firecracker.Int64(0)}
+	}
+
+	if result.Ops == nil {
+		result.Ops = &models.TokenBucket{Size: firecracker.Int64(0), RefillTime: firecracker.Int64(0)}
+	}
+
+	return result
+}
+
 func withRateLimiterFromProto(rl *proto.FirecrackerRateLimiter) firecracker.DriveOpt {
 	if rl == nil {
 		return func(d *models.Drive) {
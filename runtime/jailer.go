@@ -16,8 +16,11 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"os/exec"
 
+	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/sirupsen/logrus"
 
 	"github.com/firecracker-microvm/firecracker-containerd/config"
@@ -77,7 +80,10 @@ func newJailer(
 ) (jailer, error) {
 	if request == nil || request.JailerConfig == nil {
 		l := logger.WithField("jailer", "noop")
-		return newNoopJailer(ctx, l, service.shimDir), nil
+		j := newNoopJailer(ctx, l, service.shimDir)
+		j.firecrackerPath = service.firecrackerPath
+		j.console = service.console
+		return j, nil
 	}
 
 	if request.JailerConfig.UID == 0 || request.JailerConfig.GID == 0 {
@@ -92,6 +98,15 @@ func newJailer(
 		return nil, fmt.Errorf("failed to create oci bundle path: %s: %w", ociBundlePath, err)
 	}
 
+	blockIO, err := blockIOFromProto(request.JailerConfig.IOLimits)
+	if err != nil {
+		return nil, fmt.Errorf("invalid IO limits: %w", err)
+	}
+	var pids *specs.LinuxPids
+	if request.JailerConfig.PidsLimit > 0 {
+		pids = &specs.LinuxPids{Limit: int64(request.JailerConfig.PidsLimit)}
+	}
+
 	l := logger.WithField("jailer", "runc")
 	config := runcJailerConfig{
 		OCIBundlePath:     ociBundlePath,
@@ -103,6 +118,35 @@ func newJailer(
 		Mems:              request.JailerConfig.Mems,
 		CgroupPath:        request.JailerConfig.CgroupPath,
 		DriveExposePolicy: request.JailerConfig.DriveExposePolicy,
+		MemoryOverheadMib: memoryOverheadMib(request.JailerConfig),
+		BlockIO:           blockIO,
+		Pids:              pids,
+	}
+	j, err := newRuncJailer(ctx, l, service.vmID, config, request.DriveMounts)
+	if err != nil {
+		return nil, err
+	}
+	j.firecrackerPath = service.firecrackerPath
+	j.console = service.console
+	return j, nil
+}
+
+// setVMMStdio connects the stdio of the Firecracker process, which carries the serial console
+// of the VM, to the console if there is one. The output is logged too if logOutput is set.
+func setVMMStdio(cmd *exec.Cmd, console *vmConsole, logger *logrus.Entry, logOutput bool) {
+	if logOutput {
+		cmd.Stdout = logger.WithField("vmm_stream", "stdout").WriterLevel(logrus.DebugLevel)
+		cmd.Stderr = logger.WithField("vmm_stream", "stderr").WriterLevel(logrus.DebugLevel)
+	}
+
+	if console == nil {
+		return
+	}
+
+	cmd.Stdin = console.stdin()
+	if cmd.Stdout != nil {
+		cmd.Stdout = io.MultiWriter(console, cmd.Stdout)
+	} else {
+		cmd.Stdout = console
 	}
-	return newRuncJailer(ctx, l, service.vmID, config, request.DriveMounts)
 }
@@ -14,14 +14,26 @@
 package main
 
 import (
+	"errors"
+	"fmt"
 	"os"
 
 	"github.com/containerd/containerd/log"
 	"github.com/containerd/containerd/runtime/v2/shim"
+	"github.com/hashicorp/go-multierror"
 	"github.com/sirupsen/logrus"
+
+	"github.com/firecracker-microvm/firecracker-containerd/config"
 )
 
-const shimID = "aws.firecracker"
+const (
+	shimID = "aws.firecracker"
+
+	// validateConfigCommand is the subcommand checking the runtime config, so that a bad config
+	// is caught when it is deployed rather than by the first CreateVM. containerd always passes
+	// flags before the action of the shim, so it cannot be mistaken for one of its invocations.
+	validateConfigCommand = "validate-config"
+)
 
 var revision string
 
@@ -35,6 +47,10 @@ func init() {
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == validateConfigCommand {
+		os.Exit(validateConfig(os.Args[2:]))
+	}
+
 	shim.Run(shimID, NewService, func(cfg *shim.Config) {
 		cfg.NoSetupLogger = true
 
@@ -46,3 +62,33 @@ func main() {
 		cfg.NoReaper = true
 	})
 }
+
+// validateConfig prints every problem of the runtime config at the path of args, or the one the
+// shim loads if args is empty, and returns the exit code of the command.
+func validateConfig(args []string) int {
+	if len(args) > 1 {
+		fmt.Fprintf(os.Stderr, "usage: %s %s [CONFIG_PATH]\n", os.Args[0], validateConfigCommand)
+		return 2
+	}
+
+	var path string
+	if len(args) == 1 {
+		path = args[0]
+	}
+
+	err := config.ValidateConfig(path)
+	if err == nil {
+		fmt.Println("runtime config is valid")
+		return 0
+	}
+
+	var merr *multierror.Error
+	if errors.As(err, &merr) {
+		for _, err := range merr.Errors {
+			fmt.Fprintln(os.Stderr, err)
+		}
+	} else {
+		fmt.Fprintln(os.Stderr, err)
+	}
+	return 1
+}
@@ -0,0 +1,265 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/containerd/containerd/protobuf"
+	"github.com/containerd/fifo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+const (
+	metricsTimestampKey      = "utc_timestamp_ms"
+	latencyMetricsSection    = "latencies_us"
+	blockDeviceMetricsPrefix = "block_"
+	netDeviceMetricsPrefix   = "net_"
+)
+
+// vmMetrics accumulates the metrics Firecracker writes to its metrics FIFO. Firecracker writes
+// a JSON object each time it flushes its metrics, in which counters are the increments since
+// the previous flush and latencies are the last measured ones.
+type vmMetrics struct {
+	mu        sync.Mutex
+	flushedAt time.Time
+	// counters holds the totals of the counters of each section of the metrics
+	counters  map[string]map[string]uint64
+	latencies map[string]uint64
+}
+
+func newVMMetrics() *vmMetrics {
+	return &vmMetrics{
+		counters:  make(map[string]map[string]uint64),
+		latencies: make(map[string]uint64),
+	}
+}
+
+// consume reads the metrics written to r until it is closed.
+func (m *vmMetrics) consume(r io.Reader) error {
+	decoder := json.NewDecoder(r)
+	for {
+		var flush map[string]json.RawMessage
+		err := decoder.Decode(&flush)
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to decode metrics: %w", err)
+		}
+
+		if err := m.add(flush); err != nil {
+			return err
+		}
+	}
+}
+
+func (m *vmMetrics) add(flush map[string]json.RawMessage) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for section, raw := range flush {
+		if section == metricsTimestampKey {
+			var ms int64
+			if err := json.Unmarshal(raw, &ms); err != nil {
+				return fmt.Errorf("failed to decode metrics timestamp: %w", err)
+			}
+			m.flushedAt = time.UnixMilli(ms)
+			continue
+		}
+
+		if !isCollectedMetricsSection(section) {
+			continue
+		}
+
+		var values map[string]interface{}
+		if err := json.Unmarshal(raw, &values); err != nil {
+			return fmt.Errorf("failed to decode %s metrics: %w", section, err)
+		}
+
+		if section == latencyMetricsSection {
+			for name, value := range values {
+				if n, ok := value.(float64); ok {
+					m.latencies[name] = uint64(n)
+				}
+			}
+			continue
+		}
+
+		totals, ok := m.counters[section]
+		if !ok {
+			totals = make(map[string]uint64)
+			m.counters[section] = totals
+		}
+		for name, value := range values {
+			if n, ok := value.(float64); ok {
+				totals[name] += uint64(n)
+			}
+		}
+	}
+
+	return nil
+}
+
+func isCollectedMetricsSection(section string) bool {
+	switch section {
+	case "vcpu", "block", "net", "vsock", "mmds", "seccomp", latencyMetricsSection:
+		return true
+	}
+	return strings.HasPrefix(section, blockDeviceMetricsPrefix) || strings.HasPrefix(section, netDeviceMetricsPrefix)
+}
+
+func (m *vmMetrics) response() *proto.GetVMMetricsResponse {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	vcpu := m.counters["vcpu"]
+	vsock := m.counters["vsock"]
+	mmds := m.counters["mmds"]
+
+	resp := &proto.GetVMMetricsResponse{
+		Vcpu: &proto.FirecrackerVcpuMetrics{
+			ExitIOIn:      vcpu["exit_io_in"],
+			ExitIOOut:     vcpu["exit_io_out"],
+			ExitMMIORead:  vcpu["exit_mmio_read"],
+			ExitMMIOWrite: vcpu["exit_mmio_write"],
+			Failures:      vcpu["failures"],
+		},
+		Block:             blockMetrics(m.counters["block"]),
+		Drives:            make(map[string]*proto.FirecrackerBlockMetrics),
+		Net:               netMetrics(m.counters["net"]),
+		NetworkInterfaces: make(map[uint32]*proto.FirecrackerNetMetrics),
+		Vsock: &proto.FirecrackerVsockMetrics{
+			RxBytes:      vsock["rx_bytes_count"],
+			RxPackets:    vsock["rx_packets_count"],
+			TxBytes:      vsock["tx_bytes_count"],
+			TxPackets:    vsock["tx_packets_count"],
+			ConnsAdded:   vsock["conns_added"],
+			ConnsKilled:  vsock["conns_killed"],
+			ConnsRemoved: vsock["conns_removed"],
+		},
+		Mmds: &proto.FirecrackerMmdsMetrics{
+			RxCount:       mmds["rx_count"],
+			RxAccepted:    mmds["rx_accepted"],
+			RxAcceptedErr: mmds["rx_accepted_err"],
+			TxCount:       mmds["tx_count"],
+			TxBytes:       mmds["tx_bytes"],
+			TxErrors:      mmds["tx_errors"],
+		},
+		SeccompFaults: m.counters["seccomp"]["num_faults"],
+		Latencies: &proto.FirecrackerLatencyMetrics{
+			FullCreateSnapshot: m.latencies["full_create_snapshot"],
+			DiffCreateSnapshot: m.latencies["diff_create_snapshot"],
+			LoadSnapshot:       m.latencies["load_snapshot"],
+			PauseVM:            m.latencies["pause_vm"],
+			ResumeVM:           m.latencies["resume_vm"],
+		},
+	}
+
+	if !m.flushedAt.IsZero() {
+		resp.FlushedAt = protobuf.ToTimestamp(m.flushedAt)
+	}
+
+	for section, counters := range m.counters {
+		if driveID, ok := strings.CutPrefix(section, blockDeviceMetricsPrefix); ok {
+			resp.Drives[driveID] = blockMetrics(counters)
+		}
+		if ifaceID, ok := strings.CutPrefix(section, netDeviceMetricsPrefix); ok {
+			// Firecracker numbers the interfaces from 1, see createNetworkInterfaces of the SDK
+			if id, err := strconv.ParseUint(ifaceID, 10, 32); err == nil && id > 0 {
+				resp.NetworkInterfaces[uint32(id-1)] = netMetrics(counters)
+			}
+		}
+	}
+
+	return resp
+}
+
+func blockMetrics(counters map[string]uint64) *proto.FirecrackerBlockMetrics {
+	return &proto.FirecrackerBlockMetrics{
+		ReadBytes:                  counters["read_bytes"],
+		WriteBytes:                 counters["write_bytes"],
+		ReadCount:                  counters["read_count"],
+		WriteCount:                 counters["write_count"],
+		FlushCount:                 counters["flush_count"],
+		RateLimiterThrottledEvents: counters["rate_limiter_throttled_events"],
+		ExecuteFails:               counters["execute_fails"],
+		InvalidRequests:            counters["invalid_reqs_count"],
+	}
+}
+
+func netMetrics(counters map[string]uint64) *proto.FirecrackerNetMetrics {
+	return &proto.FirecrackerNetMetrics{
+		RxBytes:                counters["rx_bytes_count"],
+		RxPackets:              counters["rx_packets_count"],
+		RxFails:                counters["rx_fails"],
+		RxRateLimiterThrottled: counters["rx_rate_limiter_throttled"],
+		TxBytes:                counters["tx_bytes_count"],
+		TxPackets:              counters["tx_packets_count"],
+		TxFails:                counters["tx_fails"],
+		TxRateLimiterThrottled: counters["tx_rate_limiter_throttled"],
+	}
+}
+
+// collectMetrics starts reading the metrics Firecracker writes to the FIFO at the given path
+// for the lifetime of the shim.
+func (s *service) collectMetrics(path string) error {
+	metricsFifo, err := fifo.OpenFifo(s.shimCtx, path, syscall.O_RDONLY|syscall.O_NONBLOCK, 0)
+	if err != nil {
+		return fmt.Errorf("failed to open metrics fifo: %w", err)
+	}
+
+	// the metrics of a relaunched VM add up to the ones of the previous VM
+	if s.metrics == nil {
+		s.metrics = newVMMetrics()
+	}
+	go func() {
+		defer metricsFifo.Close()
+		if err := s.metrics.consume(metricsFifo); err != nil && s.shimCtx.Err() == nil {
+			s.logger.WithError(err).Warn("stopped collecting Firecracker metrics")
+		}
+	}()
+	return nil
+}
+
+// GetVMMetrics returns the metrics Firecracker reported for the VM. Metrics are only collected
+// when the shim owns the metrics FIFO, which is not the case if the client provided its path.
+func (s *service) GetVMMetrics(requestCtx context.Context, request *proto.GetVMMetricsRequest) (*proto.GetVMMetricsResponse, error) {
+	defer logPanicAndDie(s.logger)
+
+	err := s.waitVMReady()
+	if err != nil {
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
+
+	if s.metrics == nil {
+		return nil, status.Error(codes.FailedPrecondition, "metrics are not collected for VMs created with a MetricsFifoPath")
+	}
+
+	resp := s.metrics.response()
+	resp.StubDrivesExhausted = s.drivesExhausted.Load()
+	return resp, nil
+}
@@ -0,0 +1,62 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVMMetricsConsume(t *testing.T) {
+	flushes := strings.Join([]string{
+		`{"utc_timestamp_ms":1000,"vcpu":{"exit_io_in":3,"failures":0},` +
+			`"block":{"read_bytes":512,"write_count":1},"block_root_drive":{"read_bytes":512},` +
+			`"net_1":{"rx_bytes_count":100},"seccomp":{"num_faults":0},` +
+			`"latencies_us":{"pause_vm":40},"api_server":{"process_startup_time_us":10}}`,
+		`{"utc_timestamp_ms":2000,"vcpu":{"exit_io_in":2,"failures":1},` +
+			`"block":{"read_bytes":1024},"block_root_drive":{"read_bytes":1024},` +
+			`"net_1":{"rx_bytes_count":50},"latencies_us":{"pause_vm":25}}`,
+	}, "\n")
+
+	metrics := newVMMetrics()
+	require.NoError(t, metrics.consume(strings.NewReader(flushes)))
+
+	resp := metrics.response()
+	assert.Equal(t, time.UnixMilli(2000).UTC(), resp.FlushedAt.AsTime())
+
+	assert.Equal(t, uint64(5), resp.Vcpu.ExitIOIn, "counters must be summed across flushes")
+	assert.Equal(t, uint64(1), resp.Vcpu.Failures)
+	assert.Equal(t, uint64(1536), resp.Block.ReadBytes)
+	assert.Equal(t, uint64(1), resp.Block.WriteCount)
+
+	require.Contains(t, resp.Drives, "root_drive")
+	assert.Equal(t, uint64(1536), resp.Drives["root_drive"].ReadBytes)
+
+	require.Contains(t, resp.NetworkInterfaces, uint32(0))
+	assert.Equal(t, uint64(150), resp.NetworkInterfaces[0].RxBytes)
+
+	assert.Equal(t, uint64(25), resp.Latencies.PauseVM, "latencies must be the last measured ones")
+}
+
+func TestVMMetricsConsumeInvalid(t *testing.T) {
+	metrics := newVMMetrics()
+	assert.Error(t, metrics.consume(strings.NewReader(`{"vcpu":`)))
+
+	resp := metrics.response()
+	assert.Nil(t, resp.FlushedAt, "no timestamp must be reported before the first flush")
+}
@@ -0,0 +1,96 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/firecracker-microvm/firecracker-go-sdk"
+	"github.com/firecracker-microvm/firecracker-go-sdk/client/models"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+// mmdsConfigFromProto converts the MMDS configuration of a CreateVM request, whose interface IDs
+// are checked against the network interfaces of the VM.
+func mmdsConfigFromProto(cfg *proto.MMDSConfig, ifaces firecracker.NetworkInterfaces) (*models.MmdsConfig, error) {
+	mmdsCfg := &models.MmdsConfig{
+		Version: firecracker.String(cfg.Version.String()),
+	}
+
+	if cfg.IPv4Address != "" {
+		ip := net.ParseIP(cfg.IPv4Address)
+		if ip == nil || ip.To4() == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid MMDS IPv4 address %q", cfg.IPv4Address)
+		}
+		mmdsCfg.IPV4Address = firecracker.String(ip.String())
+	}
+
+	if len(cfg.NetworkInterfaceIDs) > 0 {
+		for _, id := range cfg.NetworkInterfaceIDs {
+			index, err := strconv.Atoi(id)
+			if err != nil || index < 1 || index > len(ifaces) {
+				return nil, status.Errorf(codes.InvalidArgument, "MMDS network interface %q does not exist", id)
+			}
+		}
+		mmdsCfg.NetworkInterfaces = cfg.NetworkInterfaceIDs
+	} else {
+		for i, iface := range ifaces {
+			if iface.AllowMMDS {
+				mmdsCfg.NetworkInterfaces = append(mmdsCfg.NetworkInterfaces, strconv.Itoa(i+1))
+			}
+		}
+	}
+	if len(mmdsCfg.NetworkInterfaces) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "MMDS is configured but no network interface allows it")
+	}
+
+	if cfg.Metadata != "" && !json.Valid([]byte(cfg.Metadata)) {
+		return nil, status.Error(codes.InvalidArgument, "MMDS metadata is not valid JSON")
+	}
+
+	return mmdsCfg, nil
+}
+
+// withMMDSConfig replaces the MMDS configuration of the SDK, which only sets the address, by the
+// given one. The metadata is put along with it, before the VM boots, so that the guest never
+// finds the MMDS empty.
+func withMMDSConfig(mmdsCfg *models.MmdsConfig, metadata string, logger *logrus.Entry) firecracker.Opt {
+	return func(m *firecracker.Machine) {
+		m.Handlers.FcInit = m.Handlers.FcInit.Swap(firecracker.Handler{
+			Name: firecracker.ConfigMmdsHandlerName,
+			Fn: func(ctx context.Context, m *firecracker.Machine) error {
+				client := firecracker.NewClient(m.Cfg.SocketPath, logger, false)
+				if _, err := client.PutMmdsConfig(ctx, mmdsCfg); err != nil {
+					return fmt.Errorf("failed to configure MMDS: %w", err)
+				}
+
+				if metadata == "" {
+					return nil
+				}
+				if err := m.SetMetadata(ctx, json.RawMessage(metadata)); err != nil {
+					return fmt.Errorf("failed to set initial MMDS metadata: %w", err)
+				}
+				return nil
+			},
+		})
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/firecracker-microvm/firecracker-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+func TestMMDSConfigFromProto(t *testing.T) {
+	ifaces := firecracker.NetworkInterfaces{
+		{AllowMMDS: false},
+		{AllowMMDS: true},
+	}
+
+	mmdsCfg, err := mmdsConfigFromProto(&proto.MMDSConfig{Version: proto.MMDSVersion_V2}, ifaces)
+	require.NoError(t, err)
+	assert.Equal(t, "V2", firecracker.StringValue(mmdsCfg.Version))
+	assert.Nil(t, mmdsCfg.IPV4Address)
+	assert.Equal(t, []string{"2"}, mmdsCfg.NetworkInterfaces, "interfaces must default to the ones allowing MMDS")
+
+	mmdsCfg, err = mmdsConfigFromProto(&proto.MMDSConfig{
+		IPv4Address:         "169.254.170.2",
+		NetworkInterfaceIDs: []string{"1"},
+		Metadata:            `{"latest": {}}`,
+	}, ifaces)
+	require.NoError(t, err)
+	assert.Equal(t, "V1", firecracker.StringValue(mmdsCfg.Version))
+	assert.Equal(t, "169.254.170.2", firecracker.StringValue(mmdsCfg.IPV4Address))
+	assert.Equal(t, []string{"1"}, mmdsCfg.NetworkInterfaces)
+
+	for name, cfg := range map[string]*proto.MMDSConfig{
+		"invalid address":   {IPv4Address: "fe80::1"},
+		"unknown interface": {NetworkInterfaceIDs: []string{"3"}},
+		"invalid metadata":  {Metadata: "{"},
+	} {
+		cfg := cfg
+		t.Run(name, func(t *testing.T) {
+			_, err := mmdsConfigFromProto(cfg, ifaces)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+
+	_, err = mmdsConfigFromProto(&proto.MMDSConfig{}, firecracker.NetworkInterfaces{{}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "MMDS must be reachable through an interface")
+}
@@ -33,6 +33,10 @@ type noopJailer struct {
 	shimDir vm.Dir
 	ctx     context.Context
 	pid     int
+	console *vmConsole
+
+	// firecrackerPath is the Firecracker binary to run, or empty to run the default one
+	firecrackerPath string
 }
 
 func newNoopJailer(ctx context.Context, logger *logrus.Entry, shimDir vm.Dir) *noopJailer {
@@ -45,8 +49,9 @@ func newNoopJailer(ctx context.Context, logger *logrus.Entry, shimDir vm.Dir) *n
 }
 
 func (j *noopJailer) BuildJailedMachine(cfg *config.Config, _ *firecracker.Config, vmID string) ([]firecracker.Opt, error) {
-	if len(cfg.FirecrackerBinaryPath) == 0 {
-		return []firecracker.Opt{}, nil
+	bin := j.firecrackerPath
+	if bin == "" {
+		bin = defaultFirecrackerBinary
 	}
 
 	relSocketPath, err := j.shimDir.FirecrackerSockRelPath()
@@ -55,15 +60,14 @@ func (j *noopJailer) BuildJailedMachine(cfg *config.Config, _ *firecracker.Confi
 	}
 
 	cmd := firecracker.VMCommandBuilder{}.
-		WithBin(cfg.FirecrackerBinaryPath).
+		WithBin(bin).
 		WithSocketPath(relSocketPath).
 		WithArgs([]string{"--id", vmID}).
 		Build(j.ctx)
+	// the socket and the stub drives are given relative to the shim directory
+	cmd.Dir = j.shimDir.RootPath()
 
-	if cfg.DebugHelper.LogFirecrackerOutput() {
-		cmd.Stdout = j.logger.WithField("vmm_stream", "stdout").WriterLevel(logrus.DebugLevel)
-		cmd.Stderr = j.logger.WithField("vmm_stream", "stderr").WriterLevel(logrus.DebugLevel)
-	}
+	setVMMStdio(cmd, j.console, j.logger, cfg.DebugHelper.LogFirecrackerOutput())
 
 	pidHandler := firecracker.Handler{
 		Name: "firecracker-containerd-jail-pid-handler",
@@ -0,0 +1,45 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+// claimVM hands the VM booted by a pooled shim over to the given CreateVM request. The
+// firecracker-control plugin only forwards requests matching the profile the VM was booted
+// with, so the VM is not reconfigured. From then on, the VM is known by the requested VMID.
+func (s *service) claimVM(request *proto.CreateVMRequest) (*proto.CreateVMResponse, error) {
+	err := s.waitVMReady()
+	if err != nil {
+		err = fmt.Errorf("failed to claim pooled VM: %w", err)
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
+
+	// The logger keeps the VMID of the pool, which this message ties to the claimed one
+	s.logger.WithField("claimedVMID", request.VMID).Info("pooled VM has been claimed")
+
+	s.claimedVMID.Store(request.VMID)
+	s.exitAfterAllTasksDeleted.Store(request.ExitAfterAllTasksDeleted)
+
+	err = s.publishVMStart()
+	if err != nil {
+		s.logger.WithError(err).Error("failed to publish start VM event")
+	}
+
+	return s.createVMResponse(), nil
+}
@@ -0,0 +1,293 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	eventstypes "github.com/containerd/containerd/api/events"
+	"github.com/containerd/containerd/protobuf"
+	"github.com/containerd/containerd/runtime"
+	"github.com/firecracker-microvm/firecracker-go-sdk"
+	"golang.org/x/sys/unix"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+const (
+	defaultRestartBackoff    = time.Second
+	defaultMaxRestartBackoff = time.Minute
+
+	// lostProcessExitStatus is the exit status of the processes lost along with a VM which was
+	// relaunched, as if they had been killed.
+	lostProcessExitStatus = 128 + uint32(unix.SIGKILL)
+)
+
+// validateRestartPolicy checks that the restart policy of the request can be applied to the VM.
+// Jailed VMs and VMs loaded from snapshots cannot be relaunched with the same configuration.
+func validateRestartPolicy(request *proto.CreateVMRequest) error {
+	policy := request.RestartPolicy
+	if policy == nil {
+		return nil
+	}
+	if _, ok := proto.RestartMode_name[int32(policy.Mode)]; !ok {
+		return status.Errorf(codes.InvalidArgument, "unknown restart mode %d", policy.Mode)
+	}
+	if policy.Mode == proto.RestartMode_RESTART_NEVER {
+		return nil
+	}
+
+	if request.JailerConfig != nil {
+		return status.Error(codes.InvalidArgument, "RestartPolicy cannot be set with JailerConfig")
+	}
+	if request.LoadSnapshot != nil {
+		return status.Error(codes.InvalidArgument, "RestartPolicy cannot be set when loading a snapshot")
+	}
+	if policy.MaxBackoffSeconds > 0 && policy.BackoffSeconds > policy.MaxBackoffSeconds {
+		return status.Errorf(codes.InvalidArgument, "restart backoff of %ds exceeds the maximum of %ds",
+			policy.BackoffSeconds, policy.MaxBackoffSeconds)
+	}
+	return nil
+}
+
+// shouldRestart returns whether a VM with the given restart policy is relaunched after having
+// been relaunched the given number of times already.
+func shouldRestart(policy *proto.RestartPolicy, restarts uint32) bool {
+	if policy.GetMode() != proto.RestartMode_RESTART_ON_FAILURE {
+		return false
+	}
+	return policy.MaxRetries == 0 || restarts < policy.MaxRetries
+}
+
+// restartBackoff returns the delay before the given relaunch of a VM, counted from 1. The delay
+// doubles with each relaunch up to the maximum of the policy.
+func restartBackoff(policy *proto.RestartPolicy, attempt uint32) time.Duration {
+	backoff := defaultRestartBackoff
+	if policy.BackoffSeconds > 0 {
+		backoff = time.Duration(policy.BackoffSeconds) * time.Second
+	}
+	maxBackoff := defaultMaxRestartBackoff
+	if policy.MaxBackoffSeconds > 0 {
+		maxBackoff = time.Duration(policy.MaxBackoffSeconds) * time.Second
+	}
+
+	for i := uint32(1); i < attempt && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	return backoff
+}
+
+// relaunchConfig returns the machine configuration to relaunch the VM with. The SDK fills the
+// static configuration of a CNI network interface in once CNI has set the network up, and
+// rejects interfaces having both, so it is left to the new CNI setup.
+func relaunchConfig(cfg *firecracker.Config) firecracker.Config {
+	relaunchCfg := *cfg
+	relaunchCfg.NetworkInterfaces = make(firecracker.NetworkInterfaces, len(cfg.NetworkInterfaces))
+	for i, iface := range cfg.NetworkInterfaces {
+		if iface.CNIConfiguration != nil {
+			iface.StaticConfiguration = nil
+		}
+		relaunchCfg.NetworkInterfaces[i] = iface
+	}
+	return relaunchCfg
+}
+
+// restartVM relaunches the VM, which exited unexpectedly for the given reason, as many times as
+// its restart policy allows until a relaunch succeeds. The tasks of the VM are reported as
+// exited. It returns false if the VM was not relaunched, in which case the shim must stop.
+func (s *service) restartVM(reason string) bool {
+	s.restarting.Store(true)
+	defer s.restarting.Store(false)
+
+	s.abandonTasks()
+
+	policy := s.createRequest.RestartPolicy
+	for shouldRestart(policy, s.restarts) {
+		s.restarts++
+		logger := s.logger.WithField("attempt", s.restarts)
+
+		backoff := restartBackoff(policy, s.restarts)
+		logger.Infof("relaunching VM in %s", backoff)
+		select {
+		case <-time.After(backoff):
+		case <-s.stopCh:
+			logger.Info("VM was stopped before being relaunched")
+			return false
+		}
+
+		err := s.relaunchVM()
+		if err == nil {
+			logger.Info("successfully relaunched the VM")
+			s.publishEvent(RestartEventName, &proto.VMRestart{
+				VMID:    s.boundVMID(),
+				Reason:  reason,
+				Attempt: s.restarts,
+			})
+			return true
+		}
+		logger.WithError(err).Error("failed to relaunch VM")
+
+		if s.stopping.Load() {
+			return false
+		}
+	}
+
+	s.beginStop(fmt.Sprintf("%s, and the VM was not relaunched after %d attempts", reason, s.restarts))
+	return false
+}
+
+// relaunchVM boots the VM again with the configuration it was created with, connects to its new
+// agent and mounts the drive mounts again, including the ones attached after its creation.
+func (s *service) relaunchVM() (err error) {
+	timeout := defaultCreateVMTimeout
+	if s.createRequest.TimeoutSeconds > 0 {
+		timeout = time.Duration(s.createRequest.TimeoutSeconds) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(s.shimCtx, timeout)
+	defer cancel()
+
+	// RPCs read the machine and the agent clients through vm(), which waits for the relaunch to
+	// complete. s.live is used directly below, as vm() would deadlock.
+	s.restartMu.Lock()
+	defer s.restartMu.Unlock()
+
+	// the relaunched VM boots with the stub drives, so the drives reserved in the previous VM
+	// are free again
+	s.containerStubHandler.reset()
+	attached := s.spareStubHandler.reset()
+
+	// Firecracker fails to listen on the vsock of the VM if the one of the previous VM is left
+	if err := os.Remove(s.jailer.JailPath().FirecrackerVSockPath()); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove the vsock of the previous VM: %w", err)
+	}
+
+	opts, err := s.machineOpts(ctx, s.createRequest)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			s.stopFailedVM(s.live.machine)
+		}
+	}()
+
+	if err = s.startMachine(ctx, s.createRequest, relaunchConfig(s.machineConfig), opts); err != nil {
+		return err
+	}
+
+	if err = s.mountDrives(ctx, s.live, "drive mount requested at VM creation, remounted after VM restart"); err != nil {
+		return err
+	}
+
+	vmPaths := make([]string, 0, len(attached))
+	for vmPath := range attached {
+		vmPaths = append(vmPaths, vmPath)
+	}
+	sort.Strings(vmPaths)
+	for _, vmPath := range vmPaths {
+		driveMount := attached[vmPath]
+		err = s.spareStubHandler.ReserveDriveMount(ctx, vmPath, driveMount, s.live.driveMountClient, s.live.machine)
+		if err != nil {
+			return fmt.Errorf("failed to attach drive mount %s again: %w", vmPath, err)
+		}
+		s.publishEvent(DriveMountEventName, &proto.VMDriveMount{
+			VMID:     s.boundVMID(),
+			Reason:   "drive mount attached again after VM restart",
+			HostPath: driveMount.HostPath,
+			VMPath:   driveMount.VMPath,
+		})
+	}
+
+	return nil
+}
+
+// abandonTasks reports the processes of the tasks of the VM as exited, as they were lost along
+// with the VM. They are known as lost processes until containerd deletes them.
+func (s *service) abandonTasks() {
+	procs := s.taskManager.AbandonAll()
+	exitedAt := time.Now()
+
+	taskIDs := make([]string, 0, len(procs))
+	s.lostProcsMu.Lock()
+	for taskID, execIDs := range procs {
+		taskIDs = append(taskIDs, taskID)
+		if s.lostProcs[taskID] == nil {
+			s.lostProcs[taskID] = make(map[string]time.Time)
+		}
+		for _, execID := range execIDs {
+			s.lostProcs[taskID][execID] = exitedAt
+		}
+	}
+	s.lostProcsMu.Unlock()
+
+	s.blockDeviceTasksMu.Lock()
+	for taskID := range procs {
+		// the drive of the container is not attached to the relaunched VM
+		delete(s.blockDeviceTasks, taskID)
+	}
+	s.blockDeviceTasksMu.Unlock()
+
+	sort.Strings(taskIDs)
+	for _, taskID := range taskIDs {
+		for _, execID := range procs[taskID] {
+			id := execID
+			if id == taskExecID {
+				id = taskID
+			}
+			s.logger.WithField("task_id", taskID).WithField("exec_id", execID).Info("process was lost along with the VM")
+			s.publishEvent(runtime.TaskExitEventTopic, &eventstypes.TaskExit{
+				ContainerID: taskID,
+				ID:          id,
+				ExitStatus:  lostProcessExitStatus,
+				ExitedAt:    protobuf.ToTimestamp(exitedAt),
+			})
+		}
+	}
+}
+
+// lostProcess returns when the given process was lost along with the VM, if it was.
+func (s *service) lostProcess(taskID, execID string) (time.Time, bool) {
+	s.lostProcsMu.Lock()
+	defer s.lostProcsMu.Unlock()
+
+	exitedAt, ok := s.lostProcs[taskID][execID]
+	return exitedAt, ok
+}
+
+// deleteLostProcess forgets the given lost process, and returns when it was lost if it was.
+func (s *service) deleteLostProcess(taskID, execID string) (time.Time, bool) {
+	s.lostProcsMu.Lock()
+	defer s.lostProcsMu.Unlock()
+
+	exitedAt, ok := s.lostProcs[taskID][execID]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	delete(s.lostProcs[taskID], execID)
+	if len(s.lostProcs[taskID]) == 0 {
+		delete(s.lostProcs, taskID)
+	}
+	return exitedAt, true
+}
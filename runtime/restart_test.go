@@ -0,0 +1,340 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/containerd/containerd/protobuf/types"
+	"github.com/containerd/containerd/runtime"
+	"github.com/containerd/ttrpc"
+	"github.com/firecracker-microvm/firecracker-go-sdk"
+	models "github.com/firecracker-microvm/firecracker-go-sdk/client/models"
+	"github.com/firecracker-microvm/firecracker-go-sdk/fctesting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/firecracker-microvm/firecracker-containerd/config"
+	"github.com/firecracker-microvm/firecracker-containerd/internal/debug"
+	"github.com/firecracker-microvm/firecracker-containerd/internal/vm"
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+	drivemount "github.com/firecracker-microvm/firecracker-containerd/proto/service/drivemount/ttrpc"
+)
+
+func TestValidateRestartPolicy(t *testing.T) {
+	onFailure := &proto.RestartPolicy{Mode: proto.RestartMode_RESTART_ON_FAILURE}
+
+	for _, tc := range []struct {
+		name     string
+		request  *proto.CreateVMRequest
+		expected codes.Code
+	}{
+		{
+			name:     "no policy",
+			request:  &proto.CreateVMRequest{JailerConfig: &proto.JailerConfig{}},
+			expected: codes.OK,
+		},
+		{
+			name:     "on failure",
+			request:  &proto.CreateVMRequest{RestartPolicy: onFailure},
+			expected: codes.OK,
+		},
+		{
+			name:     "unknown mode",
+			request:  &proto.CreateVMRequest{RestartPolicy: &proto.RestartPolicy{Mode: 42}},
+			expected: codes.InvalidArgument,
+		},
+		{
+			name:     "jailed",
+			request:  &proto.CreateVMRequest{RestartPolicy: onFailure, JailerConfig: &proto.JailerConfig{}},
+			expected: codes.InvalidArgument,
+		},
+		{
+			name:     "never when jailed",
+			request:  &proto.CreateVMRequest{RestartPolicy: &proto.RestartPolicy{}, JailerConfig: &proto.JailerConfig{}},
+			expected: codes.OK,
+		},
+		{
+			name:     "snapshot",
+			request:  &proto.CreateVMRequest{RestartPolicy: onFailure, LoadSnapshot: &proto.LoadSnapshotConfig{}},
+			expected: codes.InvalidArgument,
+		},
+		{
+			name: "backoff above maximum",
+			request: &proto.CreateVMRequest{RestartPolicy: &proto.RestartPolicy{
+				Mode:              proto.RestartMode_RESTART_ON_FAILURE,
+				BackoffSeconds:    10,
+				MaxBackoffSeconds: 5,
+			}},
+			expected: codes.InvalidArgument,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, status.Code(validateRestartPolicy(tc.request)))
+		})
+	}
+}
+
+func TestShouldRestart(t *testing.T) {
+	assert.False(t, shouldRestart(nil, 0), "VMs without a policy must not be relaunched")
+	assert.False(t, shouldRestart(&proto.RestartPolicy{Mode: proto.RestartMode_RESTART_NEVER}, 0))
+
+	unlimited := &proto.RestartPolicy{Mode: proto.RestartMode_RESTART_ON_FAILURE}
+	assert.True(t, shouldRestart(unlimited, 0))
+	assert.True(t, shouldRestart(unlimited, 1000))
+
+	limited := &proto.RestartPolicy{Mode: proto.RestartMode_RESTART_ON_FAILURE, MaxRetries: 2}
+	assert.True(t, shouldRestart(limited, 0))
+	assert.True(t, shouldRestart(limited, 1))
+	assert.False(t, shouldRestart(limited, 2))
+}
+
+func TestRestartBackoff(t *testing.T) {
+	defaults := &proto.RestartPolicy{}
+	assert.Equal(t, time.Second, restartBackoff(defaults, 1))
+	assert.Equal(t, 2*time.Second, restartBackoff(defaults, 2))
+	assert.Equal(t, 32*time.Second, restartBackoff(defaults, 6))
+	assert.Equal(t, time.Minute, restartBackoff(defaults, 7))
+	assert.Equal(t, time.Minute, restartBackoff(defaults, 1000))
+
+	policy := &proto.RestartPolicy{BackoffSeconds: 3, MaxBackoffSeconds: 10}
+	assert.Equal(t, 3*time.Second, restartBackoff(policy, 1))
+	assert.Equal(t, 6*time.Second, restartBackoff(policy, 2))
+	assert.Equal(t, 10*time.Second, restartBackoff(policy, 3))
+}
+
+func TestRelaunchConfig(t *testing.T) {
+	cniConfig := &firecracker.CNIConfiguration{NetworkName: "fcnet"}
+	staticConfig := &firecracker.StaticNetworkConfiguration{HostDevName: "tap0"}
+	cfg := &firecracker.Config{
+		KernelImagePath: "/path/to/kernel",
+		NetworkInterfaces: firecracker.NetworkInterfaces{
+			// filled in by the SDK once CNI has set the network up
+			{CNIConfiguration: cniConfig, StaticConfiguration: &firecracker.StaticNetworkConfiguration{HostDevName: "cni0"}},
+			{StaticConfiguration: staticConfig},
+		},
+	}
+
+	relaunchCfg := relaunchConfig(cfg)
+	assert.Equal(t, "/path/to/kernel", relaunchCfg.KernelImagePath)
+	assert.Equal(t, firecracker.NetworkInterfaces{
+		{CNIConfiguration: cniConfig},
+		{StaticConfiguration: staticConfig},
+	}, relaunchCfg.NetworkInterfaces)
+	assert.NotNil(t, cfg.NetworkInterfaces[0].StaticConfiguration, "the configuration of the VM must not be modified")
+}
+
+// abandoningTaskManager is a task manager whose processes are all lost along with the VM.
+type abandoningTaskManager struct {
+	vm.TaskManager
+	procs map[string][]string
+}
+
+func (m *abandoningTaskManager) AbandonAll() map[string][]string {
+	procs := m.procs
+	m.procs = map[string][]string{}
+	return procs
+}
+
+// recordingDriveMounter is the drive mount service of a fake agent, which records the VM paths
+// it mounts drives at and the drives it unmounts.
+type recordingDriveMounter struct {
+	drivemount.DriveMounterService
+
+	mu        sync.Mutex
+	mounted   []string
+	unmounted []string
+}
+
+func (m *recordingDriveMounter) MountDrive(_ context.Context, req *drivemount.MountDriveRequest) (*types.Empty, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.mounted = append(m.mounted, req.DestinationPath)
+	return &types.Empty{}, nil
+}
+
+func (m *recordingDriveMounter) UnmountDrive(_ context.Context, req *drivemount.UnmountDriveRequest) (*types.Empty, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.unmounted = append(m.unmounted, req.DriveID)
+	return &types.Empty{}, nil
+}
+
+// vsockListener accepts the connections to the vsock of a fake VM, acknowledging the port they
+// connect to like Firecracker does.
+type vsockListener struct {
+	net.Listener
+}
+
+func (l vsockListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	// the client waits for the acknowledgement before sending anything else
+	if _, err := bufio.NewReaderSize(conn, 16).ReadString('\n'); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if _, err := conn.Write([]byte("OK 1073741824\n")); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// serveFakeFirecracker serves an API accepting every request on the Firecracker socket of the
+// given directory, and an agent with the given drive mount service on its vsock once the VM
+// configures it.
+func serveFakeFirecracker(t *testing.T, dir vm.Dir, driveMounter drivemount.DriveMounterService) {
+	agent, err := ttrpc.NewServer()
+	require.NoError(t, err)
+	drivemount.RegisterDriveMounterService(agent, driveMounter)
+	t.Cleanup(func() { agent.Close() })
+
+	var vsockOnce sync.Once
+	api := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut && r.URL.Path == "/vsock" {
+			vsockOnce.Do(func() {
+				l, err := net.Listen("unix", dir.FirecrackerVSockPath())
+				if !assert.NoError(t, err) {
+					return
+				}
+				go agent.Serve(context.Background(), vsockListener{l})
+			})
+		}
+
+		if r.Method == http.MethodGet {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte("{}"))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})}
+
+	l, err := net.Listen("unix", dir.FirecrackerSockPath())
+	require.NoError(t, err)
+	go api.Serve(l)
+	t.Cleanup(func() { api.Close() })
+}
+
+func TestRestartVM(t *testing.T) {
+	dir := vm.Dir(t.TempDir())
+	// the shim runs in its directory, which the paths given to Firecracker are relative to
+	cwd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir.RootPath()))
+	t.Cleanup(func() { os.Chdir(cwd) })
+
+	fakeFirecracker := filepath.Join(dir.RootPath(), "fake-firecracker")
+	require.NoError(t, os.WriteFile(fakeFirecracker, []byte("#!/bin/sh\nexec sleep 60\n"), 0700))
+	driveMounter := &recordingDriveMounter{}
+	serveFakeFirecracker(t, dir, driveMounter)
+
+	uut, _ := newClockTestService(t, &fctesting.MockClient{})
+	previous := uut.live
+	debugHelper, err := debug.New()
+	require.NoError(t, err)
+	uut.config = &config.Config{DebugHelper: debugHelper}
+	jailer := newNoopJailer(uut.shimCtx, uut.logger, dir)
+	jailer.firecrackerPath = fakeFirecracker
+	uut.jailer = jailer
+	uut.vmID = "restarted"
+	uut.stopCh = make(chan struct{})
+	uut.lostProcs = make(map[string]map[string]time.Time)
+	uut.blockDeviceTasks = map[string]struct{}{"task": {}}
+	uut.taskManager = &abandoningTaskManager{procs: map[string][]string{"task": {"exec", taskExecID}}}
+	uut.createRequest = &proto.CreateVMRequest{
+		// the shim doesn't read the logs and the metrics of the fake Firecracker
+		LogFifoPath:     "unused",
+		MetricsFifoPath: "unused",
+		RestartPolicy:   &proto.RestartPolicy{Mode: proto.RestartMode_RESTART_ON_FAILURE, BackoffSeconds: 1},
+	}
+
+	relSockPath, err := dir.FirecrackerSockRelPath()
+	require.NoError(t, err)
+	relVSockPath, err := dir.FirecrackerVSockRelPath()
+	require.NoError(t, err)
+	uut.machineConfig = &firecracker.Config{
+		SocketPath:        relSockPath,
+		VsockDevices:      []firecracker.VsockDevice{{Path: relVSockPath, CID: 3}},
+		MachineCfg:        models.MachineConfiguration{VcpuCount: firecracker.Int64(1), MemSizeMib: firecracker.Int64(128)},
+		DisableValidation: true,
+	}
+	uut.containerStubHandler, err = CreateContainerStubs(uut.machineConfig, jailer, 1, uut.logger)
+	require.NoError(t, err)
+	uut.spareStubHandler, err = CreateSpareStubs(uut.machineConfig, jailer, 1, uut.logger)
+	require.NoError(t, err)
+	require.NoError(t, uut.spareStubHandler.markReserved("/logs", reservedDrive{
+		StubName:   "sparestub0",
+		DriveMount: &proto.FirecrackerDriveMount{HostPath: "/logs.img", VMPath: "/logs", FilesystemType: "ext4"},
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	envelopes, _ := uut.eventExchange.Subscribe(ctx)
+
+	require.True(t, uut.restartVM("firecracker exited"))
+	relaunched := uut.vm()
+	t.Cleanup(func() { uut.stopFailedVM(relaunched.machine) })
+
+	assert.NotSame(t, previous.machine, relaunched.machine, "the relaunched machine replaces the previous one")
+	assert.NotNil(t, relaunched.agentClient)
+	assert.NotNil(t, relaunched.driveMountClient)
+	assert.NotNil(t, relaunched.vmClockClient)
+	assert.NotEqual(t, previous.vmClockClient, relaunched.vmClockClient, "the agent clients connect to the new VM")
+	pid, err := relaunched.machine.PID()
+	require.NoError(t, err)
+	assert.Equal(t, pid, jailer.pid)
+
+	for _, execID := range []string{"exec", taskExecID} {
+		_, lost := uut.lostProcess("task", execID)
+		assert.True(t, lost, "%s must be lost along with the VM", execID)
+	}
+	assert.NotContains(t, uut.blockDeviceTasks, "task")
+	driveMounter.mu.Lock()
+	assert.Equal(t, []string{"/logs"}, driveMounter.mounted, "the attached drive mount is mounted again")
+	driveMounter.mu.Unlock()
+	count, reserved := uut.spareStubHandler.reservedDrives()
+	assert.Equal(t, 1, count)
+	assert.Contains(t, reserved, "/logs")
+
+	var topics []string
+	for topic := ""; topic != RestartEventName; {
+		select {
+		case envelope := <-envelopes:
+			topic = envelope.Topic
+			topics = append(topics, topic)
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "no restart event was published", "events: %v", topics)
+		}
+	}
+	assert.Equal(t, []string{
+		runtime.TaskExitEventTopic,
+		runtime.TaskExitEventTopic,
+		DriveMountEventName,
+		RestartEventName,
+	}, topics)
+}
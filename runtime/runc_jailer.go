@@ -42,6 +42,13 @@ const (
 	networkNamespaceRuncName = "network"
 	cacheTopologyPath        = "/sys/devices/system/cpu/cpu0/cache"
 	cacheFolderPrefix        = "index"
+
+	// defaultMemoryOverheadMib is the memory Firecracker may use above the memory of the VM when
+	// its memory is limited.
+	defaultMemoryOverheadMib = 64
+	// minIOWeight and maxIOWeight bound the IO weights of the OCI runtime spec.
+	minIOWeight = 10
+	maxIOWeight = 1000
 )
 
 var cacheTopologyPaths = []string{
@@ -71,6 +78,10 @@ type runcJailer struct {
 	configSpec specs.Spec
 	runcClient runc.Runc
 	started    bool
+	console    *vmConsole
+
+	// firecrackerPath is the Firecracker binary copied into the jail
+	firecrackerPath string
 }
 
 const firecrackerFileName = "firecracker"
@@ -87,6 +98,13 @@ type runcJailerConfig struct {
 
 	// DriveExposePolicy defines how the jailer exposes files.
 	DriveExposePolicy proto.DriveExposePolicy
+
+	// MemoryOverheadMib is the memory Firecracker may use above the memory of the VM, or 0 if
+	// its memory is not limited.
+	MemoryOverheadMib uint32
+	// BlockIO and Pids are the IO and pids limits of the cgroup of Firecracker, if any.
+	BlockIO *specs.LinuxBlockIO
+	Pids    *specs.LinuxPids
 }
 
 func newRuncJailer(
@@ -231,7 +249,7 @@ func (j *runcJailer) BuildJailedRootHandler(cfg *config.Config, machineConfig *f
 			// copy the firecracker binary
 			j.logger.WithField("root path", rootPath).Debug("copying firecracker binary")
 			newFirecrackerBinPath := filepath.Join(rootPath, firecrackerFileName)
-			if err := j.copyFileToJail(cfg.FirecrackerBinaryPath, newFirecrackerBinPath, 0500); err != nil {
+			if err := j.copyFileToJail(j.firecrackerPath, newFirecrackerBinPath, 0500); err != nil {
 				return err
 			}
 
@@ -504,10 +522,7 @@ func (j *runcJailer) jailerCommand(containerName string, isDebug bool) *exec.Cmd
 	cmd := exec.CommandContext(j.ctx, j.Config.RuncBinPath, "run", containerName)
 	cmd.Dir = j.OCIBundlePath()
 
-	if isDebug {
-		cmd.Stdout = j.logger.WithField("vmm_stream", "stdout").WriterLevel(logrus.DebugLevel)
-		cmd.Stderr = j.logger.WithField("vmm_stream", "stderr").WriterLevel(logrus.DebugLevel)
-	}
+	setVMMStdio(cmd, j.console, j.logger, isDebug)
 
 	return cmd
 }
@@ -551,6 +566,21 @@ func (j *runcJailer) overwriteConfig(machineConfig *firecracker.Config, socketPa
 	spec.Linux.Resources.CPU.Cpus = j.Config.CPUs
 	spec.Linux.Resources.CPU.Mems = j.Config.Mems
 
+	if j.Config.MemoryOverheadMib > 0 {
+		if spec.Linux.Resources.Memory == nil {
+			spec.Linux.Resources.Memory = &specs.LinuxMemory{}
+		}
+		limitMib := firecracker.Int64Value(machineConfig.MachineCfg.MemSizeMib) + int64(j.Config.MemoryOverheadMib)
+		limit := limitMib * 1024 * 1024
+		spec.Linux.Resources.Memory.Limit = &limit
+	}
+	if j.Config.BlockIO != nil {
+		spec.Linux.Resources.BlockIO = j.Config.BlockIO
+	}
+	if j.Config.Pids != nil {
+		spec.Linux.Resources.Pids = j.Config.Pids
+	}
+
 	configBytes, err := json.Marshal(&spec)
 	if err != nil {
 		return err
@@ -563,6 +593,93 @@ func (j *runcJailer) overwriteConfig(machineConfig *firecracker.Config, socketPa
 	return nil
 }
 
+// memoryOverheadMib returns the memory Firecracker may use above the memory of the VM under the
+// given jailer config, or 0 if its memory is not limited.
+func memoryOverheadMib(cfg *proto.JailerConfig) uint32 {
+	if !cfg.LimitMemory {
+		return 0
+	}
+	if cfg.MemoryOverheadMib == 0 {
+		return defaultMemoryOverheadMib
+	}
+	return cfg.MemoryOverheadMib
+}
+
+// blockIOFromProto returns the cgroup IO limits of the given jailer IO limits, looking up the
+// numbers of their block devices.
+func blockIOFromProto(limits *proto.JailerIOLimits) (*specs.LinuxBlockIO, error) {
+	if limits == nil {
+		return nil, nil
+	}
+
+	blockIO := &specs.LinuxBlockIO{}
+	if limits.Weight != 0 {
+		weight, err := ioWeight(limits.Weight)
+		if err != nil {
+			return nil, err
+		}
+		blockIO.Weight = &weight
+	}
+
+	for _, limit := range limits.Devices {
+		device, err := blockIODevice(limit.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		if limit.Weight != 0 {
+			weight, err := ioWeight(limit.Weight)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", limit.Path, err)
+			}
+			blockIO.WeightDevice = append(blockIO.WeightDevice, specs.LinuxWeightDevice{
+				LinuxBlockIODevice: device,
+				Weight:             &weight,
+			})
+		}
+
+		for _, throttle := range []struct {
+			rate    uint64
+			devices *[]specs.LinuxThrottleDevice
+		}{
+			{limit.ReadBps, &blockIO.ThrottleReadBpsDevice},
+			{limit.WriteBps, &blockIO.ThrottleWriteBpsDevice},
+			{limit.ReadIops, &blockIO.ThrottleReadIOPSDevice},
+			{limit.WriteIops, &blockIO.ThrottleWriteIOPSDevice},
+		} {
+			if throttle.rate != 0 {
+				*throttle.devices = append(*throttle.devices, specs.LinuxThrottleDevice{
+					LinuxBlockIODevice: device,
+					Rate:               throttle.rate,
+				})
+			}
+		}
+	}
+	return blockIO, nil
+}
+
+func ioWeight(weight uint32) (uint16, error) {
+	if weight < minIOWeight || weight > maxIOWeight {
+		return 0, fmt.Errorf("IO weight %d is not between %d and %d", weight, minIOWeight, maxIOWeight)
+	}
+	return uint16(weight), nil
+}
+
+// blockIODevice returns the numbers of the block device at the given path.
+func blockIODevice(path string) (specs.LinuxBlockIODevice, error) {
+	var stat unix.Stat_t
+	if err := unix.Stat(path, &stat); err != nil {
+		return specs.LinuxBlockIODevice{}, fmt.Errorf("failed to stat IO limited device: %w", err)
+	}
+	if stat.Mode&unix.S_IFMT != unix.S_IFBLK {
+		return specs.LinuxBlockIODevice{}, fmt.Errorf("%s is not a block device", path)
+	}
+	return specs.LinuxBlockIODevice{
+		Major: int64(unix.Major(uint64(stat.Rdev))),
+		Minor: int64(unix.Minor(uint64(stat.Rdev))),
+	}, nil
+}
+
 func (j runcJailer) CgroupPath() string {
 	basePath := "/firecracker-containerd"
 	if j.Config.CgroupPath != "" {
@@ -15,6 +15,7 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"syscall"
@@ -24,6 +25,7 @@ import (
 
 	"github.com/firecracker-microvm/firecracker-go-sdk"
 	models "github.com/firecracker-microvm/firecracker-go-sdk/client/models"
+	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -63,11 +65,11 @@ func TestBuildJailedRootHandler(t *testing.T) {
 	vmID := "foo"
 	jailer, err := newRuncJailer(ctx, l, vmID, runcConfig, []*proto.FirecrackerDriveMount{})
 	require.NoError(t, err, "failed to create runc jailer")
+	jailer.firecrackerPath = firecrackerPath
 
 	cfg := config.Config{
-		FirecrackerBinaryPath: firecrackerPath,
-		KernelImagePath:       kernelImagePath,
-		RootDrive:             rootDrivePath,
+		KernelImagePath: kernelImagePath,
+		RootDrive:       rootDrivePath,
 	}
 	machineConfig := firecracker.Config{
 		SocketPath:      "/path/to/api.socket",
@@ -101,6 +103,96 @@ func TestBuildJailedRootHandler(t *testing.T) {
 	assert.NoError(t, err, "failed to create root drive")
 }
 
+func TestOverwriteConfigResources(t *testing.T) {
+	dir := t.TempDir()
+
+	data, err := os.ReadFile("./firecracker-runc-config.json.example")
+	require.NoError(t, err)
+	var spec specs.Spec
+	require.NoError(t, json.Unmarshal(data, &spec))
+
+	weight := uint16(500)
+	j := &runcJailer{
+		logger: logrus.NewEntry(logrus.New()),
+		Config: runcJailerConfig{
+			UID:               123,
+			GID:               456,
+			CPUs:              "0-1",
+			MemoryOverheadMib: memoryOverheadMib(&proto.JailerConfig{LimitMemory: true}),
+			BlockIO:           &specs.LinuxBlockIO{Weight: &weight},
+			Pids:              &specs.LinuxPids{Limit: 64},
+		},
+		vmID:       "foo",
+		configSpec: spec,
+	}
+	machineConfig := firecracker.Config{
+		MachineCfg: models.MachineConfiguration{MemSizeMib: firecracker.Int64(256)},
+	}
+	configPath := filepath.Join(dir, "config.json")
+	require.NoError(t, j.overwriteConfig(&machineConfig, "api.socket", configPath))
+
+	data, err = os.ReadFile(configPath)
+	require.NoError(t, err)
+	var written specs.Spec
+	require.NoError(t, json.Unmarshal(data, &written))
+
+	resources := written.Linux.Resources
+	assert.Equal(t, "0-1", resources.CPU.Cpus)
+	require.NotNil(t, resources.Memory)
+	assert.Equal(t, int64((256+defaultMemoryOverheadMib)*1024*1024), *resources.Memory.Limit)
+	assert.Equal(t, &specs.LinuxBlockIO{Weight: &weight}, resources.BlockIO)
+	assert.Equal(t, &specs.LinuxPids{Limit: 64}, resources.Pids)
+}
+
+func TestMemoryOverheadMib(t *testing.T) {
+	assert.Equal(t, uint32(0), memoryOverheadMib(&proto.JailerConfig{MemoryOverheadMib: 32}))
+	assert.Equal(t, uint32(defaultMemoryOverheadMib), memoryOverheadMib(&proto.JailerConfig{LimitMemory: true}))
+	assert.Equal(t, uint32(32), memoryOverheadMib(&proto.JailerConfig{LimitMemory: true, MemoryOverheadMib: 32}))
+}
+
+func TestBlockIOFromProto(t *testing.T) {
+	blockIO, err := blockIOFromProto(nil)
+	require.NoError(t, err)
+	assert.Nil(t, blockIO)
+
+	blockIO, err = blockIOFromProto(&proto.JailerIOLimits{Weight: 100})
+	require.NoError(t, err)
+	weight := uint16(100)
+	assert.Equal(t, &specs.LinuxBlockIO{Weight: &weight}, blockIO)
+
+	_, err = blockIOFromProto(&proto.JailerIOLimits{Weight: 5000})
+	assert.Error(t, err, "weight out of range")
+
+	file := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(file, nil, 0600))
+	_, err = blockIOFromProto(&proto.JailerIOLimits{Devices: []*proto.JailerDeviceIOLimit{{Path: file, ReadBps: 1024}}})
+	assert.EqualError(t, err, file+" is not a block device")
+
+	devices, err := filepath.Glob("/sys/class/block/*/dev")
+	require.NoError(t, err)
+	if len(devices) == 0 {
+		return
+	}
+	path := filepath.Join("/dev", filepath.Base(filepath.Dir(devices[0])))
+	if _, err := os.Stat(path); err != nil {
+		return
+	}
+	blockIO, err = blockIOFromProto(&proto.JailerIOLimits{Devices: []*proto.JailerDeviceIOLimit{{
+		Path:      path,
+		Weight:    200,
+		ReadBps:   1024,
+		WriteIops: 10,
+	}}})
+	require.NoError(t, err)
+	require.Len(t, blockIO.WeightDevice, 1)
+	assert.Equal(t, uint16(200), *blockIO.WeightDevice[0].Weight)
+	require.Len(t, blockIO.ThrottleReadBpsDevice, 1)
+	assert.Equal(t, uint64(1024), blockIO.ThrottleReadBpsDevice[0].Rate)
+	assert.Equal(t, blockIO.WeightDevice[0].LinuxBlockIODevice, blockIO.ThrottleReadBpsDevice[0].LinuxBlockIODevice)
+	assert.Empty(t, blockIO.ThrottleWriteBpsDevice)
+	assert.Len(t, blockIO.ThrottleWriteIOPSDevice, 1)
+}
+
 func TestMkdirAllWithPermissions(t *testing.T) {
 	// requires isolation so we can change uid/gid of files
 	internal.RequiresRoot(t)
@@ -25,6 +25,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -36,6 +37,7 @@ import (
 	taskAPI "github.com/containerd/containerd/api/runtime/task/v2"
 	"github.com/containerd/containerd/api/types/task"
 	"github.com/containerd/containerd/cio"
+	"github.com/containerd/containerd/errdefs"
 	"github.com/containerd/containerd/events/exchange"
 	"github.com/containerd/containerd/log"
 	"github.com/containerd/containerd/namespaces"
@@ -47,6 +49,7 @@ import (
 	"github.com/containerd/ttrpc"
 	"github.com/firecracker-microvm/firecracker-go-sdk"
 	"github.com/firecracker-microvm/firecracker-go-sdk/client/models"
+	ops "github.com/firecracker-microvm/firecracker-go-sdk/client/operations"
 	"github.com/firecracker-microvm/firecracker-go-sdk/vsock"
 	"github.com/gofrs/uuid"
 	"github.com/hashicorp/go-multierror"
@@ -61,9 +64,13 @@ import (
 	"github.com/firecracker-microvm/firecracker-containerd/internal/bundle"
 	"github.com/firecracker-microvm/firecracker-containerd/internal/vm"
 	"github.com/firecracker-microvm/firecracker-containerd/proto"
+	agenthealth "github.com/firecracker-microvm/firecracker-containerd/proto/service/agenthealth/ttrpc"
 	drivemount "github.com/firecracker-microvm/firecracker-containerd/proto/service/drivemount/ttrpc"
 	fccontrolTtrpc "github.com/firecracker-microvm/firecracker-containerd/proto/service/fccontrol/ttrpc"
 	ioproxy "github.com/firecracker-microvm/firecracker-containerd/proto/service/ioproxy/ttrpc"
+	vmclock "github.com/firecracker-microvm/firecracker-containerd/proto/service/vmclock/ttrpc"
+	vmcopy "github.com/firecracker-microvm/firecracker-containerd/proto/service/vmcopy/ttrpc"
+	vmexec "github.com/firecracker-microvm/firecracker-containerd/proto/service/vmexec/ttrpc"
 )
 
 func init() {
@@ -82,6 +89,7 @@ const (
 	defaultStopVMTimeout       = 5 * time.Second
 	defaultShutdownTimeout     = 5 * time.Second
 	defaultVSockConnectTimeout = 5 * time.Second
+	defaultClockSyncTimeout    = 5 * time.Second
 
 	// StartEventName is the topic published to when a VM starts
 	StartEventName = "/firecracker-vm/start"
@@ -89,6 +97,36 @@ const (
 	// StopEventName is the topic published to when a VM stops
 	StopEventName = "/firecracker-vm/stop"
 
+	// PauseEventName is the topic published to when a VM is paused
+	PauseEventName = "/firecracker-vm/pause"
+
+	// ResumeEventName is the topic published to when a VM is resumed
+	ResumeEventName = "/firecracker-vm/resume"
+
+	// BalloonUpdateEventName is the topic published to when the target size of a VM's balloon changes
+	BalloonUpdateEventName = "/firecracker-vm/balloon-update"
+
+	// DriveMountEventName is the topic published to when a drive is mounted inside a VM
+	DriveMountEventName = "/firecracker-vm/drive-mount"
+
+	// DriveUnmountEventName is the topic published to when a drive is unmounted inside a VM
+	DriveUnmountEventName = "/firecracker-vm/drive-unmount"
+
+	// AgentDisconnectEventName is the topic published to when the connection to the in-VM agent is lost
+	AgentDisconnectEventName = "/firecracker-vm/agent-disconnect"
+
+	// ForceTerminateEventName is the topic published to when a VM is forcefully terminated
+	ForceTerminateEventName = "/firecracker-vm/force-terminate"
+
+	// UnexpectedExitEventName is the topic published to when Firecracker exits without being stopped
+	UnexpectedExitEventName = "/firecracker-vm/unexpected-exit"
+
+	// AgentUnhealthyEventName is the topic published to when the in-VM agent stops answering pings
+	AgentUnhealthyEventName = "/firecracker-vm/agent-unhealthy"
+
+	// RestartEventName is the topic published to when a VM is relaunched after Firecracker exited unexpectedly
+	RestartEventName = "/firecracker-vm/restart"
+
 	// taskExecID is a special exec ID that is pointing its task itself.
 	// While the constant is defined here, the convention is coming from containerd.
 	taskExecID = ""
@@ -127,30 +165,68 @@ type service struct {
 	shimCtx    context.Context
 	shimCancel func()
 
-	vmID    string
-	shimDir vm.Dir
+	// vmID is the VMID the shim was started for, which its directories are named after. The VM
+	// is known by boundVMID() once it has been claimed from a pool.
+	vmID        string
+	claimedVMID atomic.Value
+	shimDir     vm.Dir
 
 	config *config.Config
+	// firecrackerBinaries are the Firecracker binaries of the config, probed once by the first
+	// CreateVM so that the shim subcommands which never create a VM don't run them
+	firecrackerBinaries     map[string]firecrackerBinary
+	firecrackerBinariesOnce sync.Once
+	// firecrackerPath and firecracker are the path and the capabilities of the Firecracker
+	// binary the VM runs with, the path being empty for the default binary of the SDK
+	firecrackerPath string
+	firecracker     internal.FirecrackerCapabilities
+	// cpuTemplate is the custom CPU template of the VM, if any
+	cpuTemplate *internal.CustomCPUTemplate
 
 	// vmReady is closed once CreateVM has been successfully called
 	vmReady                  chan struct{}
 	vmStartOnce              sync.Once
-	agentClient              taskAPI.TaskService
-	eventBridgeClient        eventbridge.Getter
-	driveMountClient         drivemount.DriveMounterService
-	ioProxyClient            ioproxy.IOProxyService
 	jailer                   jailer
 	containerStubHandler     *StubDriveHandler
+	spareStubHandler         *StubDriveHandler
 	driveMountStubs          []MountableStubDrive
-	exitAfterAllTasksDeleted bool // exit the VM and shim when all tasks are deleted
-
-	blockDeviceTasks map[string]struct{}
+	driveMounts              []*proto.FirecrackerDriveMount
+	rootDriveRateLimiter     *proto.FirecrackerRateLimiter
+	drivesMu                 sync.Mutex  // serializes rate limiter updates of the root drive and drive mount stubs
+	exitAfterAllTasksDeleted atomic.Bool // exit the VM and shim when all tasks are deleted
+
+	blockDeviceTasks   map[string]struct{}
+	blockDeviceTasksMu sync.Mutex
+
+	// createRequest is the request the VM was created with, which it is relaunched with
+	createRequest *proto.CreateVMRequest
+	// live is the running machine of the VM and the clients of its agent, read with vm()
+	live vmHandle
+	// restartMu guards live, which a relaunch of the VM replaces
+	restartMu  sync.RWMutex
+	restarting atomic.Bool
+	restarts   uint32
+	relaunched chan struct{} // signaled once the VM has been relaunched
+	// lostProcs have the exit times of the processes lost along with a VM which was relaunched.
+	// The key is [taskID][execID].
+	lostProcs   map[string]map[string]time.Time
+	lostProcsMu sync.Mutex
 
 	cleanupErr  error
 	cleanupOnce sync.Once
 
-	machine          *firecracker.Machine
 	machineConfig    *firecracker.Config
+	metrics          *vmMetrics
+	console          *vmConsole
+	drivesExhausted  atomic.Uint64
+	createdAt        time.Time // when CreateVM started creating the VM
+	pooled           atomic.Bool
+	stopping         atomic.Bool
+	stopCh           chan struct{} // closed once the VM starts stopping
+	stopOnce         sync.Once
+	stopReason       string
+	stopStage        proto.VMStopStage
+	stopReasonMu     sync.Mutex
 	vsockIOPortCount uint32
 	vsockPortMu      sync.Mutex
 
@@ -168,6 +244,37 @@ func shimOpts(shimCtx context.Context) (*shim.Opts, error) {
 	return &opts, nil
 }
 
+// boundVMID returns the VMID the VM is known by, which is the one it was claimed as if it was
+// booted into a pool.
+func (s *service) boundVMID() string {
+	if id, ok := s.claimedVMID.Load().(string); ok {
+		return id
+	}
+	return s.vmID
+}
+
+// vmHandle is the Firecracker machine of the VM and the clients of its agent. A relaunch of the
+// VM replaces them all at once.
+type vmHandle struct {
+	machine           *firecracker.Machine
+	agentClient       taskAPI.TaskService
+	eventBridgeClient eventbridge.Getter
+	driveMountClient  drivemount.DriveMounterService
+	ioProxyClient     ioproxy.IOProxyService
+	vmExecClient      vmexec.VMExecService
+	agentHealthClient agenthealth.AgentHealthService
+	vmCopyClient      vmcopy.VMCopyService
+	vmClockClient     vmclock.VMClockService
+}
+
+// vm returns the running machine of the VM and the clients of its agent. It must not be called
+// with restartMu held.
+func (s *service) vm() vmHandle {
+	s.restartMu.RLock()
+	defer s.restartMu.RUnlock()
+	return s.live
+}
+
 // NewService creates new runtime shim.
 func NewService(shimCtx context.Context, id string, remotePublisher shim.Publisher, shimCancel func()) (shim.Shim, error) {
 	cfg, err := config.LoadConfig("")
@@ -222,9 +329,14 @@ func NewService(shimCtx context.Context, id string, remotePublisher shim.Publish
 		vmReady:          make(chan struct{}),
 		jailer:           newNoopJailer(shimCtx, logger, shimDir),
 		blockDeviceTasks: make(map[string]struct{}),
+		lostProcs:        make(map[string]map[string]time.Time),
+		stopCh:           make(chan struct{}),
+		relaunched:       make(chan struct{}, 1),
 		fifos:            make(map[string]map[string]cio.Config),
 	}
 
+	s.pooled.Store(os.Getenv(internal.PooledVMEnvVarKey) != "")
+
 	s.startEventForwarders(remotePublisher)
 
 	err = s.serveFCControl()
@@ -253,15 +365,26 @@ func (s *service) startEventForwarders(remotePublisher shim.Publisher) {
 	go func() {
 		<-s.vmReady
 
-		// Once the VM is ready, also start forwarding events from it to our exchange
-		attachCh := eventbridge.Attach(ctx, s.eventBridgeClient, s.eventExchange)
+		for {
+			// Once the VM is ready, also start forwarding events from it to our exchange
+			eventBridgeClient := s.vm().eventBridgeClient
+			attachCh := eventbridge.Attach(ctx, eventBridgeClient, s.eventExchange)
 
-		err := <-attachCh
-		if err != nil && err != context.Canceled {
-			s.logger.WithError(err).Error("error while forwarding events from VM agent")
+			err := <-attachCh
+			if err != nil && err != context.Canceled {
+				s.logger.WithError(err).Error("error while forwarding events from VM agent")
+			}
+
+			// then from the agent of the VM relaunched after it exited, if any
+			select {
+			case <-s.relaunched:
+				continue
+			case <-s.shimCtx.Done():
+			}
+			break
 		}
 
-		err = <-republishCh
+		err := <-republishCh
 		if err != nil && err != context.Canceled {
 			s.logger.WithError(err).Error("error while republishing events")
 		}
@@ -452,6 +575,9 @@ func (s *service) nextVSockPort() uint32 {
 func (s *service) waitVMReady() error {
 	select {
 	case <-s.vmReady:
+		if s.restarting.Load() {
+			return status.Error(codes.Unavailable, "VM is being relaunched")
+		}
 		return nil
 	case <-time.After(vmReadyTimeout):
 		return status.Error(codes.DeadlineExceeded, "timed out waiting for VM start")
@@ -473,14 +599,20 @@ func (s *service) CreateVM(requestCtx context.Context, request *proto.CreateVMRe
 	var (
 		err       error
 		createRan bool
-		resp      proto.CreateVMResponse
+		pooled    bool
 	)
 
 	s.vmStartOnce.Do(func() {
+		// a pooled VM can only be claimed once this returns
+		pooled = s.pooled.Load()
+		s.createdAt = time.Now()
 		err = s.createVM(ctxWithTimeout, request)
 		createRan = true
 	})
 	if !createRan {
+		if s.pooled.CompareAndSwap(true, false) {
+			return s.claimVM(request)
+		}
 		return nil, status.Error(codes.AlreadyExists, "shim cannot create VM more than once")
 	}
 
@@ -494,33 +626,47 @@ func (s *service) CreateVM(requestCtx context.Context, request *proto.CreateVMRe
 		return nil, fmt.Errorf("failed to create VM: %w", err)
 	}
 
-	// creating the VM succeeded, setup monitors and publish events to celebrate
-	err = s.publishVMStart()
-	if err != nil {
-		s.logger.WithError(err).Error("failed to publish start VM event")
+	// creating the VM succeeded, setup monitors and publish events to celebrate. A pooled VM only
+	// starts for its consumers once it is claimed.
+	if !pooled {
+		err = s.publishVMStart()
+		if err != nil {
+			s.logger.WithError(err).Error("failed to publish start VM event")
+		}
 	}
 
 	go s.monitorVMExit()
+	go s.watchAgent()
 	// let all the other methods know that the VM is ready for tasks
 	close(s.vmReady)
 
-	resp.VMID = s.vmID
-	resp.MetricsFifoPath = s.machineConfig.MetricsFifo
-	resp.LogFifoPath = s.machineConfig.LogFifo
-	resp.SocketPath = s.shimDir.FirecrackerSockPath()
+	return s.createVMResponse(), nil
+}
+
+func (s *service) createVMResponse() *proto.CreateVMResponse {
+	resp := &proto.CreateVMResponse{
+		VMID:            s.boundVMID(),
+		MetricsFifoPath: s.machineConfig.MetricsFifo,
+		LogFifoPath:     s.machineConfig.LogFifo,
+		SocketPath:      s.shimDir.FirecrackerSockPath(),
+	}
 	if c, ok := s.jailer.(cgroupPather); ok {
 		resp.CgroupPath = c.CgroupPath()
 	}
-
-	return &resp, nil
+	resp.FirecrackerVersion = s.firecracker.Version.String()
+	return resp
 }
 
 func (s *service) publishVMStart() error {
-	return s.eventExchange.Publish(s.shimCtx, StartEventName, &proto.VMStart{VMID: s.vmID})
+	return s.eventExchange.Publish(s.shimCtx, StartEventName, &proto.VMStart{VMID: s.boundVMID()})
 }
 
 func (s *service) publishVMStop() error {
-	return s.eventExchange.Publish(s.shimCtx, StopEventName, &proto.VMStop{VMID: s.vmID})
+	s.stopReasonMu.Lock()
+	reason, stage := s.stopReason, s.stopStage
+	s.stopReasonMu.Unlock()
+
+	return s.eventExchange.Publish(s.shimCtx, StopEventName, &proto.VMStop{VMID: s.boundVMID(), Reason: reason, Stage: stage})
 }
 
 func (s *service) createVM(requestCtx context.Context, request *proto.CreateVMRequest) (err error) {
@@ -542,6 +688,24 @@ func (s *service) createVM(requestCtx context.Context, request *proto.CreateVMRe
 	}
 
 	s.logger.Info("creating new VM")
+	if err = s.selectFirecrackerBinary(request.FirecrackerBinary); err != nil {
+		return err
+	}
+	if err = checkFirecrackerCapabilities(s.firecracker, request); err != nil {
+		return err
+	}
+	if err = validateRestartPolicy(request); err != nil {
+		return err
+	}
+	if s.cpuTemplate, err = s.customCPUTemplate(request); err != nil {
+		return err
+	}
+
+	s.console, err = newVMConsole(dir.ConsoleLogFilePath())
+	if err != nil {
+		return err
+	}
+
 	s.jailer, err = newJailer(s.shimCtx, s.logger, dir.RootPath(), s, request)
 	if err != nil {
 		return fmt.Errorf("failed to create jailer: %w", err)
@@ -556,11 +720,49 @@ func (s *service) createVM(requestCtx context.Context, request *proto.CreateVMRe
 		}
 	}()
 
+	var snapshot *snapshotState
+	if request.LoadSnapshot != nil {
+		snapshot, err = s.prepareSnapshotLoad(request)
+		if err != nil {
+			return fmt.Errorf("failed to prepare snapshot load: %w", err)
+		}
+	}
+
 	s.machineConfig, err = s.buildVMConfiguration(request)
 	if err != nil {
 		return fmt.Errorf("failed to build VM configuration: %w", err)
 	}
+	s.driveMounts = request.DriveMounts
+	s.createRequest = request
+
+	opts, err := s.machineOpts(requestCtx, request)
+	if err != nil {
+		return err
+	}
+
+	s.restartMu.Lock()
+	err = s.startMachine(requestCtx, request, *s.machineConfig, opts)
+	s.restartMu.Unlock()
+	if err != nil {
+		return err
+	}
+	s.exitAfterAllTasksDeleted.Store(request.ExitAfterAllTasksDeleted)
+
+	if snapshot != nil {
+		err = s.resumeFromSnapshot(requestCtx, snapshot)
+	} else {
+		err = s.mountDrives(requestCtx, s.vm(), "drive mount requested at VM creation")
+	}
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("successfully started the VM")
+	return nil
+}
 
+// machineOpts returns the options to create the Firecracker machine of the requested VM with.
+func (s *service) machineOpts(requestCtx context.Context, request *proto.CreateVMRequest) ([]firecracker.Opt, error) {
 	opts := []firecracker.Opt{}
 
 	if v, ok := s.config.DebugHelper.GetFirecrackerSDKLogLevel(); ok {
@@ -568,14 +770,10 @@ func (s *service) createVM(requestCtx context.Context, request *proto.CreateVMRe
 		logger.Logger.SetLevel(v)
 		opts = append(opts, firecracker.WithLogger(logger))
 	}
-	relVSockPath, err := s.jailer.JailPath().FirecrackerVSockRelPath()
-	if err != nil {
-		return fmt.Errorf("failed to get relative path to firecracker vsock: %w", err)
-	}
 
 	jailedOpts, err := s.jailer.BuildJailedMachine(s.config, s.machineConfig, s.vmID)
 	if err != nil {
-		return fmt.Errorf("failed to build jailed machine options: %w", err)
+		return nil, fmt.Errorf("failed to build jailed machine options: %w", err)
 	}
 
 	if request.BalloonDevice == nil {
@@ -584,79 +782,362 @@ func (s *service) createVM(requestCtx context.Context, request *proto.CreateVMRe
 		// Creates a new balloon device if one does not already exist, otherwise updates it, before machine startup.
 		balloon, err := s.createBalloon(requestCtx, request)
 		if err != nil {
-			return fmt.Errorf("failed to create balloon device: %w", err)
+			return nil, fmt.Errorf("failed to create balloon device: %w", err)
 		}
 		balloonOpts, err := s.buildBalloonDeviceOpt(balloon)
 		if err != nil {
-			return fmt.Errorf("failed to create balloon device options: %w", err)
+			return nil, fmt.Errorf("failed to create balloon device options: %w", err)
 		}
 		opts = append(opts, balloonOpts...)
 	}
 
 	opts = append(opts, jailedOpts...)
+	if s.firecracker.Snapshots && s.checkSnapshotSupport() == nil {
+		opts = append(opts, func(m *firecracker.Machine) {
+			m.Handlers.FcInit = m.Handlers.FcInit.AppendAfter(firecracker.CreateBootSourceHandlerName,
+				newRelativeStubDrivesHandler(s.jailer.JailPath().RootPath()))
+		})
+	}
+
+	if s.cpuTemplate != nil {
+		opts = append(opts, func(m *firecracker.Machine) {
+			m.Handlers.FcInit = m.Handlers.FcInit.AppendAfter(firecracker.CreateMachineHandlerName,
+				newCustomCPUTemplateHandler(s.cpuTemplate))
+		})
+	}
+
+	if request.MMDSConfig != nil {
+		if request.LoadSnapshot != nil {
+			return nil, status.Error(codes.InvalidArgument, "MMDSConfig cannot be set when loading a snapshot")
+		}
+		mmdsCfg, err := mmdsConfigFromProto(request.MMDSConfig, s.machineConfig.NetworkInterfaces)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, withMMDSConfig(mmdsCfg, request.MMDSConfig.Metadata, s.logger))
+	}
+
+	if request.LoadSnapshot != nil {
+		opts = append(opts, withSnapshotLoad(request.LoadSnapshot, s.logger))
+	}
+
+	return opts, nil
+}
+
+// startMachine starts Firecracker with the given configuration and connects to the agent of the
+// VM once it has booted. The machine and the agent clients replace the live ones, so the caller
+// holds restartMu.
+func (s *service) startMachine(requestCtx context.Context, request *proto.CreateVMRequest, cfg firecracker.Config, opts []firecracker.Opt) (err error) {
+	relVSockPath, err := s.jailer.JailPath().FirecrackerVSockRelPath()
+	if err != nil {
+		return fmt.Errorf("failed to get relative path to firecracker vsock: %w", err)
+	}
+
+	if request.MetricsFifoPath == "" {
+		// Unless the client reads the metrics FIFO itself, the shim collects the metrics
+		if err = s.collectMetrics(cfg.MetricsPath); err != nil {
+			return err
+		}
+	}
+
+	if request.LogFifoPath == "" {
+		// Likewise, the shim forwards the logs of Firecracker to its own unless the client reads them
+		if err = s.forwardLogs(cfg.LogPath); err != nil {
+			return err
+		}
+	}
 
 	// In the event that a noop jailer is used, we will pass in the shim context
 	// and have the SDK construct a new machine using that context. Otherwise, a
 	// custom process runner will be provided via options which will stomp over
 	// the shim context that was provided here.
-	s.machine, err = firecracker.NewMachine(s.shimCtx, *s.machineConfig, opts...)
+	machine, err := firecracker.NewMachine(s.shimCtx, cfg, opts...)
 	if err != nil {
 		return fmt.Errorf("failed to create new machine instance: %w", err)
 	}
+	s.live = vmHandle{machine: machine}
 
-	if err = s.machine.Start(s.shimCtx); err != nil {
+	if request.LoadSnapshot != nil {
+		// Machine.Start would also send InstanceStart, which Firecracker rejects for a VM
+		// loaded from a snapshot, so only the handlers are run. Unlike Start, this doesn't
+		// clean up after a failure.
+		if err = machine.Handlers.Run(s.shimCtx, machine); err != nil {
+			s.stopFailedVM(machine)
+			return fmt.Errorf("failed to restore the VM from snapshot: %w", err)
+		}
+	} else if err = machine.Start(s.shimCtx); err != nil {
 		return fmt.Errorf("failed to start the VM: %w", err)
 	}
 
+	defer func() {
+		if err != nil {
+			s.stopFailedVM(machine)
+		}
+	}()
+
 	s.logger.Info("calling agent")
 	conn, err := vsock.DialContext(requestCtx, relVSockPath, defaultVsockPort, vsock.WithLogger(s.logger))
 	if err != nil {
 		return fmt.Errorf("failed to dial the VM over vsock: %w", err)
 	}
 
-	rpcClient := ttrpc.NewClient(conn, ttrpc.WithOnClose(func() { _ = conn.Close() }))
-	s.agentClient = taskAPI.NewTaskClient(rpcClient)
-	s.eventBridgeClient = eventbridge.NewGetterClient(rpcClient)
-	s.driveMountClient = drivemount.NewDriveMounterClient(rpcClient)
-	s.ioProxyClient = ioproxy.NewIOProxyClient(rpcClient)
-	s.exitAfterAllTasksDeleted = request.ExitAfterAllTasksDeleted
-
-	err = s.mountDrives(requestCtx)
-	if err != nil {
-		return err
+	rpcClient := ttrpc.NewClient(conn, ttrpc.WithOnClose(func() {
+		_ = conn.Close()
+		if !s.stopping.Load() {
+			s.publishEvent(AgentDisconnectEventName, &proto.VMAgentDisconnect{
+				VMID:   s.boundVMID(),
+				Reason: "vsock connection to the agent was closed",
+			})
+		}
+	}))
+	s.live = vmHandle{
+		machine:           machine,
+		agentClient:       taskAPI.NewTaskClient(rpcClient),
+		eventBridgeClient: eventbridge.NewGetterClient(rpcClient),
+		driveMountClient:  drivemount.NewDriveMounterClient(rpcClient),
+		ioProxyClient:     ioproxy.NewIOProxyClient(rpcClient),
+		vmExecClient:      vmexec.NewVMExecClient(rpcClient),
+		agentHealthClient: agenthealth.NewAgentHealthClient(rpcClient),
+		vmCopyClient:      vmcopy.NewVMCopyClient(rpcClient),
+		vmClockClient:     vmclock.NewVMClockClient(rpcClient),
 	}
 
-	s.logger.Info("successfully started the VM")
 	return nil
 }
 
-func (s *service) mountDrives(requestCtx context.Context) error {
-	for _, stubDrive := range s.driveMountStubs {
-		err := stubDrive.PatchAndMount(requestCtx, s.machine, s.driveMountClient)
+// stopFailedVM stops a VM which failed to start and waits for the SDK to clean its network and
+// sockets up.
+func (s *service) stopFailedVM(machine *firecracker.Machine) {
+	if err := machine.StopVMM(); err != nil {
+		s.logger.WithError(err).Debug("failed to stop firecracker")
+	}
+
+	ctx, cancel := context.WithTimeout(s.shimCtx, defaultStopVMTimeout)
+	defer cancel()
+	if err := machine.Wait(ctx); err != nil && ctx.Err() != nil {
+		s.logger.WithError(err).Warn("firecracker did not exit after failing to start")
+	}
+}
+
+// mountDrives mounts the drive mounts the VM was created with in the VM of the given handle.
+func (s *service) mountDrives(requestCtx context.Context, h vmHandle, reason string) error {
+	for i, stubDrive := range s.driveMountStubs {
+		err := stubDrive.PatchAndMount(requestCtx, h.machine, h.driveMountClient)
 		if err != nil {
 			return fmt.Errorf("failed to patch drive mount stub: %w", err)
 		}
+		s.publishEvent(DriveMountEventName, &proto.VMDriveMount{
+			VMID:     s.boundVMID(),
+			Reason:   reason,
+			HostPath: s.driveMounts[i].HostPath,
+			VMPath:   s.driveMounts[i].VMPath,
+		})
+	}
+	return nil
+}
+
+// AttachDriveMount patches one of the VM's spare stub drives with the requested drive mount and mounts it
+// inside the VM. The drive mount is identified by its VM path, which DetachDriveMount takes to detach it.
+func (s *service) AttachDriveMount(requestCtx context.Context, request *proto.AttachDriveMountRequest) (*types.Empty, error) {
+	defer logPanicAndDie(s.logger)
+
+	err := s.waitVMReady()
+	if err != nil {
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
+
+	driveMount := request.DriveMount
+	if driveMount == nil {
+		return nil, status.Error(codes.InvalidArgument, "no drive mount was specified")
+	}
+	if !strings.HasPrefix(driveMount.HostPath, "/") || !strings.HasPrefix(driveMount.VMPath, "/") {
+		return nil, status.Errorf(codes.InvalidArgument, "driveMount %s contains relative path", driveMount.String())
+	}
+
+	h := s.vm()
+	err = s.spareStubHandler.ReserveDriveMount(requestCtx, driveMount.VMPath, driveMount, h.driveMountClient, h.machine)
+	if errors.Is(err, ErrDrivesExhausted) {
+		s.drivesExhausted.Add(1)
+		return nil, status.Errorf(codes.ResourceExhausted, "no spare drive left to attach %s", driveMount.VMPath)
+	} else if err != nil {
+		err = fmt.Errorf("failed to attach drive mount: %w", err)
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
+
+	s.publishEvent(DriveMountEventName, &proto.VMDriveMount{
+		VMID:     s.boundVMID(),
+		Reason:   "drive mount attached",
+		HostPath: driveMount.HostPath,
+		VMPath:   driveMount.VMPath,
+	})
+	return &types.Empty{}, nil
+}
+
+// DetachDriveMount unmounts a drive mount attached with AttachDriveMount and returns its stub drive to the
+// VM's spare drives.
+func (s *service) DetachDriveMount(requestCtx context.Context, request *proto.DetachDriveMountRequest) (*types.Empty, error) {
+	defer logPanicAndDie(s.logger)
+
+	err := s.waitVMReady()
+	if err != nil {
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
+
+	driveMount := s.spareStubHandler.driveMount(request.VMPath)
+	if driveMount == nil {
+		return nil, status.Errorf(codes.NotFound, "no drive mount is attached at %s", request.VMPath)
+	}
+
+	h := s.vm()
+	err = s.spareStubHandler.Release(requestCtx, request.VMPath, h.driveMountClient, h.machine)
+	if err != nil {
+		err = fmt.Errorf("failed to detach drive mount: %w", err)
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
+
+	s.publishEvent(DriveUnmountEventName, &proto.VMDriveUnmount{
+		VMID:     s.boundVMID(),
+		Reason:   "drive mount detached",
+		HostPath: driveMount.HostPath,
+		VMPath:   driveMount.VMPath,
+	})
+	return &types.Empty{}, nil
+}
+
+// UpdateDrive replaces the rate limiter of a drive of the running VM. The drive is either the root
+// drive, a drive mount or a container's drive, or any stub drive when identified by its drive ID.
+func (s *service) UpdateDrive(requestCtx context.Context, request *proto.UpdateDriveRequest) (*types.Empty, error) {
+	defer logPanicAndDie(s.logger)
+
+	err := s.waitVMReady()
+	if err != nil {
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
+
+	if (request.VMPath == "") == (request.DriveID == "") {
+		return nil, status.Error(codes.InvalidArgument, "exactly one of VMPath and DriveID must be specified")
+	}
+
+	found, err := s.updateDriveRateLimiter(requestCtx, request)
+	if err != nil {
+		err = fmt.Errorf("failed to update drive: %w", err)
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "no drive found for VMPath %q and DriveID %q", request.VMPath, request.DriveID)
+	}
+
+	return &types.Empty{}, nil
+}
+
+func (s *service) updateDriveRateLimiter(requestCtx context.Context, request *proto.UpdateDriveRequest) (bool, error) {
+	s.drivesMu.Lock()
+	defer s.drivesMu.Unlock()
+
+	machine := s.vm().machine
+	if root := s.rootDrive(); root != nil {
+		rootDriveID := firecracker.StringValue(root.DriveID)
+		if request.DriveID == rootDriveID || request.VMPath == "/" {
+			if err := patchDriveRateLimiter(requestCtx, machine, rootDriveID, request.RateLimiter); err != nil {
+				return true, err
+			}
+			s.rootDriveRateLimiter = request.RateLimiter
+			return true, nil
+		}
+	}
+
+	for _, stub := range s.driveMountStubs {
+		if drive, ok := stub.(stubDrive); ok && drive.matches(request.DriveID, request.VMPath) {
+			return true, drive.patchRateLimiter(requestCtx, machine, request.RateLimiter)
+		}
+	}
+
+	for _, handler := range []*StubDriveHandler{s.containerStubHandler, s.spareStubHandler} {
+		found, err := handler.UpdateRateLimiter(requestCtx, request.DriveID, request.VMPath, request.RateLimiter, machine)
+		if found {
+			return true, err
+		}
+	}
+
+	return false, nil
+}
+
+// ListDrives returns the drives of the VM: the root drive, followed by the container stub drives,
+// the drive mount stub drives and the spare stub drives.
+func (s *service) ListDrives(requestCtx context.Context, request *proto.ListDrivesRequest) (*proto.ListDrivesResponse, error) {
+	defer logPanicAndDie(s.logger)
+
+	err := s.waitVMReady()
+	if err != nil {
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
+
+	s.drivesMu.Lock()
+	defer s.drivesMu.Unlock()
+
+	resp := &proto.ListDrivesResponse{}
+	if root := s.rootDrive(); root != nil {
+		resp.Drives = append(resp.Drives, &proto.FirecrackerDriveInfo{
+			DriveID:      firecracker.StringValue(root.DriveID),
+			HostPath:     firecracker.StringValue(root.PathOnHost),
+			VMPath:       "/",
+			RateLimiter:  s.rootDriveRateLimiter,
+			IsRootDevice: true,
+			IsWritable:   !firecracker.BoolValue(root.IsReadOnly),
+		})
+	}
+
+	resp.Drives = append(resp.Drives, s.containerStubHandler.drives()...)
+	for _, stub := range s.driveMountStubs {
+		if drive, ok := stub.(stubDrive); ok {
+			resp.Drives = append(resp.Drives, drive.driveInfo(true))
+		}
+	}
+	resp.Drives = append(resp.Drives, s.spareStubHandler.drives()...)
+
+	return resp, nil
+}
+
+func (s *service) rootDrive() *models.Drive {
+	for i, drive := range s.machineConfig.Drives {
+		if firecracker.BoolValue(drive.IsRootDevice) {
+			return &s.machineConfig.Drives[i]
+		}
 	}
 	return nil
 }
 
 // StopVM will shutdown the VMM. Unlike Shutdown, this method is exposed to containerd clients.
-// If the VM has not been created yet and the timeout is hit waiting for it to exist, an error will be returned
-// but the shim will continue to shutdown. Similarly if we detect that the VM is in pause state, then
-// we are unable to communicate to the in-VM agent. In this case, we do a forceful shutdown.
+// The VM is stopped in stages of increasing force, see terminate. If the VM has not been created
+// yet and the timeout is hit waiting for it to exist, an error will be returned but the shim will
+// continue to shutdown.
 func (s *service) StopVM(requestCtx context.Context, request *proto.StopVMRequest) (_ *types.Empty, err error) {
 	defer logPanicAndDie(s.logger)
-	s.logger.WithFields(logrus.Fields{"timeout_seconds": request.TimeoutSeconds}).Debug("StopVM")
+	s.logger.WithFields(logrus.Fields{
+		"timeout_seconds":      request.TimeoutSeconds,
+		"grace_period_seconds": request.GracePeriodSeconds,
+	}).Debug("StopVM")
 
 	timeout := defaultStopVMTimeout
 	if request.TimeoutSeconds > 0 {
 		timeout = time.Duration(request.TimeoutSeconds) * time.Second
 	}
+	gracePeriod := defaultStopGracePeriod
+	if request.GracePeriodSeconds > 0 {
+		gracePeriod = time.Duration(request.GracePeriodSeconds) * time.Second
+	}
 
-	ctx, cancel := context.WithTimeout(requestCtx, timeout)
+	ctx, cancel := context.WithTimeout(requestCtx, stopTimeout(gracePeriod, timeout))
 	defer cancel()
 
-	if err = s.terminate(ctx); err != nil {
+	if err = s.terminate(ctx, gracePeriod); err != nil {
 		return nil, err
 	}
 	return &types.Empty{}, nil
@@ -672,10 +1153,12 @@ func (s *service) ResumeVM(ctx context.Context, req *proto.ResumeVMRequest) (*ty
 		return nil, err
 	}
 
-	if err := s.machine.ResumeVM(ctx); err != nil {
+	if err := s.vm().machine.ResumeVM(ctx); err != nil {
 		s.logger.WithError(err).Error()
 		return nil, err
 	}
+	s.publishEvent(ResumeEventName, &proto.VMResume{VMID: s.boundVMID(), Reason: "requested"})
+	s.syncGuestClock(ctx, "resumed")
 
 	return &types.Empty{}, nil
 }
@@ -690,10 +1173,11 @@ func (s *service) PauseVM(ctx context.Context, req *proto.PauseVMRequest) (*type
 		return nil, err
 	}
 
-	if err := s.machine.PauseVM(ctx); err != nil {
+	if err := s.vm().machine.PauseVM(ctx); err != nil {
 		s.logger.WithError(err).Error()
 		return nil, err
 	}
+	s.publishEvent(PauseEventName, &proto.VMPause{VMID: s.boundVMID(), Reason: "requested"})
 
 	return &types.Empty{}, nil
 }
@@ -715,15 +1199,54 @@ func (s *service) GetVMInfo(requestCtx context.Context, request *proto.GetVMInfo
 	}
 
 	return &proto.GetVMInfoResponse{
-		VMID:            s.vmID,
+		VMID:            s.boundVMID(),
 		SocketPath:      s.shimDir.FirecrackerSockPath(),
 		LogFifoPath:     s.machineConfig.LogPath,
 		MetricsFifoPath: s.machineConfig.MetricsPath,
 		CgroupPath:      cgroupPath,
 		VSockPath:       s.shimDir.FirecrackerVSockPath(),
+		ShimPID:         uint32(os.Getpid()),
+		State:           s.vmState(requestCtx),
+		CreatedAt:       protobuf.ToTimestamp(s.createdAt),
 	}, nil
 }
 
+// ListVMs returns metadata for the single VM being managed by this shim. Listing all VMs of a namespace
+// is handled by the firecracker-control plugin, which aggregates the responses of each shim.
+func (s *service) ListVMs(requestCtx context.Context, request *proto.ListVMsRequest) (*proto.ListVMsResponse, error) {
+	defer logPanicAndDie(s.logger)
+
+	info, err := s.GetVMInfo(requestCtx, &proto.GetVMInfoRequest{VMID: s.boundVMID()})
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.ListVMsResponse{VMs: []*proto.GetVMInfoResponse{info}}, nil
+}
+
+// vmState returns the lifecycle state of the VM as reported by Firecracker, or STOPPING
+// once the shim has started terminating the VM.
+func (s *service) vmState(ctx context.Context) proto.VMState {
+	if s.stopping.Load() {
+		return proto.VMState_STOPPING
+	}
+
+	info, err := s.vm().machine.DescribeInstanceInfo(ctx)
+	if err != nil || info.State == nil {
+		s.logger.WithError(err).Debug("failed to get instance info")
+		return proto.VMState_UNKNOWN
+	}
+
+	switch *info.State {
+	case models.InstanceInfoStateRunning:
+		return proto.VMState_RUNNING
+	case models.InstanceInfoStatePaused:
+		return proto.VMState_PAUSED
+	default:
+		return proto.VMState_UNKNOWN
+	}
+}
+
 // SetVMMetadata will update the VM being managed by this shim with the provided metadata. If the VM has not been created yet, this
 // method will wait for up to a hardcoded timeout for it to exist, returning an error if the timeout is reached.
 func (s *service) SetVMMetadata(requestCtx context.Context, request *proto.SetVMMetadataRequest) (*types.Empty, error) {
@@ -737,7 +1260,7 @@ func (s *service) SetVMMetadata(requestCtx context.Context, request *proto.SetVM
 
 	s.logger.Info("setting VM metadata")
 	jayson := json.RawMessage(request.Metadata)
-	if err := s.machine.SetMetadata(requestCtx, jayson); err != nil {
+	if err := s.vm().machine.SetMetadata(requestCtx, jayson); err != nil {
 		err = fmt.Errorf("failed to set VM metadata: %w", err)
 		s.logger.WithError(err).Error()
 		return nil, err
@@ -761,7 +1284,7 @@ func (s *service) UpdateVMMetadata(requestCtx context.Context, request *proto.Up
 
 	s.logger.Info("updating VM metadata")
 	jayson := json.RawMessage(request.Metadata)
-	if err := s.machine.UpdateMetadata(requestCtx, jayson); err != nil {
+	if err := s.vm().machine.UpdateMetadata(requestCtx, jayson); err != nil {
 		err = fmt.Errorf("failed to update VM metadata: %w", err)
 		s.logger.WithError(err).Error()
 		return nil, err
@@ -785,7 +1308,7 @@ func (s *service) GetVMMetadata(requestCtx context.Context, request *proto.GetVM
 
 	s.logger.Info("Get VM metadata")
 	var metadata json.RawMessage
-	if err := s.machine.GetMetadata(requestCtx, &metadata); err != nil {
+	if err := s.vm().machine.GetMetadata(requestCtx, &metadata); err != nil {
 		err = fmt.Errorf("failed to get VM metadata: %w", err)
 		s.logger.WithError(err).Error()
 		return nil, err
@@ -829,7 +1352,7 @@ func (s *service) GetBalloonConfig(requestCtx context.Context, req *proto.GetBal
 	}
 
 	s.logger.Info("Getting configuration for the balloon device")
-	balloon, err := s.machine.GetBalloonConfig(requestCtx)
+	balloon, err := s.vm().machine.GetBalloonConfig(requestCtx)
 	if err != nil {
 		return nil, errors.New("Failed to get balloon configuration. Please check if you have successfully created a balloon device")
 	}
@@ -852,11 +1375,64 @@ func (s *service) UpdateBalloon(requestCtx context.Context, req *proto.UpdateBal
 	}
 
 	s.logger.Infof("Updating balloon memory size, the new amount memory is %d MiB", req.AmountMib)
-	if err := s.machine.UpdateBalloon(requestCtx, req.AmountMib); err != nil {
+	if err := s.vm().machine.UpdateBalloon(requestCtx, req.AmountMib); err != nil {
 		err = fmt.Errorf("failed to update memory balloon: %w", err)
 		s.logger.WithError(err).Error()
 		return nil, err
 	}
+	s.publishEvent(BalloonUpdateEventName, &proto.VMBalloonUpdate{
+		VMID:      s.boundVMID(),
+		Reason:    "requested",
+		AmountMib: req.AmountMib,
+	})
+
+	return &types.Empty{}, nil
+}
+
+// UpdateNetworkInterface updates the rate limiters of a network interface of the running VM.
+func (s *service) UpdateNetworkInterface(requestCtx context.Context, req *proto.UpdateNetworkInterfaceRequest) (*types.Empty, error) {
+	defer logPanicAndDie(s.logger)
+
+	err := s.waitVMReady()
+	if err != nil {
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
+
+	if req.InRateLimiter == nil && req.OutRateLimiter == nil {
+		return nil, status.Error(codes.InvalidArgument, "at least one of InRateLimiter and OutRateLimiter must be specified")
+	}
+
+	if int(req.InterfaceIndex) >= len(s.machineConfig.NetworkInterfaces) {
+		return nil, status.Errorf(codes.NotFound, "VM has no network interface at index %d", req.InterfaceIndex)
+	}
+
+	// Firecracker numbers the interfaces from 1, see createNetworkInterfaces of the SDK
+	ifaceID := strconv.Itoa(int(req.InterfaceIndex) + 1)
+
+	var inRateLimiter, outRateLimiter *models.RateLimiter
+	if req.InRateLimiter != nil {
+		inRateLimiter = rateLimiterPatchFromProto(req.InRateLimiter)
+	}
+	if req.OutRateLimiter != nil {
+		outRateLimiter = rateLimiterPatchFromProto(req.OutRateLimiter)
+	}
+
+	s.logger.Infof("Updating rate limiters of network interface %s", ifaceID)
+	err = s.vm().machine.UpdateGuestNetworkInterfaceRateLimit(requestCtx, ifaceID,
+		firecracker.RateLimiterSet{
+			InRateLimiter:  inRateLimiter,
+			OutRateLimiter: outRateLimiter,
+		},
+		// The SDK sends the inbound rate limiter as the outbound one too
+		func(params *ops.PatchGuestNetworkInterfaceByIDParams) {
+			params.Body.TxRateLimiter = outRateLimiter
+		})
+	if err != nil {
+		err = fmt.Errorf("failed to update network interface: %w", err)
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
 
 	return &types.Empty{}, nil
 }
@@ -872,7 +1448,7 @@ func (s *service) GetBalloonStats(requestCtx context.Context, req *proto.GetBall
 	}
 
 	s.logger.Info("Getting statistics for the balloon device")
-	balloonStats, err := s.machine.GetBalloonStats(requestCtx)
+	balloonStats, err := s.vm().machine.GetBalloonStats(requestCtx)
 	if err != nil {
 		err = fmt.Errorf("failed to get balloon statistics: %w", err)
 		s.logger.WithError(err).Error()
@@ -919,7 +1495,7 @@ func (s *service) UpdateBalloonStats(requestCtx context.Context, req *proto.Upda
 	}
 
 	s.logger.Info("updating balloon device statistics interval")
-	if err := s.machine.UpdateBalloonStats(requestCtx, req.StatsPollingIntervals); err != nil {
+	if err := s.vm().machine.UpdateBalloonStats(requestCtx, req.StatsPollingIntervals); err != nil {
 		err = fmt.Errorf("failed to update balloon device statistics interval: %w", err)
 		s.logger.WithError(err).Error()
 		return nil, err
@@ -955,14 +1531,15 @@ func (s *service) buildVMConfiguration(req *proto.CreateVMRequest) (*firecracker
 		}},
 		MachineCfg: machineConfigurationFromProto(s.config, req.MachineCfg),
 		LogLevel:   s.config.DebugHelper.GetFirecrackerLogLevel(),
-		VMID:       s.vmID,
+		VMID:       s.boundVMID(),
 	}
 
 	flag, err := internal.SupportCPUTemplate()
 	if err != nil {
 		return nil, err
 	}
-	if !flag {
+	if !flag || s.cpuTemplate != nil {
+		// a custom CPU template replaces the static one of the runtime config
 		cfg.MachineCfg.CPUTemplate = ""
 	}
 
@@ -1010,6 +1587,9 @@ func (s *service) buildVMConfiguration(req *proto.CreateVMRequest) (*firecracker
 	}
 
 	cfg.Drives = s.buildRootDrive(req)
+	if req.RootDrive != nil {
+		s.rootDriveRateLimiter = req.RootDrive.RateLimiter
+	}
 
 	// Drives configuration
 	containerCount := int(req.ContainerCount)
@@ -1032,6 +1612,12 @@ func (s *service) buildVMConfiguration(req *proto.CreateVMRequest) (*firecracker
 		return nil, fmt.Errorf("failed to create drive mount stub drives: %w", err)
 	}
 
+	s.spareStubHandler, err = CreateSpareStubs(
+		&cfg, s.jailer, int(req.SpareDriveCount), s.logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create spare stub drives: %w", err)
+	}
+
 	// If no value for NetworkInterfaces was specified (not even an empty but non-nil list) and
 	// the runtime config specifies a default list, use those defaults
 	if req.NetworkInterfaces == nil {
@@ -1188,14 +1774,26 @@ func (s *service) Create(requestCtx context.Context, request *taskAPI.CreateTask
 	// Only mount the container's rootfs as a block device if the mount doesn't
 	// signal that it is only accessible from inside the VM.
 	if !isVMLocalRootfs {
+		h := s.vm()
 		err = s.containerStubHandler.Reserve(requestCtx, request.ID,
-			rootfsMnt.Source, vmBundleDir.RootfsPath(), "ext4", nil, s.driveMountClient, s.machine)
+			rootfsMnt.Source, vmBundleDir.RootfsPath(), "ext4", nil, h.driveMountClient, h.machine)
+		if errors.Is(err, ErrDrivesExhausted) {
+			s.drivesExhausted.Add(1)
+		}
 		if err != nil {
 			err = fmt.Errorf("failed to get stub drive for task %q: %w", request.ID, err)
 			logger.WithError(err).Error()
 			return nil, err
 		}
+		s.blockDeviceTasksMu.Lock()
 		s.blockDeviceTasks[request.ID] = struct{}{}
+		s.blockDeviceTasksMu.Unlock()
+		s.publishEvent(DriveMountEventName, &proto.VMDriveMount{
+			VMID:     s.boundVMID(),
+			Reason:   fmt.Sprintf("rootfs of task %s", request.ID),
+			HostPath: rootfsMnt.Source,
+			VMPath:   vmBundleDir.RootfsPath(),
+		})
 	}
 
 	ociConfigBytes, err := hostBundleDir.OCIConfig().Bytes()
@@ -1279,16 +1877,24 @@ func (s *service) Delete(requestCtx context.Context, req *taskAPI.DeleteRequest)
 
 	logger.Debug("delete")
 
-	agent, err := s.agent()
-	if err != nil {
-		return nil, err
-	}
-	resp, err := s.taskManager.DeleteProcess(requestCtx, req, agent)
-	if err != nil {
-		return nil, err
+	var resp *taskAPI.DeleteResponse
+	if exitedAt, lost := s.deleteLostProcess(req.ID, req.ExecID); lost {
+		resp = &taskAPI.DeleteResponse{
+			ExitedAt:   protobuf.ToTimestamp(exitedAt),
+			ExitStatus: lostProcessExitStatus,
+		}
+	} else {
+		agent, err := s.agent()
+		if err != nil {
+			return nil, err
+		}
+		resp, err = s.taskManager.DeleteProcess(requestCtx, req, agent)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	err = s.deleteFIFOs(req.ID, req.ExecID)
+	err := s.deleteFIFOs(req.ID, req.ExecID)
 	if err != nil {
 		return nil, err
 	}
@@ -1301,10 +1907,22 @@ func (s *service) Delete(requestCtx context.Context, req *taskAPI.DeleteRequest)
 
 	var result *multierror.Error
 
-	if _, contains := s.blockDeviceTasks[req.ID]; contains {
+	s.blockDeviceTasksMu.Lock()
+	_, contains := s.blockDeviceTasks[req.ID]
+	s.blockDeviceTasksMu.Unlock()
+	if contains {
 		// Trying to release stub drive for further reuse
-		if err := s.containerStubHandler.Release(requestCtx, req.ID, s.driveMountClient, s.machine); err != nil {
+		driveMount := s.containerStubHandler.driveMount(req.ID)
+		h := s.vm()
+		if err := s.containerStubHandler.Release(requestCtx, req.ID, h.driveMountClient, h.machine); err != nil {
 			result = multierror.Append(fmt.Errorf("failed to release stub drive for container: %s: %w", req.ID, err))
+		} else {
+			s.publishEvent(DriveUnmountEventName, &proto.VMDriveUnmount{
+				VMID:     s.boundVMID(),
+				Reason:   fmt.Sprintf("task %s deleted", req.ID),
+				HostPath: driveMount.GetHostPath(),
+				VMPath:   driveMount.GetVMPath(),
+			})
 		}
 	}
 
@@ -1398,6 +2016,16 @@ func (s *service) State(requestCtx context.Context, req *taskAPI.StateRequest) (
 
 	logger := log.G(requestCtx).WithFields(logrus.Fields{"task_id": req.ID, "exec_id": req.ExecID})
 	logger.Debug("state")
+	if exitedAt, lost := s.lostProcess(req.ID, req.ExecID); lost {
+		return &taskAPI.StateResponse{
+			ID:         req.ID,
+			ExecID:     req.ExecID,
+			Status:     task.Status_STOPPED,
+			ExitStatus: lostProcessExitStatus,
+			ExitedAt:   protobuf.ToTimestamp(exitedAt),
+		}, nil
+	}
+
 	agent, err := s.agent()
 	if err != nil {
 		return nil, err
@@ -1422,7 +2050,7 @@ func (s *service) State(requestCtx context.Context, req *taskAPI.StateRequest) (
 	resp.Stdout = host.Stdout
 	resp.Stderr = host.Stderr
 
-	state, err := s.ioProxyClient.State(requestCtx, &ioproxy.StateRequest{ID: req.ID, ExecID: req.ExecID})
+	state, err := s.vm().ioProxyClient.State(requestCtx, &ioproxy.StateRequest{ID: req.ID, ExecID: req.ExecID})
 	if err != nil {
 		return nil, err
 	}
@@ -1452,7 +2080,7 @@ func (s *service) attachNewProxy(
 		StdoutPort: s.nextVSockPort(),
 		StderrPort: s.nextVSockPort(),
 	}
-	_, err := s.ioProxyClient.Attach(ctx, &attach)
+	_, err := s.vm().ioProxyClient.Attach(ctx, &attach)
 	if err != nil {
 		return err
 	}
@@ -1514,6 +2142,10 @@ func (s *service) Kill(requestCtx context.Context, req *taskAPI.KillRequest) (*t
 	defer logPanicAndDie(log.G(requestCtx))
 
 	log.G(requestCtx).WithFields(logrus.Fields{"task_id": req.ID, "exec_id": req.ExecID}).Debug("kill")
+	if _, lost := s.lostProcess(req.ID, req.ExecID); lost {
+		return nil, errdefs.ToGRPCf(errdefs.ErrNotFound, "process already finished")
+	}
+
 	agent, err := s.agent()
 	if err != nil {
 		return nil, err
@@ -1606,31 +2238,52 @@ func (s *service) Shutdown(requestCtx context.Context, req *taskAPI.ShutdownRequ
 	defer logPanicAndDie(log.G(requestCtx))
 	s.logger.WithFields(logrus.Fields{"task_id": req.ID, "now": req.Now}).Debug("Shutdown")
 
-	shouldShutdown := req.Now || s.exitAfterAllTasksDeleted && s.taskManager.ShutdownIfEmpty()
+	shouldShutdown := req.Now || s.exitAfterAllTasksDeleted.Load() && s.taskManager.ShutdownIfEmpty()
 	if !shouldShutdown {
 		return &types.Empty{}, nil
 	}
 
-	ctx, cancel := context.WithTimeout(requestCtx, defaultShutdownTimeout)
+	ctx, cancel := context.WithTimeout(requestCtx, stopTimeout(defaultStopGracePeriod, defaultShutdownTimeout))
 	defer cancel()
 
-	if err := s.terminate(ctx); err != nil {
+	if err := s.terminate(ctx, defaultStopGracePeriod); err != nil {
 		return &types.Empty{}, err
 	}
 
 	return &types.Empty{}, nil
 }
 
+// syncGuestClock sets the clock of the VM, which stops while the VM is paused, to the time of the
+// host. The VM keeps running with a skewed clock if it fails.
+func (s *service) syncGuestClock(ctx context.Context, reason string) {
+	ctx, cancel := context.WithTimeout(ctx, defaultClockSyncTimeout)
+	defer cancel()
+
+	resp, err := s.vm().vmClockClient.SyncClock(ctx, &vmclock.SyncClockRequest{
+		VMID:             s.boundVMID(),
+		Reason:           reason,
+		HostTimeUnixNano: time.Now().UnixNano(),
+	})
+	if err != nil {
+		s.logger.WithError(err).Warn("failed to synchronize the clock of the VM")
+		return
+	}
+	s.logger.WithField("skew", time.Duration(resp.SkewNanoseconds)).Debug("synchronized the clock of the VM")
+}
+
 func (s *service) isPaused(ctx context.Context) (bool, error) {
-	info, err := s.machine.DescribeInstanceInfo(ctx)
+	info, err := s.vm().machine.DescribeInstanceInfo(ctx)
 	if err != nil {
 		return false, fmt.Errorf("failed to get instance info %v: %w", info, err)
 	}
 	return *info.State == models.InstanceInfoStatePaused, nil
 }
 
-func (s *service) forceTerminate(ctx context.Context) error {
-	s.logger.Errorf("forcefully terminate VM %s", s.vmID)
+func (s *service) forceTerminate(ctx context.Context, reason string) error {
+	s.logger.Errorf("forcefully terminate VM %s: %s", s.boundVMID(), reason)
+	s.beginStop("forcefully terminated: " + reason)
+	s.enterStopStage(proto.VMStopStage_VMM_KILLED)
+	s.publishEvent(ForceTerminateEventName, &proto.VMForceTerminate{VMID: s.boundVMID(), Reason: reason})
 
 	err := s.jailer.Stop(true)
 	if err != nil {
@@ -1642,53 +2295,7 @@ func (s *service) forceTerminate(ctx context.Context) error {
 		s.logger.WithError(err).Error("failed to cleanup")
 	}
 
-	return status.Errorf(codes.Internal, "forcefully terminated VM %s", s.vmID)
-}
-
-func (s *service) terminate(ctx context.Context) (retErr error) {
-	var success bool
-	defer func() {
-		if !success {
-			retErr = s.forceTerminate(ctx)
-		}
-	}()
-
-	err := s.waitVMReady()
-	if err != nil {
-		s.logger.WithError(err).Error("failed to wait VM")
-		return
-	}
-
-	paused, err := s.isPaused(ctx)
-	if err != nil {
-		s.logger.WithError(err).Error("failed to check VM")
-		return
-	}
-
-	if paused {
-		s.logger.Error("VM is paused and cannot take requests")
-		return
-	}
-
-	s.logger.Info("gracefully shutdown VM")
-	agent, err := s.agent()
-	if err != nil {
-		return err
-	}
-	_, err = agent.Shutdown(ctx, &taskAPI.ShutdownRequest{ID: s.vmID, Now: true})
-	if err != nil {
-		s.logger.WithError(err).Error("failed to call in-VM agent")
-		return
-	}
-
-	err = s.machine.Wait(ctx)
-	if err != nil {
-		s.logger.WithError(err).Error("failed to wait VM")
-		return
-	}
-
-	success = true
-	return
+	return status.Errorf(codes.Internal, "forcefully terminated VM %s", s.boundVMID())
 }
 
 func (s *service) Stats(requestCtx context.Context, req *taskAPI.StatsRequest) (*taskAPI.StatsResponse, error) {
@@ -1728,6 +2335,12 @@ func (s *service) Update(requestCtx context.Context, req *taskAPI.UpdateTaskRequ
 func (s *service) Wait(requestCtx context.Context, req *taskAPI.WaitRequest) (*taskAPI.WaitResponse, error) {
 	defer logPanicAndDie(log.G(requestCtx))
 	log.G(requestCtx).WithFields(logrus.Fields{"task_id": req.ID, "exec_id": req.ExecID}).Debug("wait")
+	if exitedAt, lost := s.lostProcess(req.ID, req.ExecID); lost {
+		return &taskAPI.WaitResponse{
+			ExitStatus: lostProcessExitStatus,
+			ExitedAt:   protobuf.ToTimestamp(exitedAt),
+		}, nil
+	}
 
 	agent, err := s.agent()
 	if err != nil {
@@ -1764,6 +2377,13 @@ func (s *service) cleanup() error {
 			s.logger.WithError(err).Error("failed to close jailer")
 		}
 
+		if s.console != nil {
+			if err := s.console.Close(); err != nil {
+				result = multierror.Append(result, err)
+				s.logger.WithError(err).Error("failed to close console")
+			}
+		}
+
 		if err := s.publishVMStop(); err != nil {
 			result = multierror.Append(result, err)
 			s.logger.WithError(err).Error("failed to publish stop VM event")
@@ -1777,11 +2397,40 @@ func (s *service) cleanup() error {
 	return s.cleanupErr
 }
 
-// monitorVMExit watches the VM and cleanup resources when it terminates.
+// monitorVMExit watches the VM and cleanup resources when it terminates, unless the VM is
+// relaunched according to its restart policy.
 func (s *service) monitorVMExit() {
-	// Block until the VM exits
-	if err := s.machine.Wait(s.shimCtx); err != nil && err != context.Canceled {
-		s.logger.WithError(err).Error("error returned from VM wait")
+	for {
+		// Block until the VM exits
+		err := s.vm().machine.Wait(s.shimCtx)
+		if err != nil && err != context.Canceled {
+			s.logger.WithError(err).Error("error returned from VM wait")
+		}
+
+		if err == context.Canceled || s.stopping.Load() {
+			break
+		}
+
+		exitStatus := exitStatusFromError(err)
+		reason := fmt.Sprintf("firecracker exited with status %d", exitStatus)
+		restart := shouldRestart(s.createRequest.RestartPolicy, s.restarts)
+		if !restart {
+			s.beginStop(reason)
+		}
+		s.publishEvent(UnexpectedExitEventName, &proto.VMUnexpectedExit{
+			VMID:       s.boundVMID(),
+			Reason:     reason,
+			ExitStatus: exitStatus,
+		})
+
+		if !restart || !s.restartVM(reason) {
+			break
+		}
+		// forward the events of the agent of the relaunched VM as well
+		select {
+		case s.relaunched <- struct{}{}:
+		default:
+		}
 	}
 
 	if err := s.cleanup(); err != nil {
@@ -1791,9 +2440,10 @@ func (s *service) monitorVMExit() {
 
 // agent returns a client to talk to in-VM agent, only if the VM is not terminated.
 func (s *service) agent() (taskAPI.TaskService, error) {
-	pid, _ := s.machine.PID()
+	h := s.vm()
+	pid, _ := h.machine.PID()
 	if pid == 0 {
-		return nil, status.Errorf(codes.NotFound, "failed to find VM %q", s.vmID)
+		return nil, status.Errorf(codes.NotFound, "failed to find VM %q", s.boundVMID())
 	}
-	return s.agentClient, nil
+	return h.agentClient, nil
 }
@@ -1778,7 +1778,7 @@ func TestStopVM_Isolated(t *testing.T) {
 		},
 
 		// Test that StopVM returns success if the VM is in paused state, instead of hanging forever.
-		// VM is force shutdown in this case, so we expect no Error or hang.
+		// The VM is resumed to be shut down gracefully in this case.
 		{
 			name:       "PauseStop",
 			withStopVM: true,
@@ -1789,9 +1789,7 @@ func TestStopVM_Isolated(t *testing.T) {
 				require.Equal(tb, status.Code(err), codes.OK)
 
 				_, err = fcClient.StopVM(ctx, &proto.StopVMRequest{VMID: req.VMID})
-				require.Error(tb, err)
-				assert.Equal(tb, codes.Internal, status.Code(err))
-				assert.Contains(tb, err.Error(), "forcefully terminated")
+				require.Equal(tb, status.Code(err), codes.OK)
 			},
 		},
 
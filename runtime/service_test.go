@@ -22,19 +22,25 @@ import (
 	"testing"
 
 	"github.com/containerd/containerd/api/runtime/task/v2"
+	"github.com/containerd/containerd/events/exchange"
 	"github.com/containerd/containerd/namespaces"
 	"github.com/firecracker-microvm/firecracker-go-sdk"
 	models "github.com/firecracker-microvm/firecracker-go-sdk/client/models"
+	ops "github.com/firecracker-microvm/firecracker-go-sdk/client/operations"
+	"github.com/firecracker-microvm/firecracker-go-sdk/fctesting"
 	"github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/firecracker-microvm/firecracker-containerd/config"
 	"github.com/firecracker-microvm/firecracker-containerd/internal"
 	"github.com/firecracker-microvm/firecracker-containerd/internal/debug"
 	"github.com/firecracker-microvm/firecracker-containerd/internal/vm"
 	"github.com/firecracker-microvm/firecracker-containerd/proto"
+	vmclock "github.com/firecracker-microvm/firecracker-containerd/proto/service/vmclock/ttrpc"
 )
 
 const (
@@ -370,3 +376,131 @@ func TestTaskCreateDoesNotLogStdoutOrStderrFields(t *testing.T) {
 		}
 	}
 }
+
+func TestUpdateNetworkInterface(t *testing.T) {
+	ctx := context.Background()
+
+	vmIsReady := make(chan struct{})
+	close(vmIsReady)
+
+	inRateLimiter := &proto.FirecrackerRateLimiter{
+		Bandwidth: &proto.FirecrackerTokenBucket{Capacity: 100, RefillTime: 1000},
+	}
+	outRateLimiter := &proto.FirecrackerRateLimiter{
+		Ops: &proto.FirecrackerTokenBucket{Capacity: 10, RefillTime: 500},
+	}
+
+	var patched *models.PartialNetworkInterface
+	mockMachine, err := firecracker.NewMachine(ctx, firecracker.Config{}, firecracker.WithClient(
+		firecracker.NewClient("/path/to/socket", nil, false, firecracker.WithOpsClient(&fctesting.MockClient{
+			PatchGuestNetworkInterfaceByIDFn: func(params *ops.PatchGuestNetworkInterfaceByIDParams) (*ops.PatchGuestNetworkInterfaceByIDNoContent, error) {
+				assert.Equal(t, "2", params.IfaceID)
+				patched = params.Body
+				return nil, nil
+			},
+		}))))
+	require.NoError(t, err, "failed to create new machine")
+
+	uut := service{
+		logger:  logrus.NewEntry(logrus.New()),
+		vmReady: vmIsReady,
+		live:    vmHandle{machine: mockMachine},
+		machineConfig: &firecracker.Config{
+			NetworkInterfaces: firecracker.NetworkInterfaces{{}, {}},
+		},
+	}
+
+	_, err = uut.UpdateNetworkInterface(ctx, &proto.UpdateNetworkInterfaceRequest{
+		InterfaceIndex: 1,
+		InRateLimiter:  inRateLimiter,
+		OutRateLimiter: outRateLimiter,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, patched)
+	assert.Equal(t, rateLimiterPatchFromProto(inRateLimiter), patched.RxRateLimiter)
+	assert.Equal(t, rateLimiterPatchFromProto(outRateLimiter), patched.TxRateLimiter)
+
+	_, err = uut.UpdateNetworkInterface(ctx, &proto.UpdateNetworkInterfaceRequest{
+		InterfaceIndex: 2,
+		InRateLimiter:  inRateLimiter,
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = uut.UpdateNetworkInterface(ctx, &proto.UpdateNetworkInterfaceRequest{InterfaceIndex: 0})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+// clockClient records the clock syncs requested by the shim.
+type clockClient struct {
+	reasons []string
+}
+
+func (c *clockClient) SyncClock(_ context.Context, req *vmclock.SyncClockRequest) (*vmclock.SyncClockResponse, error) {
+	c.reasons = append(c.reasons, req.Reason)
+	return &vmclock.SyncClockResponse{}, nil
+}
+
+func newClockTestService(t *testing.T, client *fctesting.MockClient) (*service, *clockClient) {
+	ctx := namespaces.WithNamespace(context.Background(), "test")
+
+	machine, err := firecracker.NewMachine(ctx, firecracker.Config{}, firecracker.WithClient(
+		firecracker.NewClient("/path/to/socket", nil, false, firecracker.WithOpsClient(client))))
+	require.NoError(t, err, "failed to create new machine")
+
+	vmIsReady := make(chan struct{})
+	close(vmIsReady)
+
+	clock := &clockClient{}
+	return &service{
+		shimCtx:              ctx,
+		logger:               logrus.NewEntry(logrus.New()),
+		eventExchange:        exchange.NewExchange(),
+		vmReady:              vmIsReady,
+		live:                 vmHandle{machine: machine, vmClockClient: clock},
+		machineConfig:        &firecracker.Config{},
+		containerStubHandler: &StubDriveHandler{},
+		spareStubHandler:     &StubDriveHandler{},
+	}, clock
+}
+
+func TestResumeVMSyncsClock(t *testing.T) {
+	uut, clock := newClockTestService(t, &fctesting.MockClient{})
+
+	_, err := uut.ResumeVM(context.Background(), &proto.ResumeVMRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"resumed"}, clock.reasons)
+}
+
+func TestCreateSnapshotSyncsClock(t *testing.T) {
+	var states []string
+	uut, clock := newClockTestService(t, &fctesting.MockClient{
+		DescribeInstanceFn: func(*ops.DescribeInstanceParams) (*ops.DescribeInstanceOK, error) {
+			return &ops.DescribeInstanceOK{
+				Payload: &models.InstanceInfo{State: firecracker.String(models.InstanceInfoStateRunning)},
+			}, nil
+		},
+		PatchVMFn: func(params *ops.PatchVMParams) (*ops.PatchVMNoContent, error) {
+			states = append(states, *params.Body.State)
+			return nil, nil
+		},
+	})
+	uut.firecracker.Snapshots = true
+
+	dir := t.TempDir()
+	_, err := uut.CreateSnapshot(context.Background(), &proto.CreateSnapshotRequest{
+		MemFilePath:  filepath.Join(dir, "mem"),
+		SnapshotPath: filepath.Join(dir, "snapshot"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{models.VMStatePaused, models.VMStateResumed}, states)
+	assert.Equal(t, []string{"resumed after snapshot"}, clock.reasons)
+}
+
+func TestResumeFromSnapshotSyncsClock(t *testing.T) {
+	uut, clock := newClockTestService(t, &fctesting.MockClient{})
+
+	err := uut.resumeFromSnapshot(context.Background(), &snapshotState{VSockIOPortCount: 3})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(3), uut.vsockIOPortCount)
+	assert.Equal(t, []string{"restored from snapshot"}, clock.reasons)
+}
@@ -0,0 +1,311 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/containerd/containerd/protobuf/types"
+	"github.com/firecracker-microvm/firecracker-go-sdk"
+	fcclient "github.com/firecracker-microvm/firecracker-go-sdk/client"
+	"github.com/firecracker-microvm/firecracker-go-sdk/client/models"
+	ops "github.com/firecracker-microvm/firecracker-go-sdk/client/operations"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+const (
+	// snapshotStateSuffix is appended to the snapshot path to get the path of the
+	// file holding the shim state of a snapshotted VM.
+	snapshotStateSuffix = ".shim.json"
+
+	loadSnapshotHandlerName       = "firecracker-containerd-load-snapshot"
+	relativeStubDrivesHandlerName = "firecracker-containerd-relative-stub-drives"
+)
+
+// snapshotState is the part of the shim's state that a snapshot needs in order to be
+// restored. Firecracker only snapshots the VMM, so the restoring shim uses this to
+// recreate the same stub drive layout and to know which drives are in use by tasks.
+type snapshotState struct {
+	ContainerCount   int                            `json:"containerCount"`
+	DriveMounts      []*proto.FirecrackerDriveMount `json:"driveMounts"`
+	ContainerDrives  map[string]reservedDrive       `json:"containerDrives"`
+	SpareDriveCount  int                            `json:"spareDriveCount"`
+	SpareDrives      map[string]reservedDrive       `json:"spareDrives"`
+	VSockIOPortCount uint32                         `json:"vsockIOPortCount"`
+	// MemSizeMib is the memory of the VM, which the snapshot restores regardless of the
+	// machine configuration of the request. It is 0 for snapshots taken before it was recorded.
+	MemSizeMib uint32 `json:"memSizeMib,omitempty"`
+}
+
+// CreateSnapshot writes the guest memory and the VM state to the requested files, along with the
+// shim state needed to restore the VM through CreateVM. A running VM is paused while the
+// snapshot is taken and resumed afterwards.
+func (s *service) CreateSnapshot(requestCtx context.Context, request *proto.CreateSnapshotRequest) (*types.Empty, error) {
+	defer logPanicAndDie(s.logger)
+
+	err := s.waitVMReady()
+	if err != nil {
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
+
+	if request.MemFilePath == "" || request.SnapshotPath == "" {
+		return nil, status.Error(codes.InvalidArgument, "both MemFilePath and SnapshotPath must be specified")
+	}
+	if !s.firecracker.Snapshots {
+		return nil, status.Errorf(codes.FailedPrecondition, "Firecracker %s does not support snapshots", s.firecracker.Version)
+	}
+
+	if err := s.checkSnapshotSupport(); err != nil {
+		return nil, err
+	}
+
+	paused, err := s.isPaused(requestCtx)
+	if err != nil {
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
+
+	machine := s.vm().machine
+	if !paused {
+		if err := machine.PauseVM(requestCtx); err != nil {
+			err = fmt.Errorf("failed to pause VM before snapshot: %w", err)
+			s.logger.WithError(err).Error()
+			return nil, err
+		}
+		s.publishEvent(PauseEventName, &proto.VMPause{VMID: s.boundVMID(), Reason: "snapshot"})
+
+		defer func() {
+			if err := machine.ResumeVM(requestCtx); err != nil {
+				s.logger.WithError(err).Error("failed to resume VM after snapshot")
+				return
+			}
+			s.publishEvent(ResumeEventName, &proto.VMResume{VMID: s.boundVMID(), Reason: "snapshot"})
+			s.syncGuestClock(requestCtx, "resumed after snapshot")
+		}()
+	}
+
+	snapshotType := models.SnapshotCreateParamsSnapshotTypeFull
+	if request.SnapshotType == proto.SnapshotType_DIFF {
+		snapshotType = models.SnapshotCreateParamsSnapshotTypeDiff
+	}
+
+	err = machine.CreateSnapshot(requestCtx, request.MemFilePath, request.SnapshotPath,
+		func(params *ops.CreateSnapshotParams) {
+			params.Body.SnapshotType = snapshotType
+		})
+	if err != nil {
+		err = fmt.Errorf("failed to create snapshot: %w", err)
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
+
+	if err := s.writeSnapshotState(request.SnapshotPath + snapshotStateSuffix); err != nil {
+		err = fmt.Errorf("failed to write snapshot state: %w", err)
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
+
+	return &types.Empty{}, nil
+}
+
+// checkSnapshotSupport returns an error if the VM's jailer cannot be used with snapshots.
+// The runc jailer runs Firecracker in its own mount namespace, where the snapshot files
+// would not be visible at the paths provided by the caller.
+func (s *service) checkSnapshotSupport() error {
+	if _, ok := s.jailer.(*runcJailer); ok {
+		return status.Error(codes.Unimplemented, "snapshots are not supported for VMs using the runc jailer")
+	}
+	return nil
+}
+
+func (s *service) writeSnapshotState(path string) error {
+	count, reserved := s.containerStubHandler.reservedDrives()
+	spareCount, spareReserved := s.spareStubHandler.reservedDrives()
+
+	s.vsockPortMu.Lock()
+	portCount := s.vsockIOPortCount
+	s.vsockPortMu.Unlock()
+
+	data, err := json.Marshal(snapshotState{
+		ContainerCount:   count,
+		DriveMounts:      s.driveMounts,
+		ContainerDrives:  reserved,
+		SpareDriveCount:  spareCount,
+		SpareDrives:      spareReserved,
+		VSockIOPortCount: portCount,
+		MemSizeMib:       uint32(firecracker.Int64Value(s.machineConfig.MachineCfg.MemSizeMib)),
+	})
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0600)
+}
+
+func readSnapshotState(path string) (*snapshotState, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var state snapshotState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &state, nil
+}
+
+// prepareSnapshotLoad reads the shim state written alongside the snapshot and updates the
+// request so the restored VM gets the same stub drives as the snapshotted one.
+func (s *service) prepareSnapshotLoad(request *proto.CreateVMRequest) (*snapshotState, error) {
+	cfg := request.LoadSnapshot
+	if cfg.MemFilePath == "" || cfg.SnapshotPath == "" {
+		return nil, status.Error(codes.InvalidArgument, "both MemFilePath and SnapshotPath must be specified")
+	}
+
+	if err := s.checkSnapshotSupport(); err != nil {
+		return nil, err
+	}
+
+	state, err := readSnapshotState(cfg.SnapshotPath + snapshotStateSuffix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot state: %w", err)
+	}
+
+	request.ContainerCount = int32(state.ContainerCount)
+	request.DriveMounts = state.DriveMounts
+	request.SpareDriveCount = int32(state.SpareDriveCount)
+
+	// the memory of the jailer's cgroup is limited according to the memory of the VM
+	if state.MemSizeMib == 0 {
+		if request.JailerConfig.GetLimitMemory() {
+			return nil, status.Error(codes.FailedPrecondition,
+				"the memory of the VM cannot be limited as the snapshot doesn't record its size")
+		}
+		return state, nil
+	}
+	if request.MachineCfg == nil {
+		request.MachineCfg = &proto.FirecrackerMachineConfiguration{}
+	}
+	request.MachineCfg.MemSizeMib = state.MemSizeMib
+	return state, nil
+}
+
+// resumeFromSnapshot restores the shim state of a VM loaded from a snapshot and sets the clock
+// of the VM, which stopped when the snapshot was taken.
+func (s *service) resumeFromSnapshot(ctx context.Context, state *snapshotState) error {
+	// drives of the snapshotted VM are still mounted in the guest
+	if err := s.restoreSnapshotState(state); err != nil {
+		return err
+	}
+	s.syncGuestClock(ctx, "restored from snapshot")
+	return nil
+}
+
+// restoreSnapshotState marks the drives that were in use when the snapshot was taken as
+// reserved again. Those drives are still mounted inside the restored guest.
+func (s *service) restoreSnapshotState(state *snapshotState) error {
+	for id, drive := range state.ContainerDrives {
+		if err := s.containerStubHandler.markReserved(id, drive); err != nil {
+			return fmt.Errorf("failed to restore drive of %q: %w", id, err)
+		}
+		s.blockDeviceTasksMu.Lock()
+		s.blockDeviceTasks[id] = struct{}{}
+		s.blockDeviceTasksMu.Unlock()
+	}
+
+	for vmPath, drive := range state.SpareDrives {
+		if err := s.spareStubHandler.markReserved(vmPath, drive); err != nil {
+			return fmt.Errorf("failed to restore drive mount at %q: %w", vmPath, err)
+		}
+	}
+
+	s.vsockPortMu.Lock()
+	s.vsockIOPortCount = state.VSockIOPortCount
+	s.vsockPortMu.Unlock()
+
+	return nil
+}
+
+// withSnapshotLoad replaces the handlers that configure and boot a new VM with a handler
+// that loads the given snapshot. The devices of the VM are all part of the snapshot.
+func withSnapshotLoad(cfg *proto.LoadSnapshotConfig, logger *logrus.Entry) firecracker.Opt {
+	return func(m *firecracker.Machine) {
+		for _, name := range []string{
+			firecracker.CreateMachineHandlerName,
+			firecracker.CreateBootSourceHandlerName,
+			firecracker.AttachDrivesHandlerName,
+			firecracker.CreateNetworkInterfacesHandlerName,
+			firecracker.AddVsocksHandlerName,
+			firecracker.ConfigMmdsHandlerName,
+			firecracker.CreateBalloonHandlerName,
+		} {
+			m.Handlers.FcInit = m.Handlers.FcInit.Remove(name)
+		}
+		m.Handlers.FcInit = m.Handlers.FcInit.Append(newLoadSnapshotHandler(cfg, logger))
+	}
+}
+
+// newRelativeStubDrivesHandler returns a handler that attaches the stub drives located in the jail
+// by their path relative to it, which is also the working directory of Firecracker. This keeps
+// snapshots of the VM from referencing the directory of this particular shim, so they can be
+// restored by another one. It is only installed for VMs which can be snapshotted, whose noop
+// jailer runs Firecracker in the jail.
+func newRelativeStubDrivesHandler(jailPath string) firecracker.Handler {
+	return firecracker.Handler{
+		Name: relativeStubDrivesHandlerName,
+		Fn: func(ctx context.Context, m *firecracker.Machine) error {
+			for i, drive := range m.Cfg.Drives {
+				drivePath := firecracker.StringValue(drive.PathOnHost)
+				if filepath.Dir(drivePath) == jailPath {
+					m.Cfg.Drives[i].PathOnHost = firecracker.String(filepath.Base(drivePath))
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// newLoadSnapshotHandler returns a handler loading the snapshot and resuming the VM. The SDK's
+// Machine does not support loading snapshots, so the API is called through the generated client.
+func newLoadSnapshotHandler(cfg *proto.LoadSnapshotConfig, logger *logrus.Entry) firecracker.Handler {
+	return firecracker.Handler{
+		Name: loadSnapshotHandlerName,
+		Fn: func(ctx context.Context, m *firecracker.Machine) error {
+			client := fcclient.New(firecracker.NewUnixSocketTransport(m.Cfg.SocketPath, logger, false), nil)
+
+			params := ops.NewLoadSnapshotParamsWithContext(ctx)
+			params.SetBody(&models.SnapshotLoadParams{
+				MemFilePath:         firecracker.String(cfg.MemFilePath),
+				SnapshotPath:        firecracker.String(cfg.SnapshotPath),
+				EnableDiffSnapshots: cfg.EnableDiffSnapshots,
+				ResumeVM:            true,
+			})
+
+			if _, err := client.Operations.LoadSnapshot(params); err != nil {
+				return fmt.Errorf("failed to load snapshot: %w", err)
+			}
+			return nil
+		},
+	}
+}
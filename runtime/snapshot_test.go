@@ -0,0 +1,188 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/firecracker-microvm/firecracker-go-sdk"
+	models "github.com/firecracker-microvm/firecracker-go-sdk/client/models"
+	ops "github.com/firecracker-microvm/firecracker-go-sdk/client/operations"
+	"github.com/firecracker-microvm/firecracker-go-sdk/fctesting"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/firecracker-microvm/firecracker-containerd/config"
+	"github.com/firecracker-microvm/firecracker-containerd/internal"
+	"github.com/firecracker-microvm/firecracker-containerd/internal/debug"
+	"github.com/firecracker-microvm/firecracker-containerd/internal/vm"
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+func TestRelativeStubDrivesHandler(t *testing.T) {
+	machine := &firecracker.Machine{Cfg: firecracker.Config{
+		Drives: []models.Drive{
+			{PathOnHost: firecracker.String("/rootfs.img")},
+			{PathOnHost: firecracker.String("/shim/ctrstub0")},
+			{PathOnHost: firecracker.String("/shim/sub/ctrstub1")},
+		},
+	}}
+
+	require.NoError(t, newRelativeStubDrivesHandler("/shim").Fn(context.Background(), machine))
+	assert.Equal(t, "/rootfs.img", firecracker.StringValue(machine.Cfg.Drives[0].PathOnHost))
+	assert.Equal(t, "ctrstub0", firecracker.StringValue(machine.Cfg.Drives[1].PathOnHost))
+	assert.Equal(t, "/shim/sub/ctrstub1", firecracker.StringValue(machine.Cfg.Drives[2].PathOnHost))
+}
+
+func TestMachineOptsRelativeStubDrives(t *testing.T) {
+	debugHelper, err := debug.New()
+	require.NoError(t, err)
+
+	for _, snapshots := range []bool{true, false} {
+		logger := logrus.NewEntry(logrus.New())
+		uut := &service{
+			logger:        logger,
+			config:        &config.Config{DebugHelper: debugHelper},
+			machineConfig: &firecracker.Config{},
+			jailer:        newNoopJailer(context.Background(), logger, vm.Dir(t.TempDir())),
+			firecracker:   internal.FirecrackerCapabilities{Snapshots: snapshots},
+		}
+
+		opts, err := uut.machineOpts(context.Background(), &proto.CreateVMRequest{})
+		require.NoError(t, err)
+		opts = append(opts, firecracker.WithClient(firecracker.NewClient("/path/to/socket", nil, false,
+			firecracker.WithOpsClient(&fctesting.MockClient{}))))
+		machine, err := firecracker.NewMachine(context.Background(), firecracker.Config{}, opts...)
+		require.NoError(t, err)
+
+		assert.Equal(t, snapshots, machine.Handlers.FcInit.Has(relativeStubDrivesHandlerName),
+			"the stub drives are only made relative for VMs which can be snapshotted")
+	}
+}
+
+func TestSnapshotStateRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	logger := logrus.NewEntry(logrus.New())
+	driveMount := &proto.FirecrackerDriveMount{HostPath: "/data.img", VMPath: "/data", FilesystemType: "ext4"}
+	attached := &proto.FirecrackerDriveMount{HostPath: "/logs.img", VMPath: "/logs", FilesystemType: "ext4"}
+
+	// the snapshotted VM has a container running and a drive mount attached
+	snapshotted, _ := newClockTestService(t, &fctesting.MockClient{
+		DescribeInstanceFn: func(*ops.DescribeInstanceParams) (*ops.DescribeInstanceOK, error) {
+			return &ops.DescribeInstanceOK{
+				Payload: &models.InstanceInfo{State: firecracker.String(models.InstanceInfoStateRunning)},
+			}, nil
+		},
+	})
+	snapshotted.firecracker.Snapshots = true
+	snapshotted.jailer = newNoopJailer(ctx, logger, vm.Dir(t.TempDir()))
+	snapshotted.driveMounts = []*proto.FirecrackerDriveMount{driveMount}
+	snapshotted.vsockIOPortCount = 7
+	snapshotted.machineConfig.MachineCfg.MemSizeMib = firecracker.Int64(2048)
+
+	var err error
+	snapshotted.containerStubHandler, err = CreateContainerStubs(&firecracker.Config{}, snapshotted.jailer, 2, logger)
+	require.NoError(t, err)
+	require.NoError(t, snapshotted.containerStubHandler.markReserved("task",
+		reservedDrive{StubName: "ctrstub1", DriveMount: &proto.FirecrackerDriveMount{VMPath: "/container/rootfs"}}))
+	snapshotted.spareStubHandler, err = CreateSpareStubs(&firecracker.Config{}, snapshotted.jailer, 1, logger)
+	require.NoError(t, err)
+	require.NoError(t, snapshotted.spareStubHandler.markReserved("/logs", reservedDrive{StubName: "sparestub0", DriveMount: attached}))
+
+	dir := t.TempDir()
+	snapshotPath := filepath.Join(dir, "snapshot")
+	_, err = snapshotted.CreateSnapshot(ctx, &proto.CreateSnapshotRequest{
+		MemFilePath:  filepath.Join(dir, "mem"),
+		SnapshotPath: snapshotPath,
+	})
+	require.NoError(t, err)
+	written, err := readSnapshotState(snapshotPath + ".shim.json")
+	require.NoError(t, err)
+	assert.Equal(t, 2, written.ContainerCount)
+	assert.Equal(t, "ctrstub1", written.ContainerDrives["task"].StubName)
+	assert.Equal(t, 1, written.SpareDriveCount)
+	assert.Equal(t, "sparestub0", written.SpareDrives["/logs"].StubName)
+	assert.Equal(t, uint32(7), written.VSockIOPortCount)
+	assert.Equal(t, uint32(2048), written.MemSizeMib)
+
+	// the restoring shim creates the same stub drives and marks the same ones as used
+	restored, clock := newClockTestService(t, &fctesting.MockClient{})
+	restored.jailer = newNoopJailer(ctx, logger, vm.Dir(t.TempDir()))
+	request := &proto.CreateVMRequest{LoadSnapshot: &proto.LoadSnapshotConfig{
+		MemFilePath:  filepath.Join(dir, "mem"),
+		SnapshotPath: snapshotPath,
+	}}
+	state, err := restored.prepareSnapshotLoad(request)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), request.ContainerCount)
+	assert.Equal(t, int32(1), request.SpareDriveCount)
+	require.Len(t, request.DriveMounts, 1)
+	assert.True(t, protobuf.Equal(driveMount, request.DriveMounts[0]))
+	assert.Equal(t, uint32(2048), request.MachineCfg.GetMemSizeMib(), "the VM is restored with the memory it was snapshotted with")
+
+	restored.containerStubHandler, err = CreateContainerStubs(&firecracker.Config{}, restored.jailer, int(request.ContainerCount), logger)
+	require.NoError(t, err)
+	restored.spareStubHandler, err = CreateSpareStubs(&firecracker.Config{}, restored.jailer, int(request.SpareDriveCount), logger)
+	require.NoError(t, err)
+	restored.blockDeviceTasks = make(map[string]struct{})
+	require.NoError(t, restored.resumeFromSnapshot(ctx, state))
+
+	count, reserved := restored.containerStubHandler.reservedDrives()
+	assert.Equal(t, 2, count)
+	assert.Equal(t, []string{"task"}, keys(reserved))
+	assert.Equal(t, "ctrstub1", reserved["task"].StubName)
+	assert.Contains(t, restored.blockDeviceTasks, "task")
+
+	count, reserved = restored.spareStubHandler.reservedDrives()
+	assert.Equal(t, 1, count)
+	assert.Equal(t, "sparestub0", reserved["/logs"].StubName)
+	assert.True(t, protobuf.Equal(attached, reserved["/logs"].DriveMount))
+
+	assert.Equal(t, uint32(7), restored.vsockIOPortCount)
+	assert.Equal(t, []string{"restored from snapshot"}, clock.reasons)
+}
+
+func TestPrepareSnapshotLoadWithoutMemSize(t *testing.T) {
+	uut, _ := newClockTestService(t, &fctesting.MockClient{})
+	uut.jailer = newNoopJailer(context.Background(), uut.logger, vm.Dir(t.TempDir()))
+
+	// snapshots taken before the memory of the VM was recorded
+	snapshotPath := filepath.Join(t.TempDir(), "snapshot")
+	require.NoError(t, os.WriteFile(snapshotPath+".shim.json", []byte(`{"containerCount":1}`), 0600))
+	loadSnapshot := &proto.LoadSnapshotConfig{MemFilePath: "/mem", SnapshotPath: snapshotPath}
+
+	request := &proto.CreateVMRequest{LoadSnapshot: loadSnapshot}
+	_, err := uut.prepareSnapshotLoad(request)
+	require.NoError(t, err)
+	assert.Nil(t, request.MachineCfg)
+
+	request = &proto.CreateVMRequest{LoadSnapshot: loadSnapshot, JailerConfig: &proto.JailerConfig{LimitMemory: true}}
+	_, err = uut.prepareSnapshotLoad(request)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err), "the memory cannot be limited to an unknown size")
+}
+
+func keys(m map[string]reservedDrive) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
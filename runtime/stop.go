@@ -0,0 +1,167 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	taskAPI "github.com/containerd/containerd/api/runtime/task/v2"
+	"github.com/containerd/containerd/errdefs"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sys/unix"
+
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+)
+
+const (
+	// defaultStopGracePeriod is the time tasks have to exit after SIGTERM before they are sent
+	// SIGKILL, when the stop request doesn't set it.
+	defaultStopGracePeriod = 5 * time.Second
+	// taskKillTimeout is the time tasks have to exit after SIGKILL.
+	taskKillTimeout = 5 * time.Second
+	// ctrlAltDelTimeout is the time the VM has to exit after Ctrl+Alt+Del before its VMM is
+	// killed. It is given in addition to the timeout of the stop request, as that timeout may
+	// have been used up by the previous stages already.
+	ctrlAltDelTimeout = 5 * time.Second
+)
+
+// stopTimeout returns the time a stop with the given grace period and timeout may take, which
+// budgets the grace period and the time tasks have to exit after SIGKILL before the timeout,
+// the rest of the VM having the whole timeout to stop.
+func stopTimeout(gracePeriod, timeout time.Duration) time.Duration {
+	return gracePeriod + taskKillTimeout + timeout
+}
+
+// terminate stops the VM in stages of increasing force, each only tried if the previous one
+// failed:
+//  1. the tasks are sent SIGTERM and, if they haven't exited after the grace period, SIGKILL,
+//     then the agent shuts the VM down
+//  2. the VM is sent Ctrl+Alt+Del
+//  3. the VMM is killed
+//
+// A paused VM is resumed first so that it can take part in the first two stages. The stage the
+// VM stopped at is reported in the stop event.
+func (s *service) terminate(ctx context.Context, gracePeriod time.Duration) error {
+	s.beginStop("stop requested")
+
+	err := s.waitVMReady()
+	if err != nil {
+		s.logger.WithError(err).Error("failed to wait VM")
+		return s.forceTerminate(ctx, "VM did not become ready")
+	}
+
+	paused, err := s.isPaused(ctx)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to check VM")
+		return s.forceTerminate(ctx, "failed to get VM state")
+	}
+	if paused {
+		if err := s.vm().machine.ResumeVM(ctx); err != nil {
+			s.logger.WithError(err).Error("failed to resume VM")
+			return s.forceTerminate(ctx, "VM is paused")
+		}
+		s.publishEvent(ResumeEventName, &proto.VMResume{VMID: s.boundVMID(), Reason: "resumed to be stopped"})
+	}
+
+	err = s.shutdownThroughAgent(ctx, gracePeriod)
+	if err == nil {
+		return nil
+	}
+	s.logger.WithError(err).Warn("failed to shut down the VM through the agent")
+
+	err = s.sendCtrlAltDel()
+	if err == nil {
+		return nil
+	}
+	s.logger.WithError(err).Warn("failed to shut down the VM with Ctrl+Alt+Del")
+
+	return s.forceTerminate(ctx, "VM did not stop gracefully")
+}
+
+// shutdownThroughAgent stops the tasks of the VM, then has the agent shut the VM down and waits
+// for it to exit.
+func (s *service) shutdownThroughAgent(ctx context.Context, gracePeriod time.Duration) error {
+	agent, err := s.agent()
+	if err != nil {
+		return err
+	}
+
+	stage := proto.VMStopStage_TASKS_TERMINATED
+	if ids := s.taskManager.TaskIDs(); len(ids) > 0 {
+		if err := signalTasks(ctx, agent, ids, unix.SIGTERM, gracePeriod); err != nil {
+			s.logger.WithError(err).Warn("tasks did not exit on SIGTERM")
+
+			stage = proto.VMStopStage_TASKS_KILLED
+			if err := signalTasks(ctx, agent, ids, unix.SIGKILL, taskKillTimeout); err != nil {
+				return fmt.Errorf("tasks did not exit on SIGKILL: %w", err)
+			}
+		}
+	}
+
+	s.enterStopStage(stage)
+	if _, err := agent.Shutdown(ctx, &taskAPI.ShutdownRequest{ID: s.boundVMID(), Now: true}); err != nil {
+		return fmt.Errorf("agent failed to shut down the VM: %w", err)
+	}
+	if err := s.vm().machine.Wait(ctx); err != nil {
+		return fmt.Errorf("VM did not exit in time: %w", err)
+	}
+	return nil
+}
+
+// sendCtrlAltDel sends Ctrl+Alt+Del to the VM and waits for it to exit.
+func (s *service) sendCtrlAltDel() error {
+	ctx, cancel := context.WithTimeout(s.shimCtx, ctrlAltDelTimeout)
+	defer cancel()
+
+	s.enterStopStage(proto.VMStopStage_CTRL_ALT_DEL)
+	machine := s.vm().machine
+	if err := machine.Shutdown(ctx); err != nil {
+		return fmt.Errorf("failed to send Ctrl+Alt+Del: %w", err)
+	}
+	if err := machine.Wait(ctx); err != nil {
+		return fmt.Errorf("VM did not exit on Ctrl+Alt+Del in time: %w", err)
+	}
+	return nil
+}
+
+// signalTasks sends signal to all the processes of the given tasks and waits for up to timeout
+// for the tasks to exit. Tasks which have already been deleted are skipped.
+func signalTasks(ctx context.Context, agent taskAPI.TaskService, ids []string, signal unix.Signal, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var group errgroup.Group
+	for _, id := range ids {
+		id := id
+		group.Go(func() error {
+			_, err := agent.Kill(ctx, &taskAPI.KillRequest{ID: id, Signal: uint32(signal), All: true})
+			if err != nil && !isTaskGone(err) {
+				return fmt.Errorf("failed to send %s to task %q: %w", unix.SignalName(signal), id, err)
+			}
+			_, err = agent.Wait(ctx, &taskAPI.WaitRequest{ID: id})
+			if err != nil && !isTaskGone(err) {
+				return fmt.Errorf("failed to wait for task %q: %w", id, err)
+			}
+			return nil
+		})
+	}
+	return group.Wait()
+}
+
+// isTaskGone returns whether err is returned for a task which has exited or been deleted.
+func isTaskGone(err error) bool {
+	return errdefs.IsNotFound(errdefs.FromGRPC(err))
+}
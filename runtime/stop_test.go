@@ -0,0 +1,105 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	taskAPI "github.com/containerd/containerd/api/runtime/task/v2"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/protobuf/types"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/sys/unix"
+)
+
+// signaledTaskService fakes the tasks of an agent, which exit on any signal but the one they
+// ignore.
+type signaledTaskService struct {
+	taskAPI.TaskService
+
+	mu      sync.Mutex
+	ignored map[string]unix.Signal
+	exited  map[string]chan struct{}
+	signals map[string][]unix.Signal
+}
+
+func newSignaledTaskService(ignored map[string]unix.Signal) *signaledTaskService {
+	ts := &signaledTaskService{
+		ignored: ignored,
+		exited:  make(map[string]chan struct{}),
+		signals: make(map[string][]unix.Signal),
+	}
+	for id := range ignored {
+		ts.exited[id] = make(chan struct{})
+	}
+	return ts
+}
+
+func (ts *signaledTaskService) Kill(_ context.Context, req *taskAPI.KillRequest) (*types.Empty, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	exited, ok := ts.exited[req.ID]
+	if !ok {
+		return nil, errdefs.ToGRPCf(errdefs.ErrNotFound, "task %q not found", req.ID)
+	}
+	signal := unix.Signal(req.Signal)
+	ts.signals[req.ID] = append(ts.signals[req.ID], signal)
+	if signal != ts.ignored[req.ID] {
+		select {
+		case <-exited:
+		default:
+			close(exited)
+		}
+	}
+	return &types.Empty{}, nil
+}
+
+func (ts *signaledTaskService) Wait(ctx context.Context, req *taskAPI.WaitRequest) (*taskAPI.WaitResponse, error) {
+	ts.mu.Lock()
+	exited, ok := ts.exited[req.ID]
+	ts.mu.Unlock()
+	if !ok {
+		return nil, errdefs.ToGRPCf(errdefs.ErrNotFound, "task %q not found", req.ID)
+	}
+
+	select {
+	case <-exited:
+		return &taskAPI.WaitResponse{}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func TestSignalTasks(t *testing.T) {
+	ts := newSignaledTaskService(map[string]unix.Signal{
+		"graceful": 0,
+		"stubborn": unix.SIGTERM,
+	})
+	ids := []string{"graceful", "stubborn", "deleted"}
+
+	err := signalTasks(context.Background(), ts, ids, unix.SIGTERM, 100*time.Millisecond)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "a task ignoring SIGTERM must not have exited")
+
+	err = signalTasks(context.Background(), ts, ids, unix.SIGKILL, 100*time.Millisecond)
+	assert.NoError(t, err)
+
+	assert.Equal(t, map[string][]unix.Signal{
+		"graceful": {unix.SIGTERM, unix.SIGKILL},
+		"stubborn": {unix.SIGTERM, unix.SIGKILL},
+	}, ts.signals)
+}
@@ -0,0 +1,184 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/containerd/containerd/protobuf/types"
+	"github.com/firecracker-microvm/firecracker-go-sdk/vsock"
+	"golang.org/x/sys/unix"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+	vmcopy "github.com/firecracker-microvm/firecracker-containerd/proto/service/vmcopy/ttrpc"
+)
+
+// CopyToVM streams the archive of the request to the agent, which extracts it into the VM.
+func (s *service) CopyToVM(requestCtx context.Context, request *proto.CopyToVMRequest) (*types.Empty, error) {
+	defer logPanicAndDie(s.logger)
+
+	err := s.waitVMReady()
+	if err != nil {
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
+
+	if request.ArchivePath == "" || request.VMPath == "" {
+		return nil, status.Error(codes.InvalidArgument, "both an archive path and a VM path must be specified")
+	}
+
+	logger := s.logger.WithField("vm_path", request.VMPath)
+
+	// opening a FIFO blocks until the client opens it for writing
+	archive, err := os.Open(request.ArchivePath)
+	if err != nil {
+		err = fmt.Errorf("failed to open archive %q: %w", request.ArchivePath, err)
+		logger.WithError(err).Error()
+		return nil, err
+	}
+	defer archive.Close()
+
+	port := s.nextVSockPort()
+	err = s.copyOverVSock(requestCtx, port, func(conn io.ReadWriter) error {
+		_, err := io.Copy(conn, archive)
+		return err
+	}, func() error {
+		_, err := s.vm().vmCopyClient.Extract(requestCtx, &vmcopy.ExtractRequest{Port: port, Path: request.VMPath})
+		return err
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to copy to VM: %w", err)
+		logger.WithError(err).Error()
+		return nil, err
+	}
+
+	return &types.Empty{}, nil
+}
+
+// CopyFromVM writes the archive of a file or directory of the VM, which the agent streams, to
+// the archive path of the request.
+func (s *service) CopyFromVM(requestCtx context.Context, request *proto.CopyFromVMRequest) (*types.Empty, error) {
+	defer logPanicAndDie(s.logger)
+
+	err := s.waitVMReady()
+	if err != nil {
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
+
+	if request.ArchivePath == "" || request.VMPath == "" {
+		return nil, status.Error(codes.InvalidArgument, "both an archive path and a VM path must be specified")
+	}
+
+	logger := s.logger.WithField("vm_path", request.VMPath)
+
+	archive, err := createArchive(request.ArchivePath)
+	if err != nil {
+		err = fmt.Errorf("failed to open archive %q: %w", request.ArchivePath, err)
+		logger.WithError(err).Error()
+		return nil, err
+	}
+	defer archive.Close()
+
+	port := s.nextVSockPort()
+	err = s.copyOverVSock(requestCtx, port, func(conn io.ReadWriter) error {
+		_, err := io.Copy(archive, conn)
+		return err
+	}, func() error {
+		_, err := s.vm().vmCopyClient.Archive(requestCtx, &vmcopy.ArchiveRequest{Port: port, Path: request.VMPath})
+		return err
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to copy from VM: %w", err)
+		logger.WithError(err).Error()
+		return nil, err
+	}
+
+	return &types.Empty{}, nil
+}
+
+// createArchive opens the path CopyFromVM writes an archive to, which is either created or a
+// FIFO the client reads the archive from. Other existing files are never written to, so that
+// requests cannot overwrite arbitrary files of the host.
+func createArchive(path string) (*os.File, error) {
+	archive, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if !errors.Is(err, os.ErrExist) {
+		return archive, err
+	}
+
+	info, err := os.Lstat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.Mode()&os.ModeNamedPipe == 0 {
+		return nil, status.Errorf(codes.AlreadyExists, "%q already exists and is not a FIFO", path)
+	}
+
+	// opening a FIFO blocks until the client opens it for reading
+	archive, err = os.OpenFile(path, os.O_WRONLY|unix.O_NOFOLLOW, 0)
+	if err != nil {
+		return nil, err
+	}
+	// the FIFO might have been replaced since it was checked
+	if info, err = archive.Stat(); err != nil || info.Mode()&os.ModeNamedPipe == 0 {
+		archive.Close()
+		return nil, status.Errorf(codes.AlreadyExists, "%q already exists and is not a FIFO", path)
+	}
+	return archive, nil
+}
+
+// copyOverVSock calls the agent, which listens on the given port for the duration of the call,
+// while transferring the archive over a connection to that port. The connection is closed once
+// transfer returns, which marks the end of an archive sent to the agent.
+func (s *service) copyOverVSock(
+	requestCtx context.Context,
+	port uint32,
+	transfer func(conn io.ReadWriter) error,
+	call func() error,
+) error {
+	relVSockPath, err := s.jailer.JailPath().FirecrackerVSockRelPath()
+	if err != nil {
+		return fmt.Errorf("failed to get relative path to firecracker vsock: %w", err)
+	}
+
+	dialCtx, cancel := context.WithTimeout(requestCtx, defaultVSockConnectTimeout)
+	defer cancel()
+
+	copyDone := make(chan error, 1)
+	go func() {
+		// the agent might not be listening yet, which the dial retries on
+		conn, err := vsock.DialContext(dialCtx, relVSockPath, port, vsock.WithLogger(s.logger))
+		if err != nil {
+			copyDone <- fmt.Errorf("failed to dial vsock port %d: %w", port, err)
+			return
+		}
+		defer conn.Close()
+		copyDone <- transfer(conn)
+	}()
+
+	if err := call(); err != nil {
+		// the agent closes the connection if it accepted it, otherwise the dial must be stopped
+		cancel()
+		<-copyDone
+		return err
+	}
+
+	return <-copyDone
+}
@@ -0,0 +1,70 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCreateArchive(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "archive.tar")
+	archive, err := createArchive(path)
+	require.NoError(t, err)
+	_, err = archive.WriteString("archive")
+	require.NoError(t, err)
+	require.NoError(t, archive.Close())
+
+	_, err = createArchive(path)
+	assert.Equal(t, codes.AlreadyExists, status.Code(err), "an existing file must not be overwritten")
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "archive", string(contents))
+
+	link := filepath.Join(dir, "link")
+	require.NoError(t, os.Symlink(path, link))
+	_, err = createArchive(link)
+	assert.Equal(t, codes.AlreadyExists, status.Code(err), "a symlink must not be followed")
+
+	fifo := filepath.Join(dir, "fifo")
+	require.NoError(t, unix.Mkfifo(fifo, 0600))
+	read := make(chan string, 1)
+	go func() {
+		r, err := os.Open(fifo)
+		if err != nil {
+			read <- err.Error()
+			return
+		}
+		defer r.Close()
+		data, _ := io.ReadAll(r)
+		read <- string(data)
+	}()
+
+	archive, err = createArchive(fifo)
+	require.NoError(t, err)
+	_, err = archive.WriteString("streamed")
+	require.NoError(t, err)
+	require.NoError(t, archive.Close())
+	assert.Equal(t, "streamed", <-read)
+}
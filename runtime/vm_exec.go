@@ -0,0 +1,106 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/firecracker-microvm/firecracker-containerd/internal/vm"
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+	vmexec "github.com/firecracker-microvm/firecracker-containerd/proto/service/vmexec/ttrpc"
+)
+
+// ExecInVM runs a process in the root namespaces of the VM, outside of any container, with its
+// stdio connected to the FIFOs of the request. It returns the exit status of the process once it
+// has exited.
+func (s *service) ExecInVM(requestCtx context.Context, request *proto.ExecInVMRequest) (*proto.ExecInVMResponse, error) {
+	defer logPanicAndDie(s.logger)
+
+	err := s.waitVMReady()
+	if err != nil {
+		s.logger.WithError(err).Error()
+		return nil, err
+	}
+
+	if len(request.Args) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no args were specified")
+	}
+	if request.Terminal {
+		if request.StdoutPath == "" {
+			return nil, status.Error(codes.InvalidArgument, "a terminal requires a stdout path")
+		}
+		if request.StderrPath != "" {
+			return nil, status.Error(codes.InvalidArgument, "stderr is written to stdout with a terminal")
+		}
+	}
+
+	extraData := &proto.ExtraData{}
+	if request.StdinPath != "" {
+		extraData.StdinPort = s.nextVSockPort()
+	}
+	if request.StdoutPath != "" {
+		extraData.StdoutPort = s.nextVSockPort()
+	}
+	if request.StderrPath != "" {
+		extraData.StderrPort = s.nextVSockPort()
+	}
+
+	logger := s.logger.WithField("args", request.Args)
+	proxy, err := s.newIOProxy(logger, request.StdinPath, request.StdoutPath, request.StderrPath, extraData)
+	if err != nil {
+		err = fmt.Errorf("failed to create stdio proxy: %w", err)
+		logger.WithError(err).Error()
+		return nil, err
+	}
+
+	procCtx, procCancel := context.WithCancel(s.shimCtx)
+	defer procCancel()
+
+	initDone, copyDone := vm.StartIOProxy(procCtx, logger, proxy)
+	go func() {
+		if err := <-initDone; err != nil {
+			logger.WithError(err).Error("failed to initialize stdio")
+		}
+	}()
+
+	resp, err := s.vm().vmExecClient.Exec(requestCtx, &vmexec.ExecRequest{
+		Args:       request.Args,
+		Env:        request.Env,
+		Cwd:        request.Cwd,
+		Terminal:   request.Terminal,
+		Width:      request.Width,
+		Height:     request.Height,
+		StdinPort:  extraData.StdinPort,
+		StdoutPort: extraData.StdoutPort,
+		StderrPort: extraData.StderrPort,
+	})
+
+	// the output of the process has been written by the time it has exited, so its stdio can
+	// be flushed and closed
+	procCancel()
+	<-copyDone
+
+	if err != nil {
+		err = fmt.Errorf("failed to exec in VM: %w", err)
+		logger.WithError(err).Error()
+		return nil, err
+	}
+
+	logger.WithField("exit_status", resp.ExitStatus).Debug("exec in VM exited")
+	return &proto.ExecInVMResponse{ExitStatus: resp.ExitStatus}, nil
+}
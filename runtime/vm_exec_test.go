@@ -0,0 +1,194 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/firecracker-microvm/firecracker-containerd/internal/vm"
+	"github.com/firecracker-microvm/firecracker-containerd/proto"
+	vmexec "github.com/firecracker-microvm/firecracker-containerd/proto/service/vmexec/ttrpc"
+)
+
+// execAgent fakes the agent of a VM running a process which writes its stdin to stdout in upper
+// case and a warning to stderr, then exits with status 3. Its stdio is connected over the vsock
+// of the VM, like the agent's.
+type execAgent struct {
+	mu       sync.Mutex
+	conns    map[uint32]chan net.Conn
+	requests []*vmexec.ExecRequest
+}
+
+func serveExecAgent(t *testing.T, dir vm.Dir) *execAgent {
+	agent := &execAgent{conns: make(map[uint32]chan net.Conn)}
+
+	l, err := net.Listen("unix", dir.FirecrackerVSockPath())
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			t.Cleanup(func() { conn.Close() })
+
+			var port uint32
+			line, err := bufio.NewReaderSize(conn, 16).ReadString('\n')
+			if err == nil {
+				_, err = fmt.Sscanf(line, "CONNECT %d\n", &port)
+			}
+			if err == nil {
+				_, err = conn.Write([]byte("OK 1073741824\n"))
+			}
+			if !assert.NoError(t, err, "failed to accept a vsock connection") {
+				conn.Close()
+				continue
+			}
+			agent.conn(port) <- conn
+		}
+	}()
+	return agent
+}
+
+// conn returns the channel the connection to the given port is sent to once accepted.
+func (a *execAgent) conn(port uint32) chan net.Conn {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.conns[port] == nil {
+		a.conns[port] = make(chan net.Conn, 1)
+	}
+	return a.conns[port]
+}
+
+func (a *execAgent) Exec(ctx context.Context, req *vmexec.ExecRequest) (*vmexec.ExecResponse, error) {
+	a.mu.Lock()
+	a.requests = append(a.requests, req)
+	a.mu.Unlock()
+
+	var stdin, stdout, stderr net.Conn
+	for _, c := range []struct {
+		port uint32
+		conn *net.Conn
+	}{{req.StdinPort, &stdin}, {req.StdoutPort, &stdout}, {req.StderrPort, &stderr}} {
+		select {
+		case *c.conn = <-a.conn(c.port):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	input, err := io.ReadAll(stdin)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := stdout.Write([]byte(strings.ToUpper(string(input)))); err != nil {
+		return nil, err
+	}
+	if _, err := stderr.Write([]byte("warning\n")); err != nil {
+		return nil, err
+	}
+	stdout.Close()
+	stderr.Close()
+	return &vmexec.ExecResponse{ExitStatus: 3}, nil
+}
+
+func TestExecInVM(t *testing.T) {
+	uut, _ := newClockTestService(t, nil)
+	dir := vm.Dir(t.TempDir())
+	uut.jailer = newNoopJailer(uut.shimCtx, uut.logger, dir)
+	agent := serveExecAgent(t, dir)
+	uut.live.vmExecClient = agent
+
+	fifoDir := t.TempDir()
+	fifos := make(map[string]string)
+	for _, name := range []string{"stdin", "stdout", "stderr"} {
+		fifos[name] = filepath.Join(fifoDir, name)
+		require.NoError(t, unix.Mkfifo(fifos[name], 0600))
+	}
+
+	var (
+		wg             sync.WaitGroup
+		stdout, stderr []byte
+		stdoutErr      error
+		stderrErr      error
+	)
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, os.WriteFile(fifos["stdin"], []byte("hello\n"), 0600))
+	}()
+	go func() {
+		defer wg.Done()
+		stdout, stdoutErr = os.ReadFile(fifos["stdout"])
+	}()
+	go func() {
+		defer wg.Done()
+		stderr, stderrErr = os.ReadFile(fifos["stderr"])
+	}()
+
+	resp, err := uut.ExecInVM(context.Background(), &proto.ExecInVMRequest{
+		Args:       []string{"/bin/upcase"},
+		Env:        []string{"LANG=C"},
+		Cwd:        "/root",
+		StdinPath:  fifos["stdin"],
+		StdoutPath: fifos["stdout"],
+		StderrPath: fifos["stderr"],
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(3), resp.ExitStatus, "the exit status of the process is returned")
+
+	wg.Wait()
+	require.NoError(t, stdoutErr)
+	require.NoError(t, stderrErr)
+	assert.Equal(t, "HELLO\n", string(stdout))
+	assert.Equal(t, "warning\n", string(stderr))
+
+	require.Len(t, agent.requests, 1)
+	req := agent.requests[0]
+	assert.Equal(t, []string{"/bin/upcase"}, req.Args)
+	assert.Equal(t, []string{"LANG=C"}, req.Env)
+	assert.Equal(t, "/root", req.Cwd)
+	assert.Equal(t, []uint32{minVsockIOPort, minVsockIOPort + 1, minVsockIOPort + 2},
+		[]uint32{req.StdinPort, req.StdoutPort, req.StderrPort}, "each stdio stream gets its own vsock port")
+}
+
+func TestExecInVMValidation(t *testing.T) {
+	uut, _ := newClockTestService(t, nil)
+
+	for name, request := range map[string]*proto.ExecInVMRequest{
+		"no args":              {},
+		"terminal w/o stdout":  {Args: []string{"sh"}, Terminal: true},
+		"terminal with stderr": {Args: []string{"sh"}, Terminal: true, StdoutPath: "/out", StderrPath: "/err"},
+	} {
+		_, err := uut.ExecInVM(context.Background(), request)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), name)
+	}
+}
@@ -0,0 +1,247 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/containerd/fifo"
+	"github.com/sirupsen/logrus"
+)
+
+// firecrackerLogLine matches the lines Firecracker writes to its log, such as
+// "2022-02-03T19:58:51.123456789 [anonymous-instance:fc_api:INFO] API server started.".
+// The bracketed prefix holds the instance ID, the thread name, the level if it is shown and
+// the origin of the line if it is shown, separated by colons.
+var firecrackerLogLine = regexp.MustCompile(`^\S+ \[([^\]]*)\] ?(.*)$`)
+
+type firecrackerLogEntry struct {
+	level   logrus.Level
+	module  string
+	message string
+}
+
+// parseFirecrackerLogLine splits a line of the Firecracker log into its level, module and
+// message. The module is the origin of the line if Firecracker shows it, or else the thread
+// which wrote the line. Lines which are not formatted as expected, such as the ones of a
+// multi-line message, are returned as they are at the info level.
+func parseFirecrackerLogLine(line string) firecrackerLogEntry {
+	entry := firecrackerLogEntry{level: logrus.InfoLevel, message: line}
+
+	match := firecrackerLogLine.FindStringSubmatch(line)
+	if match == nil {
+		return entry
+	}
+
+	fields := strings.Split(match[1], ":")
+	for i, field := range fields {
+		level, ok := firecrackerLogLevel(field)
+		if !ok || i == 0 {
+			continue
+		}
+
+		entry.level = level
+		entry.message = match[2]
+		if i+1 < len(fields) {
+			entry.module = strings.Join(fields[i+1:], ":")
+		} else {
+			entry.module = fields[i-1]
+		}
+		break
+	}
+
+	return entry
+}
+
+func firecrackerLogLevel(level string) (logrus.Level, bool) {
+	switch level {
+	case "ERROR":
+		return logrus.ErrorLevel, true
+	case "WARN", "WARNING":
+		return logrus.WarnLevel, true
+	case "INFO":
+		return logrus.InfoLevel, true
+	case "DEBUG":
+		return logrus.DebugLevel, true
+	case "TRACE":
+		return logrus.TraceLevel, true
+	}
+	return logrus.InfoLevel, false
+}
+
+// rotatingFile is a file which is rotated when its size exceeds maxSize. The rotated files are
+// suffixed with their generation, starting from ".1" for the most recent one.
+type rotatingFile struct {
+	mu         sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	file *os.File
+	size int64
+}
+
+func newRotatingFile(path string, maxSize int64, maxBackups int) (*rotatingFile, error) {
+	f := &rotatingFile{path: path, maxSize: maxSize, maxBackups: maxBackups}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *rotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open %q: %w", f.path, err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat %q: %w", f.path, err)
+	}
+
+	f.file = file
+	f.size = info.Size()
+	return nil
+}
+
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.size > 0 && f.size+int64(len(p)) > f.maxSize {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+func (f *rotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return fmt.Errorf("failed to close %q: %w", f.path, err)
+	}
+
+	backup := func(generation int) string {
+		return fmt.Sprintf("%s.%d", f.path, generation)
+	}
+
+	if f.maxBackups == 0 {
+		if err := os.Remove(f.path); err != nil {
+			return fmt.Errorf("failed to remove %q: %w", f.path, err)
+		}
+	} else {
+		for generation := f.maxBackups - 1; generation > 0; generation-- {
+			err := os.Rename(backup(generation), backup(generation+1))
+			if err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to rotate %q: %w", backup(generation), err)
+			}
+		}
+		if err := os.Rename(f.path, backup(1)); err != nil {
+			return fmt.Errorf("failed to rotate %q: %w", f.path, err)
+		}
+	}
+
+	return f.open()
+}
+
+func (f *rotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.file.Close()
+}
+
+// forwardFirecrackerLogs logs the lines of the Firecracker log read from r until it is closed.
+// The lines are also written as they are to sink, if it isn't nil.
+func forwardFirecrackerLogs(r io.Reader, logger func() *logrus.Entry, sink io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if sink != nil {
+			if _, err := io.WriteString(sink, line+"\n"); err != nil {
+				return fmt.Errorf("failed to write Firecracker log file: %w", err)
+			}
+		}
+
+		entry := parseFirecrackerLogLine(line)
+		logger().WithField("module", entry.module).Log(entry.level, entry.message)
+	}
+	return scanner.Err()
+}
+
+// firecrackerLogger returns the logger Firecracker logs are forwarded to. It shares the output
+// of the shim logger, but is at the level Firecracker is configured with.
+func (s *service) firecrackerLogger() *logrus.Logger {
+	logger := logrus.New()
+	logger.SetOutput(s.logger.Logger.Out)
+	logger.SetFormatter(s.logger.Logger.Formatter)
+
+	level, err := logrus.ParseLevel(s.config.DebugHelper.GetFirecrackerLogLevel())
+	if err != nil {
+		// Firecracker defaults to the warning level
+		level = logrus.WarnLevel
+	}
+	logger.SetLevel(level)
+	return logger
+}
+
+// forwardLogs starts forwarding the logs Firecracker writes to the FIFO at the given path for
+// the lifetime of the shim.
+func (s *service) forwardLogs(path string) error {
+	logFifo, err := fifo.OpenFifo(s.shimCtx, path, syscall.O_RDONLY|syscall.O_NONBLOCK, 0)
+	if err != nil {
+		return fmt.Errorf("failed to open log fifo: %w", err)
+	}
+
+	var sink *rotatingFile
+	if cfg := s.config.FirecrackerLogFile; cfg.MaxSizeMib > 0 {
+		sink, err = newRotatingFile(s.shimDir.FirecrackerLogFilePath(), int64(cfg.MaxSizeMib)<<20, cfg.MaxBackups)
+		if err != nil {
+			logFifo.Close()
+			return fmt.Errorf("failed to create Firecracker log file: %w", err)
+		}
+	}
+
+	fcLogger := s.firecrackerLogger()
+	logger := func() *logrus.Entry {
+		// the VMID changes when a pooled VM is claimed
+		return fcLogger.WithFields(s.logger.Data).WithField("namespace", s.namespace)
+	}
+
+	go func() {
+		defer logFifo.Close()
+		var err error
+		if sink != nil {
+			defer sink.Close()
+			err = forwardFirecrackerLogs(logFifo, logger, sink)
+		} else {
+			err = forwardFirecrackerLogs(logFifo, logger, nil)
+		}
+		if err != nil && s.shimCtx.Err() == nil {
+			s.logger.WithError(err).Warn("stopped forwarding Firecracker logs")
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,121 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFirecrackerLogLine(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		line     string
+		expected firecrackerLogEntry
+	}{
+		{
+			name: "level",
+			line: "2022-02-03T19:58:51.123456789 [anonymous-instance:fc_api:WARN] The API server received a request",
+			expected: firecrackerLogEntry{
+				level:   logrus.WarnLevel,
+				module:  "fc_api",
+				message: "The API server received a request",
+			},
+		},
+		{
+			name: "origin",
+			line: "2022-02-03T19:58:51.123456789 [vm-1:fc_vcpu 0:ERROR:src/vmm/src/vstate/vcpu/mod.rs:42] Failure",
+			expected: firecrackerLogEntry{
+				level:   logrus.ErrorLevel,
+				module:  "src/vmm/src/vstate/vcpu/mod.rs:42",
+				message: "Failure",
+			},
+		},
+		{
+			name: "no level",
+			line: "2022-02-03T19:58:51.123456789 [anonymous-instance:main] Running Firecracker",
+			expected: firecrackerLogEntry{
+				level:   logrus.InfoLevel,
+				message: "2022-02-03T19:58:51.123456789 [anonymous-instance:main] Running Firecracker",
+			},
+		},
+		{
+			name: "continuation",
+			line: "    at src/main.rs",
+			expected: firecrackerLogEntry{
+				level:   logrus.InfoLevel,
+				message: "    at src/main.rs",
+			},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, parseFirecrackerLogLine(tc.line))
+		})
+	}
+}
+
+func TestForwardFirecrackerLogs(t *testing.T) {
+	logger, hook := test.NewNullLogger()
+	logger.SetLevel(logrus.InfoLevel)
+	entry := logger.WithField("vmID", "vm-1")
+
+	var sink bytes.Buffer
+	lines := "2022-02-03T19:58:51.123456789 [vm-1:main:INFO] Running Firecracker\n" +
+		"2022-02-03T19:58:51.123456789 [vm-1:main:DEBUG] Debugging\n"
+	err := forwardFirecrackerLogs(strings.NewReader(lines), func() *logrus.Entry { return entry }, &sink)
+	require.NoError(t, err)
+
+	assert.Equal(t, lines, sink.String(), "all the lines must be written to the sink")
+
+	require.Len(t, hook.AllEntries(), 1, "lines below the logger level must not be logged")
+	logged := hook.LastEntry()
+	assert.Equal(t, "Running Firecracker", logged.Message)
+	assert.Equal(t, "main", logged.Data["module"])
+	assert.Equal(t, "vm-1", logged.Data["vmID"])
+}
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fc.log")
+
+	f, err := newRotatingFile(path, 10, 2)
+	require.NoError(t, err)
+	defer f.Close()
+
+	for _, s := range []string{"aaaaaaaa\n", "bbbbbbbb\n", "cccccccc\n", "dddddddd\n"} {
+		_, err := f.Write([]byte(s))
+		require.NoError(t, err)
+	}
+
+	for name, expected := range map[string]string{
+		path:        "dddddddd\n",
+		path + ".1": "cccccccc\n",
+		path + ".2": "bbbbbbbb\n",
+	} {
+		contents, err := os.ReadFile(name)
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(contents))
+	}
+
+	_, err = os.Stat(path + ".3")
+	assert.True(t, os.IsNotExist(err), "only MaxBackups rotated files must be kept")
+}